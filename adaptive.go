@@ -0,0 +1,120 @@
+package pocketbase
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// AdaptivePaging configures the page size of FullList, which adapts to the latency and the
+// size of the pages, instead of fetching pages of 500 records: the size halves after a slow
+// or large page, and doubles after a page twice as fast and small as the thresholds.
+type AdaptivePaging struct {
+	// InitialSize is the size of the first page, 500 by default.
+	InitialSize int
+	// MinSize and MaxSize bound the page size, 50 and 1000 by default; PocketBase returns
+	// at most 1000 records per page.
+	MinSize int
+	MaxSize int
+	// MaxLatency is the latency of the slow pages; 0 ignores the latency.
+	MaxLatency time.Duration
+	// MaxBytes is the size of the large pages, in bytes; 0 ignores the size.
+	MaxBytes int
+}
+
+// WithAdaptivePaging adapts the page size of the FullList calls of the client, e.g. to keep
+// the pages of records with large json fields under a second:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithAdaptivePaging(pocketbase.AdaptivePaging{
+//		MaxLatency: time.Second,
+//		MaxBytes:   4 << 20,
+//	}))
+//
+// The pages after a resize are fetched at the offset of the records fetched so far, with a
+// size dividing the offset when one is close to the adapted size, or else skipping the
+// records fetched twice.
+func WithAdaptivePaging(paging AdaptivePaging) ClientOption {
+	return func(c *Client) {
+		if paging.InitialSize <= 0 {
+			paging.InitialSize = 500
+		}
+		if paging.MinSize <= 0 {
+			paging.MinSize = 50
+		}
+		if paging.MaxSize <= 0 {
+			paging.MaxSize = 1000
+		}
+		paging.MinSize = min(paging.MinSize, paging.MaxSize)
+		paging.InitialSize = min(max(paging.InitialSize, paging.MinSize), paging.MaxSize)
+		c.paging = &paging
+	}
+}
+
+// next returns the size of the page following a page of size, fetched in elapsed and
+// holding bytes.
+func (p AdaptivePaging) next(size int, elapsed time.Duration, bytes int64) int {
+	slow := p.MaxLatency > 0 && elapsed > p.MaxLatency
+	large := p.MaxBytes > 0 && bytes > int64(p.MaxBytes)
+	fast := p.MaxLatency <= 0 || elapsed < p.MaxLatency/2
+	small := p.MaxBytes <= 0 || bytes < int64(p.MaxBytes)/2
+	switch {
+	case slow || large:
+		size /= 2
+	case fast && small && (p.MaxLatency > 0 || p.MaxBytes > 0):
+		size *= 2
+	}
+	return min(max(size, p.MinSize), p.MaxSize)
+}
+
+// adaptiveList fetches all the records matching params with list, in pages of the adaptive
+// size of the client.
+func adaptiveList[T any](ctx context.Context, c *Client, params ParamsList, list func(context.Context, ParamsList) (ResponseList[T], error)) (ResponseList[T], error) {
+	var response ResponseList[T]
+	paging := *c.paging
+	received, ok := ctx.Value(responseSizeKey{}).(*atomic.Int64)
+	if !ok {
+		received = new(atomic.Int64)
+		ctx = context.WithValue(ctx, responseSizeKey{}, received)
+	}
+
+	size, offset := paging.InitialSize, 0
+	for {
+		params.Size = size
+		params.Page = offset/size + 1
+		skip := offset - (params.Page-1)*size
+
+		before, start := received.Load(), time.Now()
+		r, err := list(ctx, params)
+		if err != nil {
+			return response, err
+		}
+		if response.Items == nil {
+			response.Page = 1
+			response.PerPage = r.PerPage
+			response.TotalItems = r.TotalItems
+			response.TotalPages = r.TotalPages
+			response.Items = []T{}
+		}
+		if skip < len(r.Items) {
+			response.Items = append(response.Items, r.Items[skip:]...)
+			offset += len(r.Items) - skip
+		}
+
+		if len(r.Items) < size || offset >= r.TotalItems {
+			return response, nil
+		}
+		size = pageSize(offset, paging.next(size, time.Since(start), received.Load()-before), paging.MinSize)
+	}
+}
+
+// pageSize returns the size of the page at offset closest to the target size: the largest
+// size down to half the target which divides the offset, so that no record is fetched
+// twice, or the target size.
+func pageSize(offset, target, minSize int) int {
+	for size := target; size >= max(target/2, minSize, 1); size-- {
+		if offset%size == 0 {
+			return size
+		}
+	}
+	return target
+}
@@ -0,0 +1,116 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptivePaging_Next(t *testing.T) {
+	paging := AdaptivePaging{MinSize: 50, MaxSize: 1000, MaxLatency: time.Second, MaxBytes: 1 << 20}
+	assert.Equal(t, 250, paging.next(500, 2*time.Second, 0))
+	assert.Equal(t, 250, paging.next(500, 0, 2<<20))
+	assert.Equal(t, 1000, paging.next(500, 100*time.Millisecond, 1<<10))
+	assert.Equal(t, 500, paging.next(500, 700*time.Millisecond, 1<<10), "neither slow nor fast")
+	assert.Equal(t, 50, paging.next(60, 2*time.Second, 0))
+	assert.Equal(t, 1000, paging.next(1000, 0, 0))
+
+	// without thresholds, the size doesn't change
+	assert.Equal(t, 500, AdaptivePaging{MinSize: 50, MaxSize: 1000}.next(500, time.Hour, 1<<30))
+}
+
+func TestPageSize(t *testing.T) {
+	assert.Equal(t, 500, pageSize(0, 500, 50))
+	assert.Equal(t, 300, pageSize(300, 600, 50))
+	assert.Equal(t, 900, pageSize(900, 1000, 50))
+	assert.Equal(t, 125, pageSize(625, 250, 50))
+	// no divisor close enough, the records fetched twice are skipped
+	assert.Equal(t, 62, pageSize(625, 62, 50))
+}
+
+func TestWithAdaptivePaging(t *testing.T) {
+	const total = 1234
+	var (
+		mu    sync.Mutex
+		sizes []int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("perPage"))
+		mu.Lock()
+		sizes = append(sizes, perPage)
+		mu.Unlock()
+
+		items := []map[string]any{}
+		for i := (page - 1) * perPage; i < min(page*perPage, total); i++ {
+			items = append(items, map[string]any{"id": fmt.Sprintf("p%04d", i), "field": strings.Repeat("a", 100)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"page": page, "perPage": perPage, "totalItems": total, "totalPages": (total + perPage - 1) / perPage, "items": items,
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	// the pages of more than 16KB are halved
+	client := NewClient(srv.URL, WithAdaptivePaging(AdaptivePaging{InitialSize: 400, MinSize: 100, MaxBytes: 16 << 10}))
+	list, err := client.FullList("posts", ParamsList{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, total)
+	for i, item := range list.Items {
+		require.Equal(t, fmt.Sprintf("p%04d", i), item["id"])
+	}
+	assert.Equal(t, total, list.TotalItems)
+	assert.Equal(t, []int{400, 200, 100, 100, 100, 100, 100, 100, 100}, sizes)
+
+	// the fast pages are doubled, once the offset is a multiple of the new size
+	sizes = nil
+	type post struct {
+		ID string `json:"id"`
+	}
+	client = NewClient(srv.URL, WithAdaptivePaging(AdaptivePaging{InitialSize: 300, MaxLatency: time.Minute}))
+	posts, err := CollectionSet[post](client, "posts").FullList(ParamsList{})
+	require.NoError(t, err)
+	require.Len(t, posts.Items, total)
+	for i, item := range posts.Items {
+		require.Equal(t, fmt.Sprintf("p%04d", i), item.ID)
+	}
+	assert.Equal(t, []int{300, 300, 600, 600}, sizes)
+
+	// the records fetched twice are skipped
+	sizes = nil
+	client = NewClient(srv.URL, WithAdaptivePaging(AdaptivePaging{InitialSize: 125, MinSize: 60, MaxBytes: 4 << 10}))
+	list, err = client.FullList("posts", ParamsList{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, total)
+	for i, item := range list.Items {
+		require.Equal(t, fmt.Sprintf("p%04d", i), item["id"])
+	}
+	assert.Equal(t, []int{125, 62}, sizes[:2])
+}
+
+func TestWithAdaptivePaging_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithAdaptivePaging(AdaptivePaging{InitialSize: 1, MinSize: 1, MaxLatency: time.Minute}))
+	fixed := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+
+	want, err := fixed.FullList(migrations.PostsPublic, ParamsList{Sort: "id"})
+	require.NoError(t, err)
+	got, err := client.FullList(migrations.PostsPublic, ParamsList{Sort: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, want.Items, got.Items)
+}
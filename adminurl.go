@@ -0,0 +1,53 @@
+package pocketbase
+
+import "net/url"
+
+// AdminURL builds the URLs of the pages of the PocketBase dashboard, e.g. to link the
+// operators straight to a record from the error reports of internal tools:
+//
+//	log.Printf("can't sync post, see %s", client.AdminURL().Record("posts", id))
+//
+// The collections can be given by name or id.
+type AdminURL struct {
+	base string
+}
+
+// AdminURL returns the builder of the dashboard URLs of the PocketBase instance.
+func (c *Client) AdminURL() AdminURL {
+	return AdminURL{base: c.url + "/_/"}
+}
+
+// Dashboard returns the URL of the dashboard.
+func (a AdminURL) Dashboard() string {
+	return a.base
+}
+
+// Collection returns the URL of the records page of the collection.
+func (a AdminURL) Collection(collection string) string {
+	return a.page("collections", url.Values{"collection": {collection}})
+}
+
+// Records returns the URL of the records page of the collection filtered with the filter
+// expression, e.g. built by Filter.
+func (a AdminURL) Records(collection, filter string) string {
+	return a.page("collections", url.Values{"collection": {collection}, "filter": {filter}})
+}
+
+// Record returns the URL of the records page of the collection with the record opened.
+func (a AdminURL) Record(collection, id string) string {
+	return a.page("collections", url.Values{"collection": {collection}, "recordId": {id}})
+}
+
+// Logs returns the URL of the logs page.
+func (a AdminURL) Logs() string {
+	return a.page("logs", nil)
+}
+
+// page returns the URL of a page of the dashboard, routed by the fragment.
+func (a AdminURL) page(path string, query url.Values) string {
+	u := a.base + "#/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
@@ -0,0 +1,20 @@
+package pocketbase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_AdminURL(t *testing.T) {
+	admin := NewClient("https://pb.example.com/").AdminURL()
+	assert.Equal(t, "https://pb.example.com/_/", admin.Dashboard())
+	assert.Equal(t, "https://pb.example.com/_/#/collections?collection=posts", admin.Collection("posts"))
+	assert.Equal(t, "https://pb.example.com/_/#/collections?collection=posts&recordId=abc123", admin.Record("posts", "abc123"))
+	assert.Equal(t, "https://pb.example.com/_/#/collections?collection=posts&filter=title+%3D+%27a+%26+b%27",
+		admin.Records("posts", Filter("title = {:title}", map[string]any{"title": "a & b"})))
+	assert.Equal(t, "https://pb.example.com/_/#/logs", admin.Logs())
+
+	assert.Equal(t, "http://127.0.0.1:8090/pb/_/#/collections?collection=a%2Fb&recordId=x%23y",
+		NewClient("http://127.0.0.1:8090/pb").AdminURL().Record("a/b", "x#y"), "the parameters are escaped")
+}
@@ -0,0 +1,198 @@
+package pocketbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Aggregator computes a value over the records of Aggregate, over all of them or by
+	// group of a field.
+	Aggregator struct {
+		op    string
+		field string
+		by    string
+	}
+
+	// AggregateResult holds the values computed by Aggregate.
+	AggregateResult struct {
+		// Records is the number of aggregated records.
+		Records int
+
+		values map[Aggregator]map[string]float64
+	}
+)
+
+// Count counts the records.
+func Count() Aggregator {
+	return Aggregator{op: "count"}
+}
+
+// CountBy counts the records by value of the field, e.g. CountBy("status").
+func CountBy(field string) Aggregator {
+	return Count().By(field)
+}
+
+// Sum sums the numbers of the field.
+func Sum(field string) Aggregator {
+	return Aggregator{op: "sum", field: field}
+}
+
+// Min returns the lowest number of the field.
+func Min(field string) Aggregator {
+	return Aggregator{op: "min", field: field}
+}
+
+// Max returns the highest number of the field.
+func Max(field string) Aggregator {
+	return Aggregator{op: "max", field: field}
+}
+
+// By computes the value by group of the records with the same value of the field, e.g.
+// Sum("amount").By("status"). The records of a field with multiple values, e.g. the tags
+// of a multiple select field, are in the groups of all their values.
+func (a Aggregator) By(field string) Aggregator {
+	a.by = field
+	return a
+}
+
+// String returns the name of the aggregator, e.g. sum(amount) by status.
+func (a Aggregator) String() string {
+	s := a.op + "(" + a.field + ")"
+	if a.by != "" {
+		s += " by " + a.by
+	}
+	return s
+}
+
+// Aggregate computes the aggregators over the records of the collection matching the filter
+// of params, since PocketBase has no aggregation API:
+//
+//	result, err := client.Aggregate(ctx, "orders", pocketbase.ParamsList{Filters: "paid = true"},
+//		pocketbase.Sum("amount"), pocketbase.CountBy("status"))
+//	total := result.Value(pocketbase.Sum("amount"))
+//	byStatus := result.Groups(pocketbase.CountBy("status"))
+//
+// The records are streamed page by page, sorted by id, without their count and with only
+// the fields of the aggregators, so they are never all held in memory. The fields must be
+// fields of the records, not of their expanded relations; the values which aren't numbers,
+// e.g. null, are left out of the sums, minimums and maximums.
+func (c *Client) Aggregate(ctx context.Context, collection string, params ParamsList, aggregators ...Aggregator) (AggregateResult, error) {
+	result := AggregateResult{values: make(map[Aggregator]map[string]float64, len(aggregators))}
+	fields := []string{"id"}
+	for _, a := range aggregators {
+		result.values[a] = map[string]float64{}
+		for _, field := range []string{a.field, a.by} {
+			if field != "" && !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	params.Fields = strings.Join(fields, ",")
+	params.Expand = ""
+	params.skipTotal = true
+	err := keysetPages(ctx, c, collection, params, func(r ResponseList[json.RawMessage]) error {
+		for _, raw := range r.Items {
+			var record map[string]any
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.UseNumber()
+			if err := decoder.Decode(&record); err != nil {
+				return fmt.Errorf("[aggregate] can't unmarshal record, err %w", err)
+			}
+			result.Records++
+			for _, a := range aggregators {
+				result.add(a, record)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// add aggregates the record into the groups of its value of the group field.
+func (r AggregateResult) add(a Aggregator, record map[string]any) {
+	n, isNumber := 1.0, true
+	if a.op != "count" {
+		n, isNumber = aggregateNumber(record[a.field])
+	}
+	if !isNumber {
+		return
+	}
+
+	groups := r.values[a]
+	for _, group := range aggregateGroups(a.by, record) {
+		current, seen := groups[group]
+		switch {
+		case !seen:
+			groups[group] = n
+		case a.op == "count" || a.op == "sum":
+			groups[group] = current + n
+		case a.op == "min":
+			groups[group] = min(current, n)
+		case a.op == "max":
+			groups[group] = max(current, n)
+		}
+	}
+}
+
+// Value returns the value of an aggregator without group, 0 when no value was aggregated.
+func (r AggregateResult) Value(a Aggregator) float64 {
+	return r.values[a][""]
+}
+
+// Groups returns the values of an aggregator by group, keyed by the values of the group
+// field formatted as strings, e.g. "true" or "42"; the empty values are keyed by "".
+func (r AggregateResult) Groups(a Aggregator) map[string]float64 {
+	return r.values[a]
+}
+
+// aggregateGroups returns the groups of the record for the group field, the single group
+// "" without one.
+func aggregateGroups(by string, record map[string]any) []string {
+	if by == "" {
+		return []string{""}
+	}
+	values, ok := record[by].([]any)
+	if !ok {
+		return []string{aggregateKey(record[by])}
+	}
+	groups := make([]string, len(values))
+	for i, v := range values {
+		groups[i] = aggregateKey(v)
+	}
+	return groups
+}
+
+// aggregateKey formats a value of a group field.
+func aggregateKey(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+// aggregateNumber returns the number of a value, and whether it is one.
+func aggregateNumber(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
@@ -0,0 +1,75 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Aggregate(t *testing.T) {
+	pages := []string{
+		`{"id": "o1", "amount": 10, "status": "paid", "tags": ["a", "b"], "rush": true},
+		 {"id": "o2", "amount": 2.5, "status": "paid", "tags": ["a"], "rush": false}`,
+		`{"id": "o3", "amount": null, "status": "", "tags": [], "rush": false},
+		 {"id": "o4", "amount": -4, "status": "open", "tags": ["b"], "rush": true}`,
+		`{"id": "o5", "amount": 7, "status": "open", "tags": [], "rush": false}`,
+	}
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, fmt.Sprintf("%s|%s|%s|%s", q.Get("filter"), q.Get("sort"), q.Get("fields"), q.Get("skipTotal")))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 2, "totalItems": -1, "totalPages": -1, "items": [%s]}`, pages[len(queries)-1])
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithAdaptivePaging(AdaptivePaging{InitialSize: 2, MinSize: 2, MaxSize: 2}))
+
+	result, err := client.Aggregate(context.Background(), "orders", ParamsList{Filters: "paid = true", Sort: "-amount", Expand: "customer"},
+		Count(), Sum("amount"), Min("amount"), Max("amount"), CountBy("status"), Sum("amount").By("tags"), Max("amount").By("rush"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"paid = true|id|id,amount,status,tags,rush|1",
+		"(paid = true) && id > 'o2'|id|id,amount,status,tags,rush|1",
+		"(paid = true) && id > 'o4'|id|id,amount,status,tags,rush|1",
+	}, queries, "the pages are streamed by id with the fields of the aggregators")
+
+	assert.Equal(t, 5, result.Records)
+	assert.Equal(t, 5.0, result.Value(Count()))
+	assert.Equal(t, 15.5, result.Value(Sum("amount")), "null isn't a number")
+	assert.Equal(t, -4.0, result.Value(Min("amount")))
+	assert.Equal(t, 10.0, result.Value(Max("amount")))
+	assert.Equal(t, map[string]float64{"paid": 2, "": 1, "open": 2}, result.Groups(CountBy("status")))
+	assert.Equal(t, map[string]float64{"a": 12.5, "b": 6}, result.Groups(Sum("amount").By("tags")), "the multiple values are all grouped")
+	assert.Equal(t, map[string]float64{"true": 10, "false": 7}, result.Groups(Max("amount").By("rush")))
+	assert.Equal(t, "sum(amount) by tags", Sum("amount").By("tags").String())
+	assert.Nil(t, result.Groups(Sum("other")), "unknown aggregator")
+}
+
+func TestClient_Aggregate_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	posts := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	for _, field := range []string{"aggregate-a", "aggregate-b", "aggregate-a"} {
+		created, err := posts.Create(map[string]any{"field": field})
+		require.NoError(t, err)
+		defer func() {
+			_ = posts.Delete(created.ID)
+		}()
+	}
+
+	result, err := client.Aggregate(context.Background(), migrations.PostsPublic,
+		ParamsList{Filters: Filter("field ~ {:prefix}", map[string]any{"prefix": "aggregate-%"})},
+		Count(), CountBy("field"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Records)
+	assert.Equal(t, 3.0, result.Value(Count()))
+	assert.Equal(t, map[string]float64{"aggregate-a": 2, "aggregate-b": 1}, result.Groups(CountBy("field")))
+}
@@ -0,0 +1,139 @@
+// Package app assembles the PocketBase server executable.
+//
+// The server is configured with the PB_* environment variables documented
+// on config, or the matching CLI flags. Forks extend it by blank-importing
+// packages that add hooks, routes and jobs with Register, like migrations:
+//
+//	package main
+//
+//	import (
+//		"github.com/Forty2Co/pocketbase/app"
+//
+//		_ "example.com/fork/hooks"
+//		_ "github.com/Forty2Co/pocketbase/migrations"
+//	)
+//
+//	func main() {
+//		if err := app.Start(); err != nil {
+//			panic(err)
+//		}
+//	}
+package app
+
+import (
+	"os"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/plugins/jsvm"
+	"github.com/pocketbase/pocketbase/plugins/migratecmd"
+)
+
+var registered []func(*pocketbase.PocketBase)
+
+// Register adds a function extending the app, e.g. binding hooks or routes.
+// It's meant to be called from init, before Start.
+//
+//	func init() {
+//		app.Register(func(pb *pocketbase.PocketBase) {
+//			pb.OnServe().BindFunc(func(e *core.ServeEvent) error {
+//				e.Router.GET("/hello", hello)
+//				return e.Next()
+//			})
+//		})
+//	}
+func Register(fn func(*pocketbase.PocketBase)) {
+	registered = append(registered, fn)
+}
+
+// New creates the app configured from the environment, with the built-in
+// plugins and the registered extensions.
+func New() *pocketbase.PocketBase {
+	cfg := configFromEnv(os.Getenv)
+
+	pb := pocketbase.NewWithConfig(pocketbase.Config{
+		DefaultDataDir:       cfg.DataDir,
+		DefaultDev:           cfg.Dev,
+		DefaultEncryptionEnv: cfg.EncryptionEnv,
+	})
+
+	// JS hooks are reloaded on change in dev.
+	jsvm.MustRegister(pb, jsvm.Config{
+		HooksDir:   cfg.HooksDir,
+		HooksWatch: cfg.Dev,
+	})
+
+	// In dev, schema changes made in the dashboard generate Go migrations into the migrations package.
+	migratecmd.MustRegister(pb, pb.RootCmd, migratecmd.Config{
+		Dir:         cfg.MigrationsDir,
+		Automigrate: cfg.Dev,
+	})
+
+	if cfg.AdminEmail != "" && cfg.AdminPassword != "" {
+		pb.OnServe().BindFunc(bootstrapSuperuser(cfg.AdminEmail, cfg.AdminPassword))
+	}
+
+	pb.OnServe().BindFunc(bindSeed(cfg.SeedDir))
+
+	if cfg.BackupCron != "" {
+		pb.OnBootstrap().BindFunc(bindBackups(cfg.BackupCron, cfg.BackupKeep))
+	}
+
+	serveMaintenance := &maintenance{forced: cfg.Maintenance}
+	pb.OnServe().BindFunc(serveMaintenance.bind)
+	pb.OnServe().BindFunc((&probes{maintenance: serveMaintenance}).bind)
+
+	if cfg.DrainPeriod > 0 {
+		newDrainer(cfg.DrainPeriod).bind(pb)
+	}
+
+	pb.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		e.Router.Bind(gunzipBody())
+		return e.Next()
+	})
+
+	if cfg.RequestLog {
+		pb.OnServe().BindFunc(func(e *core.ServeEvent) error {
+			e.Router.Bind(requestLog(os.Stdout))
+			return e.Next()
+		})
+	}
+
+	if cfg.Tracing {
+		pb.OnServe().BindFunc(bindTracing)
+	}
+
+	extend(pb)
+
+	// Bound last, so the routes of the extensions take precedence.
+	pb.OnServe().BindFunc(bindPublic(cfg.PublicDir, cfg.IndexFallback))
+
+	serve := cmd.NewServeCommand(pb, true)
+	cfg.applyServeDefaults(serve)
+	serveLimits := &limits{}
+	serveLimits.bindFlags(serve, cfg)
+	pb.OnServe().BindFunc(serveLimits.apply)
+	serveCORS := &cors{}
+	serveCORS.bindFlags(serve, cfg)
+	pb.OnServe().BindFunc(serveCORS.apply(serve))
+	pb.RootCmd.AddCommand(cmd.NewSuperuserCommand(pb))
+	pb.RootCmd.AddCommand(newSchemaCommand(pb, cfg.MigrationsDir))
+	pb.RootCmd.AddCommand(newRollbackCommand(pb))
+	pb.RootCmd.AddCommand(newTokenCommand(pb))
+	pb.RootCmd.AddCommand(serve)
+
+	return pb
+}
+
+// Start creates the app and executes its command line.
+func Start() error {
+	return New().Execute()
+}
+
+// extend applies the registered extensions in registration order.
+func extend(pb *pocketbase.PocketBase) {
+	for _, fn := range registered {
+		fn(pb)
+	}
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	defer func(r []func(*pocketbase.PocketBase)) { registered = r }(registered)
+
+	var calls []int
+	Register(func(*pocketbase.PocketBase) { calls = append(calls, 1) })
+	Register(func(*pocketbase.PocketBase) { calls = append(calls, 2) })
+
+	pb := &pocketbase.PocketBase{}
+	extend(pb)
+	assert.Equal(t, []int{1, 2}, calls)
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/filesystem/blob"
+)
+
+const (
+	// backupJobID is the id of the cron job creating the scheduled backups.
+	backupJobID = "scheduledBackup"
+	// backupPrefix is the name prefix of the scheduled backups, the only ones rotated.
+	backupPrefix = "@scheduled_pb_backup_"
+)
+
+// bindBackups returns a bootstrap hook registering the cron job creating a backup
+// on schedule and keeping only the keep most recent ones, all of them if keep is 0.
+func bindBackups(schedule string, keep int) func(*core.BootstrapEvent) error {
+	return func(e *core.BootstrapEvent) error {
+		if err := e.Next(); err != nil {
+			return err
+		}
+
+		app := e.App
+		if err := app.Cron().Add(backupJobID, schedule, func() {
+			if err := scheduledBackup(app, keep); err != nil {
+				app.Logger().Error("Scheduled backup failed", "error", err)
+			}
+		}); err != nil {
+			return fmt.Errorf("invalid backup schedule %q: %w", schedule, err)
+		}
+		return nil
+	}
+}
+
+// scheduledBackup creates a backup and removes the scheduled backups beyond keep.
+func scheduledBackup(app core.App, keep int) error {
+	name := backupPrefix + time.Now().UTC().Format("20060102150405") + ".zip"
+	if err := app.CreateBackup(context.Background(), name); err != nil {
+		return fmt.Errorf("failed to create backup %q: %w", name, err)
+	}
+	if keep == 0 {
+		return nil
+	}
+
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	files, err := fsys.List(backupPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+	for _, f := range staleBackups(files, keep) {
+		if err := fsys.Delete(f.Key); err != nil {
+			return fmt.Errorf("failed to remove backup %q: %w", f.Key, err)
+		}
+	}
+	return nil
+}
+
+// staleBackups returns the backups beyond the keep most recent ones.
+func staleBackups(files []*blob.ListObject, keep int) []*blob.ListObject {
+	if len(files) <= keep {
+		return nil
+	}
+	files = slices.Clone(files)
+	slices.SortFunc(files, func(a, b *blob.ListObject) int {
+		return b.ModTime.Compare(a.ModTime)
+	})
+	return files[keep:]
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem/blob"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleBackups(t *testing.T) {
+	now := time.Now()
+	files := []*blob.ListObject{
+		{Key: "b", ModTime: now.Add(-2 * time.Hour)},
+		{Key: "a", ModTime: now.Add(-1 * time.Hour)},
+		{Key: "d", ModTime: now.Add(-4 * time.Hour)},
+		{Key: "c", ModTime: now.Add(-3 * time.Hour)},
+	}
+
+	var keys []string
+	for _, f := range staleBackups(files, 2) {
+		keys = append(keys, f.Key)
+	}
+	assert.Equal(t, []string{"c", "d"}, keys)
+	assert.Equal(t, "b", files[0].Key, "the input is not reordered")
+
+	assert.Empty(t, staleBackups(files, 4))
+	assert.Empty(t, staleBackups(nil, 2))
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// config is the server configuration read from environment variables.
+//
+// The values are the defaults of the matching CLI flags, so flags still take precedence.
+type config struct {
+	// DataDir is the data directory (PB_DATA_DIR, --dir), defaults to ./pb_data next to the executable.
+	DataDir string
+	// Dev enables the dev mode (PB_DEV, --dev), defaults to true when started with go run.
+	Dev bool
+	// EncryptionEnv is the env variable holding the settings encryption key (PB_ENCRYPTION_ENV, --encryptionEnv).
+	EncryptionEnv string
+	// HTTPAddr is the HTTP listen address (PB_HTTP_ADDR, serve --http).
+	HTTPAddr string
+	// HTTPSAddr is the HTTPS listen address (PB_HTTPS_ADDR, serve --https).
+	HTTPSAddr string
+	// Origins are the CORS allowed origins (PB_ORIGINS, comma separated, serve --origins).
+	Origins []string
+	// AdminEmail and AdminPassword are the credentials of the superuser created,
+	// or updated, on start (PB_ADMIN_EMAIL, PB_ADMIN_PASSWORD).
+	AdminEmail    string
+	AdminPassword string
+	// MigrationsDir is the directory the Go migrations are generated into (PB_MIGRATIONS_DIR),
+	// defaults to the migrations directory next to the data directory.
+	MigrationsDir string
+	// HooksDir is the directory of the pb_hooks/*.pb.js files (PB_HOOKS_DIR),
+	// defaults to the pb_hooks directory next to the data directory.
+	HooksDir string
+	// Tracing enables the OpenTelemetry tracing of the requests, when an OTLP endpoint is set
+	// (OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) and OTEL_SDK_DISABLED isn't.
+	Tracing bool
+	// BackupCron is the cron schedule of the backups (PB_BACKUP_CRON), e.g. "0 3 * * *".
+	BackupCron string
+	// BackupKeep is the number of scheduled backups kept (PB_BACKUP_KEEP), defaults to 7; 0 keeps all.
+	BackupKeep int
+	// PublicDir is the directory of the frontend (PB_PUBLIC_DIR), defaults to the pb_public
+	// directory next to the data directory. It's ignored when a frontend is embedded.
+	PublicDir string
+	// IndexFallback renders index.html for missing frontend paths (PB_INDEX_FALLBACK), defaults to true.
+	IndexFallback bool
+	// DrainPeriod is how long the shutdown waits for the in-flight requests, backups and realtime
+	// messages (PB_DRAIN_PERIOD), defaults to 10s; 0 disables the drain.
+	DrainPeriod time.Duration
+	// SeedDir is the directory of the JSON files seeding an empty database (PB_SEED_DIR),
+	// defaults to the seed directory next to the data directory.
+	SeedDir string
+	// RateLimits are the rate limit rules enabled on start (PB_RATE_LIMITS, serve --rateLimits),
+	// e.g. "*:auth=2/3s,/api/=300/10s@guest".
+	RateLimits string
+	// MaxBodySize is the max request body size (PB_MAX_BODY_SIZE, serve --maxBodySize), e.g. "64MB".
+	MaxBodySize string
+	// RequestLog logs every request as a JSON line to stdout (PB_REQUEST_LOG), defaults to true.
+	RequestLog bool
+	// CORSMethods are the CORS allowed methods (PB_CORS_METHODS, comma separated, serve --corsMethods).
+	CORSMethods []string
+	// CORSHeaders are the CORS allowed headers (PB_CORS_HEADERS, comma separated, serve --corsHeaders).
+	CORSHeaders []string
+	// Maintenance forces the maintenance mode (PB_MAINTENANCE), which otherwise is toggled
+	// by superusers with PUT /api/maintenance.
+	Maintenance bool
+}
+
+// configFromEnv reads the config from the environment.
+func configFromEnv(getenv func(string) string) config {
+	c := config{
+		DataDir:       getenv("PB_DATA_DIR"),
+		Dev:           strings.HasPrefix(os.Args[0], os.TempDir()),
+		EncryptionEnv: getenv("PB_ENCRYPTION_ENV"),
+		HTTPAddr:      getenv("PB_HTTP_ADDR"),
+		HTTPSAddr:     getenv("PB_HTTPS_ADDR"),
+		AdminEmail:    getenv("PB_ADMIN_EMAIL"),
+		AdminPassword: getenv("PB_ADMIN_PASSWORD"),
+		MigrationsDir: getenv("PB_MIGRATIONS_DIR"),
+		HooksDir:      getenv("PB_HOOKS_DIR"),
+		BackupCron:    getenv("PB_BACKUP_CRON"),
+		BackupKeep:    7,
+		PublicDir:     getenv("PB_PUBLIC_DIR"),
+		IndexFallback: true,
+		DrainPeriod:   10 * time.Second,
+		SeedDir:       getenv("PB_SEED_DIR"),
+		RateLimits:    getenv("PB_RATE_LIMITS"),
+		MaxBodySize:   getenv("PB_MAX_BODY_SIZE"),
+		RequestLog:    true,
+	}
+	if period, err := time.ParseDuration(getenv("PB_DRAIN_PERIOD")); err == nil && period >= 0 {
+		c.DrainPeriod = period
+	}
+	if keep, err := strconv.Atoi(getenv("PB_BACKUP_KEEP")); err == nil && keep >= 0 {
+		c.BackupKeep = keep
+	}
+	if getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		disabled, _ := strconv.ParseBool(getenv("OTEL_SDK_DISABLED"))
+		c.Tracing = !disabled
+	}
+	if maintenance, err := strconv.ParseBool(getenv("PB_MAINTENANCE")); err == nil {
+		c.Maintenance = maintenance
+	}
+	if requestLog, err := strconv.ParseBool(getenv("PB_REQUEST_LOG")); err == nil {
+		c.RequestLog = requestLog
+	}
+	if fallback, err := strconv.ParseBool(getenv("PB_INDEX_FALLBACK")); err == nil {
+		c.IndexFallback = fallback
+	}
+	if dev, err := strconv.ParseBool(getenv("PB_DEV")); err == nil {
+		c.Dev = dev
+	}
+	c.Origins = splitList(getenv("PB_ORIGINS"))
+	c.CORSMethods = splitList(getenv("PB_CORS_METHODS"))
+	c.CORSHeaders = splitList(getenv("PB_CORS_HEADERS"))
+	return c
+}
+
+// splitList splits a comma separated list, dropping the empty items.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// applyServeDefaults sets the config as defaults of the serve command flags.
+func (c config) applyServeDefaults(serve *cobra.Command) {
+	setFlagDefault(serve.PersistentFlags(), "http", c.HTTPAddr)
+	setFlagDefault(serve.PersistentFlags(), "https", c.HTTPSAddr)
+	if len(c.Origins) > 0 {
+		setFlagDefault(serve.PersistentFlags(), "origins", c.Origins...)
+	}
+}
+
+// setFlagDefault replaces the default value of a flag, if values are not empty.
+func setFlagDefault(flags *pflag.FlagSet, name string, values ...string) {
+	f := flags.Lookup(name)
+	if f == nil || len(values) == 0 || values[0] == "" {
+		return
+	}
+	if slice, ok := f.Value.(pflag.SliceValue); ok {
+		_ = slice.Replace(values)
+	} else {
+		_ = f.Value.Set(values[0])
+	}
+	f.DefValue = f.Value.String()
+}
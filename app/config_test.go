@@ -0,0 +1,90 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"PB_DATA_DIR":       "/data",
+		"PB_DEV":            "true",
+		"PB_ENCRYPTION_ENV": "PB_KEY",
+		"PB_HTTP_ADDR":      "0.0.0.0:8080",
+		"PB_HTTPS_ADDR":     "0.0.0.0:8443",
+		"PB_ORIGINS":        "https://a.example.com, https://b.example.com,",
+		"PB_ADMIN_EMAIL":    "admin@example.com",
+		"PB_ADMIN_PASSWORD": "secret",
+		"PB_MIGRATIONS_DIR": "./migrations",
+		"PB_HOOKS_DIR":      "./pb_hooks",
+		"PB_BACKUP_CRON":    "0 3 * * *",
+		"PB_BACKUP_KEEP":    "3",
+		"PB_PUBLIC_DIR":     "./dist",
+		"PB_INDEX_FALLBACK": "false",
+		"PB_DRAIN_PERIOD":   "30s",
+		"PB_SEED_DIR":       "./seed",
+		"PB_RATE_LIMITS":    "/api/=300/10s",
+		"PB_MAX_BODY_SIZE":  "64MB",
+		"PB_REQUEST_LOG":    "false",
+		"PB_CORS_METHODS":   "GET, POST",
+		"PB_CORS_HEADERS":   "Authorization,X-Request-Id",
+		"PB_MAINTENANCE":    "1",
+
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+	}
+	c := configFromEnv(func(key string) string { return env[key] })
+	assert.Equal(t, config{
+		DataDir:       "/data",
+		Dev:           true,
+		EncryptionEnv: "PB_KEY",
+		HTTPAddr:      "0.0.0.0:8080",
+		HTTPSAddr:     "0.0.0.0:8443",
+		Origins:       []string{"https://a.example.com", "https://b.example.com"},
+		AdminEmail:    "admin@example.com",
+		AdminPassword: "secret",
+		MigrationsDir: "./migrations",
+		HooksDir:      "./pb_hooks",
+		Tracing:       true,
+		BackupCron:    "0 3 * * *",
+		BackupKeep:    3,
+		PublicDir:     "./dist",
+		DrainPeriod:   30 * time.Second,
+		SeedDir:       "./seed",
+		RateLimits:    "/api/=300/10s",
+		MaxBodySize:   "64MB",
+		CORSMethods:   []string{"GET", "POST"},
+		CORSHeaders:   []string{"Authorization", "X-Request-Id"},
+		Maintenance:   true,
+	}, c)
+
+	empty := configFromEnv(func(string) string { return "" })
+	assert.Empty(t, empty.DataDir)
+	assert.Empty(t, empty.Origins)
+	assert.False(t, empty.Tracing)
+	assert.Equal(t, 7, empty.BackupKeep)
+	assert.True(t, empty.IndexFallback)
+	assert.True(t, empty.RequestLog)
+	assert.Equal(t, 10*time.Second, empty.DrainPeriod)
+
+	env["OTEL_SDK_DISABLED"] = "true"
+	assert.False(t, configFromEnv(func(key string) string { return env[key] }).Tracing)
+}
+
+func TestConfig_ApplyServeDefaults(t *testing.T) {
+	serve := cmd.NewServeCommand(nil, false)
+	config{HTTPAddr: "0.0.0.0:8080", Origins: []string{"https://a.example.com"}}.applyServeDefaults(serve)
+
+	flags := serve.PersistentFlags()
+	assert.Equal(t, "0.0.0.0:8080", flags.Lookup("http").Value.String())
+	assert.Equal(t, "", flags.Lookup("https").Value.String())
+	assert.Equal(t, "[https://a.example.com]", flags.Lookup("origins").Value.String())
+
+	// flags take precedence over the defaults
+	require.NoError(t, flags.Parse([]string{"--http=127.0.0.1:9000", "--origins=https://b.example.com"}))
+	assert.Equal(t, "127.0.0.1:9000", flags.Lookup("http").Value.String())
+	assert.Equal(t, "[https://b.example.com]", flags.Lookup("origins").Value.String())
+}
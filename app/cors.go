@@ -0,0 +1,54 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// defaultCORSMethods are the methods allowed by PocketBase.
+var defaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete}
+
+// cors are the CORS methods and headers applied on serve, together with the origins of serve --origins.
+type cors struct {
+	// methods are the allowed methods; empty allows the PocketBase defaults.
+	methods []string
+	// headers are the allowed headers; empty allows the headers requested by the preflight requests.
+	headers []string
+}
+
+// bindFlags adds the --corsMethods and --corsHeaders flags to the serve command, defaulting to the config.
+func (c *cors) bindFlags(serve *cobra.Command, cfg config) {
+	serve.PersistentFlags().StringSliceVar(&c.methods, "corsMethods", cfg.CORSMethods,
+		"CORS allowed methods (default GET,HEAD,PUT,PATCH,POST,DELETE)")
+	serve.PersistentFlags().StringSliceVar(&c.headers, "corsHeaders", cfg.CORSHeaders,
+		"CORS allowed headers (default the requested headers)")
+}
+
+// apply returns a serve hook replacing the CORS middleware of PocketBase.
+func (c *cors) apply(serve *cobra.Command) func(*core.ServeEvent) error {
+	return func(e *core.ServeEvent) error {
+		if len(c.methods) == 0 && len(c.headers) == 0 {
+			return e.Next()
+		}
+
+		origins, err := serve.PersistentFlags().GetStringSlice("origins")
+		if err != nil {
+			return err
+		}
+		methods := c.methods
+		if len(methods) == 0 {
+			methods = defaultCORSMethods
+		}
+
+		// same id as the default middleware, which it replaces
+		e.Router.Bind(apis.CORS(apis.CORSConfig{
+			AllowOrigins: origins,
+			AllowMethods: methods,
+			AllowHeaders: c.headers,
+		}))
+		return e.Next()
+	}
+}
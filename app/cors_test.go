@@ -0,0 +1,51 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS(t *testing.T) {
+	serve := cmd.NewServeCommand(nil, false)
+	var c cors
+	c.bindFlags(serve, config{CORSMethods: []string{"GET", "POST"}})
+	require.NoError(t, serve.PersistentFlags().Parse([]string{
+		"--origins=https://app.example.com",
+		"--corsHeaders=Authorization,X-Request-Id",
+	}))
+
+	r := router.NewRouter(func(w http.ResponseWriter, r *http.Request) (*core.RequestEvent, router.EventCleanupFunc) {
+		e := &core.RequestEvent{}
+		e.Response = w
+		e.Request = r
+		return e, nil
+	})
+	r.Bind(apis.CORS(apis.CORSConfig{AllowOrigins: []string{"*"}}))
+	r.GET("/api/health", func(e *core.RequestEvent) error { return e.NoContent(http.StatusNoContent) })
+	require.NoError(t, c.apply(serve)(&core.ServeEvent{Router: r}))
+	mux, err := r.BuildMux()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/health", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET,POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization,X-Request-Id", rec.Header().Get("Access-Control-Allow-Headers"))
+
+	req.Header.Set("Origin", "https://other.example.com")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
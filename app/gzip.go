@@ -0,0 +1,39 @@
+package app
+
+import (
+	"compress/gzip"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// gunzipBodyMiddlewareID is the id of the request middleware decoding the gzip bodies.
+const gunzipBodyMiddlewareID = "gunzipBody"
+
+// gunzipBody is a request middleware decoding the request bodies with a gzip
+// Content-Encoding, e.g. the large writes of the SDK clients with WithRequestCompression.
+// It runs before the body size limits, which apply to the decoded bodies.
+func gunzipBody() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       gunzipBodyMiddlewareID,
+		Priority: apis.DefaultBodyLimitMiddlewarePriority - 1,
+		Func: func(e *core.RequestEvent) error {
+			if !strings.EqualFold(strings.TrimSpace(e.Request.Header.Get("Content-Encoding")), "gzip") {
+				return e.Next()
+			}
+			body, err := gzip.NewReader(e.Request.Body)
+			if err != nil {
+				return e.BadRequestError("Invalid gzip request body.", err)
+			}
+			defer body.Close()
+
+			e.Request.Body = body
+			e.Request.ContentLength = -1
+			e.Request.Header.Del("Content-Encoding")
+			e.Request.Header.Del("Content-Length")
+			return e.Next()
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGunzipBody(t *testing.T) {
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(gunzipBody())
+	h.Bind(maxBodySize(16))
+	request := func(body []byte, encoding string) (string, error) {
+		e := &core.RequestEvent{}
+		e.Request = httptest.NewRequest(http.MethodPost, "/api/collections/posts/records", bytes.NewReader(body))
+		e.Request.Header.Set("Content-Encoding", encoding)
+		e.Response = httptest.NewRecorder()
+		var decoded string
+		err := h.Trigger(e, func(e *core.RequestEvent) error {
+			assert.Empty(t, e.Request.Header.Get("Content-Encoding"))
+			raw, err := io.ReadAll(e.Request.Body)
+			decoded = string(raw)
+			return err
+		})
+		return decoded, err
+	}
+	compress := func(s string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write([]byte(s))
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	body, err := request(compress(`{"field":"test"}`), "gzip")
+	require.NoError(t, err)
+	assert.Equal(t, `{"field":"test"}`, body)
+
+	body, err = request([]byte(`{"field":"plain"}`)[:16], "")
+	require.NoError(t, err)
+	assert.Equal(t, `{"field":"plain"`, body)
+
+	var apiErr *router.ApiError
+	_, err = request([]byte("not gzip"), "gzip")
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+
+	// the body limits apply to the decoded bodies
+	var maxBytesErr *http.MaxBytesError
+	_, err = request(compress(strings.Repeat("a", 64)), "GZIP")
+	assert.ErrorAs(t, err, &maxBytesErr)
+}
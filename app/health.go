@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	// livenessPath reports that the process is up, e.g. for the liveness probe of Kubernetes.
+	livenessPath = "/healthz"
+	// readinessPath reports that the instance can serve traffic, e.g. for the readiness probe.
+	readinessPath = "/readyz"
+)
+
+// probes serves the liveness and readiness endpoints. Unlike /api/health, the readiness
+// fails while the database is unavailable, migrations are pending or during maintenance,
+// so no traffic is routed to an instance which can't serve it.
+type probes struct {
+	maintenance *maintenance
+	// migrated caches that the migrations were applied, as they can't be unapplied while serving.
+	migrated atomic.Bool
+}
+
+// probeState is the body of the probe endpoints, with the failed checks of the readiness.
+type probeState struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// bind registers the probe routes on serve.
+func (p *probes) bind(e *core.ServeEvent) error {
+	e.Router.GET(livenessPath, p.live)
+	e.Router.GET(readinessPath, p.ready)
+	return e.Next()
+}
+
+func (p *probes) live(e *core.RequestEvent) error {
+	return e.JSON(http.StatusOK, probeState{Status: "ok"})
+}
+
+func (p *probes) ready(e *core.RequestEvent) error {
+	checks := p.check(e.App)
+	if len(checks) > 0 {
+		return e.JSON(http.StatusServiceUnavailable, probeState{Status: "unavailable", Checks: checks})
+	}
+	return e.JSON(http.StatusOK, probeState{Status: "ok"})
+}
+
+// check returns the failed readiness checks by name.
+func (p *probes) check(app core.App) map[string]string {
+	checks := map[string]string{}
+	if p.maintenance != nil && p.maintenance.active() {
+		checks["maintenance"] = "enabled"
+	}
+
+	if !app.IsBootstrapped() {
+		checks["database"] = "not open"
+		return checks
+	}
+	if _, err := app.DB().NewQuery("SELECT 1").Execute(); err != nil {
+		checks["database"] = err.Error()
+		return checks
+	}
+
+	if !p.migrated.Load() {
+		pending, err := pendingMigrations(app)
+		switch {
+		case err != nil:
+			checks["migrations"] = err.Error()
+		case pending > 0:
+			checks["migrations"] = fmt.Sprintf("%d pending", pending)
+		default:
+			p.migrated.Store(true)
+		}
+	}
+	return checks
+}
+
+// pendingMigrations returns the number of app migrations which aren't applied yet.
+func pendingMigrations(app core.App) (int, error) {
+	files := appMigrationFiles()
+	if len(files) == 0 {
+		return 0, nil
+	}
+
+	var applied int
+	err := app.DB().Select("count(*)").
+		From(core.DefaultMigrationsTable).
+		Where(dbx.HashExp{"file": files}).
+		Row(&applied)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count the applied migrations: %w", err)
+	}
+	return len(files) - applied, nil
+}
+
+// appMigrationFiles returns the files of the registered app migrations.
+func appMigrationFiles() []any {
+	var files []any
+	for _, m := range core.AppMigrations.Items() {
+		files = append(files, m.File)
+	}
+	return files
+}
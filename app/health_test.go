@@ -0,0 +1,38 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbes(t *testing.T) {
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: t.TempDir()})
+	m := &maintenance{forced: true}
+	p := &probes{maintenance: m}
+	probe := func(handler func(*core.RequestEvent) error) (int, probeState) {
+		e := &core.RequestEvent{App: app}
+		e.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		e.Response = recorder
+		require.NoError(t, handler(e))
+
+		var state probeState
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &state))
+		return recorder.Code, state
+	}
+
+	code, state := probe(p.live)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", state.Status)
+
+	code, state = probe(p.ready)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", state.Status)
+	assert.Equal(t, map[string]string{"maintenance": "enabled", "database": "not open"}, state.Checks)
+}
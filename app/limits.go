@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/spf13/cobra"
+)
+
+// maxBodySizeMiddlewareID is the id of the request middleware limiting the body size.
+const maxBodySizeMiddlewareID = "maxBodySize"
+
+// limits are the rate limit rules and the request body size limit applied on serve,
+// overriding the settings of the dashboard on every start.
+type limits struct {
+	// rateLimits are the rate limit rules, see parseRateLimits; empty keeps the settings.
+	rateLimits string
+	// maxBodySize caps the request body size of all routes, see parseSize;
+	// empty keeps the PocketBase limits, derived from the max file sizes of the collections.
+	maxBodySize string
+}
+
+// bindFlags adds the --rateLimits and --maxBodySize flags to the serve command,
+// defaulting to the config.
+func (l *limits) bindFlags(serve *cobra.Command, c config) {
+	serve.PersistentFlags().StringVar(&l.rateLimits, "rateLimits", c.RateLimits,
+		"comma separated rate limit rules, e.g. \"*:auth=2/3s,/api/=300/10s@guest\"")
+	serve.PersistentFlags().StringVar(&l.maxBodySize, "maxBodySize", c.MaxBodySize,
+		"max request body size of all routes, including uploads, e.g. \"64MB\"")
+}
+
+// apply is a serve hook enabling the rate limit rules and the body size limit.
+func (l *limits) apply(e *core.ServeEvent) error {
+	if l.rateLimits != "" {
+		rules, err := parseRateLimits(l.rateLimits)
+		if err != nil {
+			return err
+		}
+		settings := e.App.Settings()
+		settings.RateLimits.Enabled = true
+		settings.RateLimits.Rules = rules
+		if err := e.App.Save(settings); err != nil {
+			return fmt.Errorf("failed to save the rate limits: %w", err)
+		}
+	}
+
+	if l.maxBodySize != "" {
+		size, err := parseSize(l.maxBodySize)
+		if err != nil {
+			return err
+		}
+		e.Router.Bind(maxBodySize(size))
+	}
+
+	return e.Next()
+}
+
+// maxBodySize is a request middleware rejecting the bodies larger than limit. It applies
+// on top of the PocketBase body limit, which routes like the record ones override.
+func maxBodySize(limit int64) *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       maxBodySizeMiddlewareID,
+		Priority: apis.DefaultBodyLimitMiddlewarePriority,
+		Func: func(e *core.RequestEvent) error {
+			if e.Request.ContentLength > limit {
+				return e.Error(http.StatusRequestEntityTooLarge, "Request entity too large.", nil)
+			}
+			e.Request.Body = http.MaxBytesReader(e.Response, e.Request.Body, limit)
+			return e.Next()
+		},
+	}
+}
+
+// parseRateLimits parses comma separated "label=maxRequests/duration[@audience]" rules,
+// e.g. "*:auth=2/3s" or "/api/=300/10s@guest". The duration is rounded to seconds.
+func parseRateLimits(s string) ([]core.RateLimitRule, error) {
+	var rules []core.RateLimitRule
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		i := strings.LastIndex(raw, "=")
+		if i < 1 {
+			return nil, fmt.Errorf("invalid rate limit rule %q", raw)
+		}
+		rule := core.RateLimitRule{Label: strings.TrimSpace(raw[:i])}
+
+		limit := raw[i+1:]
+		if j := strings.Index(limit, "@"); j >= 0 {
+			limit, rule.Audience = limit[:j], limit[j:]
+		}
+		count, period, ok := strings.Cut(limit, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit rule %q", raw)
+		}
+		var err error
+		if rule.MaxRequests, err = strconv.Atoi(count); err != nil {
+			return nil, fmt.Errorf("invalid max requests of rate limit rule %q: %w", raw, err)
+		}
+		d, err := time.ParseDuration(period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration of rate limit rule %q: %w", raw, err)
+		}
+		rule.Duration = int64(d.Round(time.Second) / time.Second)
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseSize parses a size in bytes, with an optional KB, MB or GB suffix (powers of 1024).
+func parseSize(raw string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	unit := int64(1)
+	for suffix, size := range map[string]int64{"KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30} {
+		if strings.HasSuffix(s, suffix) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, suffix)), size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSuffix(s, "B"), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", raw)
+	}
+	return n * unit, nil
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	rules, err := parseRateLimits("*:auth=2/3s, /api/=300/10s@guest,POST /api/collections/=10/1m@auth,")
+	require.NoError(t, err)
+	assert.Equal(t, []core.RateLimitRule{
+		{Label: "*:auth", MaxRequests: 2, Duration: 3},
+		{Label: "/api/", MaxRequests: 300, Duration: 10, Audience: "@guest"},
+		{Label: "POST /api/collections/", MaxRequests: 10, Duration: 60, Audience: "@auth"},
+	}, rules)
+
+	for _, invalid := range []string{"/api/", "=1/1s", "/api/=1", "/api/=x/1s", "/api/=1/x"} {
+		_, err := parseRateLimits(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for s, size := range map[string]int64{
+		"1024":  1024,
+		"512B":  512,
+		"64KB":  64 << 10,
+		"64 MB": 64 << 20,
+		"1gb":   1 << 30,
+	} {
+		n, err := parseSize(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, size, n, s)
+	}
+
+	for _, invalid := range []string{"", "MB", "-1", "1TB"} {
+		_, err := parseSize(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLimits_BindFlags(t *testing.T) {
+	serve := cmd.NewServeCommand(nil, false)
+	var l limits
+	l.bindFlags(serve, config{RateLimits: "/api/=300/10s", MaxBodySize: "64MB"})
+	assert.Equal(t, "/api/=300/10s", l.rateLimits)
+	assert.Equal(t, "64MB", l.maxBodySize)
+
+	require.NoError(t, serve.PersistentFlags().Parse([]string{"--maxBodySize=1GB"}))
+	assert.Equal(t, "1GB", l.maxBodySize)
+}
+
+func TestMaxBodySize(t *testing.T) {
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(maxBodySize(4))
+	request := func(body string, contentLength int64) error {
+		e := &core.RequestEvent{}
+		e.Request = httptest.NewRequest(http.MethodPost, "/api/collections/posts/records", strings.NewReader(body))
+		e.Request.ContentLength = contentLength
+		e.Response = httptest.NewRecorder()
+		return h.Trigger(e, func(e *core.RequestEvent) error {
+			_, err := io.ReadAll(e.Request.Body)
+			return err
+		})
+	}
+
+	assert.NoError(t, request("abcd", 4))
+
+	var apiErr *router.ApiError
+	require.ErrorAs(t, request("abcde", 5), &apiErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, apiErr.Status)
+
+	// chunked bodies are cut while reading
+	var maxBytesErr *http.MaxBytesError
+	assert.ErrorAs(t, request("abcde", -1), &maxBytesErr)
+}
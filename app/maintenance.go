@@ -0,0 +1,138 @@
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+const (
+	// maintenanceMiddlewareID is the id of the request middleware rejecting the calls during maintenance.
+	maintenanceMiddlewareID = "maintenance"
+	// maintenanceParam is the id of the param persisting the maintenance mode, next to the settings.
+	maintenanceParam = "maintenance"
+)
+
+// maintenance rejects the API calls of non-superusers with 503 while enabled, e.g.
+// during migrations and restores. It's enabled by the env, or toggled at runtime by
+// superusers with PUT /api/maintenance, which persists across restarts.
+type maintenance struct {
+	// forced enables the maintenance regardless of the persisted mode.
+	forced  bool
+	enabled atomic.Bool
+}
+
+// maintenanceState is the body of the maintenance route.
+type maintenanceState struct {
+	Enabled bool `json:"enabled"`
+}
+
+// bind registers the middleware and the route on serve.
+func (m *maintenance) bind(e *core.ServeEvent) error {
+	enabled, err := m.load(e.App)
+	if err != nil {
+		return err
+	}
+	m.enabled.Store(enabled)
+
+	e.Router.Bind(m.middleware())
+	route := e.Router.Group("/api/maintenance")
+	route.Bind(apis.RequireSuperuserAuth())
+	route.GET("", m.get)
+	route.PUT("", m.set)
+	return e.Next()
+}
+
+// active reports whether the maintenance mode is enabled.
+func (m *maintenance) active() bool {
+	return m.forced || m.enabled.Load()
+}
+
+// middleware rejects the API calls of non-superusers during maintenance, except the ones
+// needed by superusers to sign in, manage backups and end the maintenance.
+func (m *maintenance) middleware() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       maintenanceMiddlewareID,
+		Priority: apis.DefaultLoadAuthTokenMiddlewarePriority + 1,
+		Func: func(e *core.RequestEvent) error {
+			if !m.active() || e.HasSuperuserAuth() || !maintenanceApplies(e.Request.URL.Path) {
+				return e.Next()
+			}
+			return e.Error(http.StatusServiceUnavailable, "The server is under maintenance.", nil)
+		},
+	}
+}
+
+// maintenanceApplies reports whether the maintenance mode applies to an URL path.
+func maintenanceApplies(path string) bool {
+	if !strings.HasPrefix(path, "/api/") {
+		return false // dashboard and frontend
+	}
+	for _, prefix := range []string{
+		"/api/health",
+		"/api/maintenance",
+		"/api/backups",
+		"/api/collections/" + core.CollectionNameSuperusers + "/",
+	} {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *maintenance) get(e *core.RequestEvent) error {
+	return e.JSON(http.StatusOK, maintenanceState{Enabled: m.active()})
+}
+
+func (m *maintenance) set(e *core.RequestEvent) error {
+	var state maintenanceState
+	if err := e.BindBody(&state); err != nil {
+		return e.BadRequestError("Invalid maintenance state.", err)
+	}
+	if err := m.save(e.App, state.Enabled); err != nil {
+		return e.InternalServerError("Failed to save the maintenance state.", err)
+	}
+	m.enabled.Store(state.Enabled)
+	return m.get(e)
+}
+
+// load reads the persisted maintenance mode.
+func (m *maintenance) load(app core.App) (bool, error) {
+	var raw string
+	err := app.DB().Select("value").From("_params").
+		Where(dbx.HashExp{"id": maintenanceParam}).
+		Row(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var state maintenanceState
+	err = json.Unmarshal([]byte(raw), &state)
+	return state.Enabled, err
+}
+
+// save persists the maintenance mode.
+func (m *maintenance) save(app core.App, enabled bool) error {
+	raw, err := json.Marshal(maintenanceState{Enabled: enabled})
+	if err != nil {
+		return err
+	}
+	_, err = app.DB().NewQuery(`
+		INSERT INTO {{_params}} ([[id]], [[value]]) VALUES ({:id}, {:value})
+		ON CONFLICT ([[id]]) DO UPDATE SET
+			[[value]] = excluded.[[value]],
+			[[updated]] = strftime('%Y-%m-%d %H:%M:%fZ')
+	`).Bind(dbx.Params{"id": maintenanceParam, "value": string(raw)}).Execute()
+	return err
+}
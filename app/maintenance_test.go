@@ -0,0 +1,45 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	m := &maintenance{}
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(m.middleware())
+	request := func(path string, auth *core.Record) error {
+		e := &core.RequestEvent{Auth: auth}
+		e.Request = httptest.NewRequest(http.MethodGet, path, nil)
+		e.Response = httptest.NewRecorder()
+		return h.Trigger(e, func(*core.RequestEvent) error { return nil })
+	}
+	user := core.NewRecord(core.NewAuthCollection("users"))
+	superuser := core.NewRecord(core.NewAuthCollection(core.CollectionNameSuperusers))
+
+	assert.NoError(t, request("/api/collections/posts/records", user))
+
+	m.enabled.Store(true)
+	var apiErr *router.ApiError
+	require.ErrorAs(t, request("/api/collections/posts/records", user), &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+	assert.Error(t, request("/api/collections/posts/records", nil))
+
+	assert.NoError(t, request("/api/collections/posts/records", superuser))
+	assert.NoError(t, request("/api/collections/_superusers/auth-with-password", nil))
+	assert.NoError(t, request("/api/health", nil))
+	assert.NoError(t, request("/_/", nil))
+	assert.NoError(t, request("/index.html", user))
+
+	m.enabled.Store(false)
+	m.forced = true
+	assert.Error(t, request("/api/collections/posts/records", user))
+}
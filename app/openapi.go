@@ -0,0 +1,336 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// openAPIVersion is the version of the OpenAPI specification of the generated documents.
+const openAPIVersion = "3.0.3"
+
+// openAPIDocument returns an OpenAPI document describing the record endpoints of the
+// non-system collections, with a schema of the records and request bodies per collection.
+func openAPIDocument(collections []*core.Collection, serverURL string) map[string]any {
+	paths := map[string]any{}
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"status":  map[string]any{"type": "integer"},
+				"message": map[string]any{"type": "string"},
+				"data":    map[string]any{"type": "object", "additionalProperties": true},
+			},
+		},
+	}
+
+	for _, c := range collections {
+		if c.System {
+			continue
+		}
+		name := schemaName(c.Name)
+		schemas[name] = recordSchema(c)
+		if !c.IsView() {
+			schemas[name+"Body"] = recordBodySchema(c)
+		}
+		for path, item := range collectionPaths(c, name) {
+			paths[path] = item
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{
+			"title":   "PocketBase records API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"token": map[string]any{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        "Authorization",
+					"description": "Auth token of a superuser or an auth record.",
+				},
+			},
+		},
+		"security": []any{map[string]any{"token": []any{}}},
+	}
+	if serverURL != "" {
+		doc["servers"] = []any{map[string]any{"url": serverURL}}
+	}
+	return doc
+}
+
+// schemaName returns the schema name of a collection, e.g. PostsPublic for posts_public.
+func schemaName(collection string) string {
+	parts := strings.FieldsFunc(collection, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	for i, part := range parts {
+		parts[i] = inflector.UcFirst(part)
+	}
+	return strings.Join(parts, "")
+}
+
+// recordSchema returns the schema of the records of the collection, without the hidden fields.
+func recordSchema(c *core.Collection) map[string]any {
+	properties := map[string]any{
+		"collectionId":   map[string]any{"type": "string"},
+		"collectionName": map[string]any{"type": "string"},
+	}
+	required := []string{"collectionId", "collectionName"}
+	for _, f := range c.Fields {
+		if f.GetHidden() {
+			continue
+		}
+		properties[f.GetName()] = fieldSchema(f)
+		required = append(required, f.GetName())
+	}
+	sort.Strings(required)
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// recordBodySchema returns the schema of the create and update bodies of the collection.
+// The required fields only apply to the creation.
+func recordBodySchema(c *core.Collection) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for _, f := range c.Fields {
+		name := f.GetName()
+		switch {
+		case f.Type() == core.FieldTypeAutodate || name == core.FieldNameTokenKey:
+			continue
+		case name == core.FieldNameId:
+			properties[name] = map[string]any{"type": "string", "description": "Optional custom id."}
+		case name == core.FieldNamePassword:
+			properties[name] = map[string]any{"type": "string", "format": "password"}
+			properties[name+"Confirm"] = map[string]any{"type": "string", "format": "password"}
+			required = append(required, name, name+"Confirm")
+		default:
+			properties[name] = fieldSchema(f)
+			if fieldRequired(f) {
+				required = append(required, name)
+			}
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// fieldRequired reports whether the field is required, if it has such an option.
+func fieldRequired(f core.Field) bool {
+	switch f := f.(type) {
+	case *core.TextField:
+		return f.Required
+	case *core.EditorField:
+		return f.Required
+	case *core.NumberField:
+		return f.Required
+	case *core.BoolField:
+		return f.Required
+	case *core.EmailField:
+		return f.Required
+	case *core.URLField:
+		return f.Required
+	case *core.DateField:
+		return f.Required
+	case *core.JSONField:
+		return f.Required
+	case *core.SelectField:
+		return f.Required
+	case *core.FileField:
+		return f.Required
+	case *core.RelationField:
+		return f.Required
+	case *core.GeoPointField:
+		return f.Required
+	}
+	return false
+}
+
+// fieldSchema returns the schema of the values of a field.
+func fieldSchema(f core.Field) map[string]any {
+	switch f := f.(type) {
+	case *core.NumberField:
+		if f.OnlyInt {
+			return map[string]any{"type": "integer"}
+		}
+		return map[string]any{"type": "number"}
+	case *core.BoolField:
+		return map[string]any{"type": "boolean"}
+	case *core.EmailField:
+		return map[string]any{"type": "string", "format": "email"}
+	case *core.URLField:
+		return map[string]any{"type": "string", "format": "uri"}
+	case *core.DateField, *core.AutodateField:
+		return map[string]any{"type": "string", "description": `Date as "2006-01-02 15:04:05.000Z", or empty.`}
+	case *core.JSONField:
+		return map[string]any{"nullable": true}
+	case *core.GeoPointField:
+		return map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"lon": map[string]any{"type": "number"},
+				"lat": map[string]any{"type": "number"},
+			},
+		}
+	case *core.SelectField:
+		return multiple(map[string]any{"type": "string", "enum": f.Values}, f.IsMultiple())
+	case *core.FileField:
+		return multiple(map[string]any{"type": "string", "description": "File name."}, f.IsMultiple())
+	case *core.RelationField:
+		return multiple(map[string]any{"type": "string", "description": "Related record id."}, f.IsMultiple())
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func multiple(item map[string]any, isMultiple bool) map[string]any {
+	if !isMultiple {
+		return item
+	}
+	return map[string]any{"type": "array", "items": item}
+}
+
+// collectionPaths returns the path items of the record endpoints of the collection.
+func collectionPaths(c *core.Collection, name string) map[string]any {
+	base := "/api/collections/" + c.Name
+	record := ref(name)
+	list := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"page":       map[string]any{"type": "integer"},
+			"perPage":    map[string]any{"type": "integer"},
+			"totalItems": map[string]any{"type": "integer"},
+			"totalPages": map[string]any{"type": "integer"},
+			"items":      map[string]any{"type": "array", "items": record},
+		},
+	}
+
+	records := map[string]any{
+		"get": operation("list"+name, "Lists the "+c.Name+" records.", c.Name, listParameters(), nil, list),
+	}
+	one := map[string]any{
+		"parameters": []any{pathParameter("id")},
+		"get":        operation("view"+name, "Returns a "+c.Name+" record.", c.Name, recordParameters(), nil, record),
+	}
+	paths := map[string]any{
+		base + "/records":      records,
+		base + "/records/{id}": one,
+	}
+	if c.IsView() {
+		return paths
+	}
+
+	body := ref(name + "Body")
+	records["post"] = operation("create"+name, "Creates a "+c.Name+" record.", c.Name, recordParameters(), body, record)
+	one["patch"] = operation("update"+name, "Updates a "+c.Name+" record.", c.Name, recordParameters(), body, record)
+	one["delete"] = operation("delete"+name, "Deletes a "+c.Name+" record.", c.Name, nil, nil, nil)
+
+	if c.IsAuth() && c.PasswordAuth.Enabled {
+		paths[base+"/auth-with-password"] = map[string]any{
+			"post": operation("authWithPassword"+name, "Authenticates a "+c.Name+" record.", c.Name, nil,
+				map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"identity": map[string]any{"type": "string"},
+						"password": map[string]any{"type": "string", "format": "password"},
+					},
+					"required": []string{"identity", "password"},
+				},
+				map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"token":  map[string]any{"type": "string"},
+						"record": record,
+					},
+				}),
+		}
+	}
+	return paths
+}
+
+// operation returns an operation, without request body when body is nil and with an
+// empty 204 response when result is nil.
+func operation(id, summary, tag string, parameters []any, body, result map[string]any) map[string]any {
+	op := map[string]any{
+		"operationId": id,
+		"summary":     summary,
+		"tags":        []string{tag},
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+	if body != nil {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json":    map[string]any{"schema": body},
+				"multipart/form-data": map[string]any{"schema": body},
+			},
+		}
+	}
+
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content":     map[string]any{"application/json": map[string]any{"schema": ref("Error")}},
+	}
+	responses := map[string]any{"default": errorResponse}
+	if result == nil {
+		responses["204"] = map[string]any{"description": "No content"}
+	} else {
+		responses["200"] = map[string]any{
+			"description": "Success",
+			"content":     map[string]any{"application/json": map[string]any{"schema": result}},
+		}
+	}
+	op["responses"] = responses
+	return op
+}
+
+func ref(schema string) map[string]any {
+	return map[string]any{"$ref": fmt.Sprintf("#/components/schemas/%s", schema)}
+}
+
+func pathParameter(name string) map[string]any {
+	return map[string]any{"name": name, "in": "path", "required": true, "schema": map[string]any{"type": "string"}}
+}
+
+func queryParameter(name, typ, description string) map[string]any {
+	return map[string]any{"name": name, "in": "query", "description": description, "schema": map[string]any{"type": typ}}
+}
+
+func recordParameters() []any {
+	return []any{
+		queryParameter("expand", "string", "Relations to expand, e.g. author,comments_via_post."),
+		queryParameter("fields", "string", "Fields to return, e.g. id,title."),
+	}
+}
+
+func listParameters() []any {
+	return append([]any{
+		queryParameter("page", "integer", "Page, from 1."),
+		queryParameter("perPage", "integer", "Records per page."),
+		queryParameter("sort", "string", "Sort fields, e.g. -created,id."),
+		queryParameter("filter", "string", "Filter expression, e.g. status = 'published'."),
+		queryParameter("skipTotal", "boolean", "Skips the total counts."),
+	}, recordParameters()...)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	posts := core.NewBaseCollection("posts_public")
+	posts.Fields.Add(
+		&core.TextField{Name: "title", Required: true},
+		&core.SelectField{Name: "tags", Values: []string{"a", "b"}, MaxSelect: 2},
+		&core.RelationField{Name: "author", CollectionId: "users", MaxSelect: 1},
+		&core.TextField{Name: "secret", Hidden: true},
+		&core.AutodateField{Name: "created", OnCreate: true},
+	)
+	users := core.NewAuthCollection("users")
+	view := core.NewViewCollection("stats", "select 1")
+	superusers := core.NewAuthCollection(core.CollectionNameSuperusers)
+	superusers.System = true
+
+	doc := openAPIDocument([]*core.Collection{posts, users, view, superusers}, "https://pb.example.com")
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var parsed struct {
+		OpenAPI string                    `json:"openapi"`
+		Servers []map[string]string       `json:"servers"`
+		Paths   map[string]map[string]any `json:"paths"`
+		Comps   struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, "3.0.3", parsed.OpenAPI)
+	assert.Equal(t, "https://pb.example.com", parsed.Servers[0]["url"])
+
+	assert.Contains(t, parsed.Paths["/api/collections/posts_public/records"], "post")
+	assert.Contains(t, parsed.Paths["/api/collections/posts_public/records/{id}"], "delete")
+	assert.Contains(t, parsed.Paths, "/api/collections/users/auth-with-password")
+	assert.NotContains(t, parsed.Paths["/api/collections/stats/records"], "post")
+	assert.NotContains(t, parsed.Paths, "/api/collections/_superusers/records")
+
+	record := parsed.Comps.Schemas["PostsPublic"]
+	assert.NotContains(t, record.Properties, "secret")
+	assert.Equal(t, "array", record.Properties["tags"]["type"])
+	assert.Equal(t, "string", record.Properties["author"]["type"])
+
+	body := parsed.Comps.Schemas["PostsPublicBody"]
+	assert.Equal(t, []string{"title"}, body.Required)
+	assert.NotContains(t, body.Properties, "created")
+	assert.Contains(t, parsed.Comps.Schemas["UsersBody"].Properties, "passwordConfirm")
+	assert.NotContains(t, parsed.Comps.Schemas["UsersBody"].Properties, "tokenKey")
+	assert.NotContains(t, parsed.Comps.Schemas, "StatsBody")
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// publicFS is the embedded frontend set with SetPublicFS.
+var publicFS fs.FS
+
+// SetPublicFS serves the frontend from fsys instead of the pb_public directory,
+// so a single binary ships both the API and the UI:
+//
+//	//go:embed all:dist
+//	var dist embed.FS
+//
+//	func main() {
+//		ui, _ := fs.Sub(dist, "dist")
+//		app.SetPublicFS(ui)
+//		...
+//	}
+func SetPublicFS(fsys fs.FS) {
+	publicFS = fsys
+}
+
+// bindPublic returns a serve hook serving the frontend on the routes not handled by the API.
+// With indexFallback, missing paths render index.html for client-side routing.
+func bindPublic(dir string, indexFallback bool) func(*core.ServeEvent) error {
+	return func(e *core.ServeEvent) error {
+		if !e.Router.HasRoute(http.MethodGet, "/{path...}") {
+			e.Router.GET("/{path...}", apis.Static(frontendFS(e.App, dir), indexFallback))
+		}
+		return e.Next()
+	}
+}
+
+// frontendFS returns the embedded frontend, if any, or the public directory,
+// which defaults to pb_public next to the data directory.
+func frontendFS(app core.App, dir string) fs.FS {
+	if publicFS != nil {
+		return publicFS
+	}
+	if dir == "" {
+		dir = filepath.Join(app.DataDir(), "../pb_public")
+	}
+	return os.DirFS(dir)
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontendFS(t *testing.T) {
+	defer SetPublicFS(nil)
+
+	dir := t.TempDir()
+	assert.Equal(t, os.DirFS(dir), frontendFS(nil, dir))
+
+	ui := fstest.MapFS{"index.html": {Data: []byte("<html>app</html>")}}
+	SetPublicFS(ui)
+	require.Equal(t, ui, frontendFS(nil, dir))
+
+	// client-side routes render the index
+	e := &core.RequestEvent{}
+	e.Request = httptest.NewRequest(http.MethodGet, "/settings/profile", nil)
+	e.Request.SetPathValue("path", "settings/profile")
+	rec := httptest.NewRecorder()
+	e.Response = rec
+	require.NoError(t, apis.Static(frontendFS(nil, dir), true)(e))
+	assert.Equal(t, "<html>app</html>", rec.Body.String())
+}
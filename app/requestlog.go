@@ -0,0 +1,86 @@
+package app
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+const (
+	// requestLogMiddlewareID is the id of the request middleware logging the requests.
+	requestLogMiddlewareID = "requestLog"
+	// requestIDHeader is the header of the request id, taken from the request or generated.
+	requestIDHeader = "X-Request-Id"
+)
+
+// requestLog returns a request middleware logging every request as a JSON line to w,
+// e.g. for ingestion by Loki or CloudWatch. The errors are logged at the error level,
+// and the client errors at the warn level.
+func requestLog(w io.Writer) *hook.Handler[*core.RequestEvent] {
+	logger := slog.New(slog.NewJSONHandler(w, nil))
+
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       requestLogMiddlewareID,
+		Priority: apis.DefaultActivityLoggerMiddlewarePriority - 3,
+		Func: func(e *core.RequestEvent) error {
+			start := time.Now()
+
+			requestID := e.Request.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = security.RandomString(20)
+			}
+			e.Response.Header().Set(requestIDHeader, requestID)
+
+			err := e.Next()
+
+			status := responseStatus(e, err)
+			attrs := []slog.Attr{
+				slog.String("requestId", requestID),
+				slog.String("method", e.Request.Method),
+				slog.String("url", e.Request.URL.RequestURI()),
+				slog.Int("status", status),
+				slog.Float64("latencyMs", float64(time.Since(start))/float64(time.Millisecond)),
+				slog.String("remoteIp", e.RemoteIP()),
+				slog.String("userAgent", e.Request.UserAgent()),
+			}
+			if e.Auth != nil {
+				attrs = append(attrs,
+					slog.String("auth", e.Auth.Id),
+					slog.String("authCollection", e.Auth.Collection().Name),
+				)
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= 500 || (err != nil && status == 0):
+				level = slog.LevelError
+			case status >= 400:
+				level = slog.LevelWarn
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+
+			logger.LogAttrs(e.Request.Context(), level, "request", attrs...)
+			return err
+		},
+	}
+}
+
+// responseStatus returns the status of the response, which for errors
+// is only written after the middlewares, so it's taken from the error.
+func responseStatus(e *core.RequestEvent, err error) int {
+	status := e.Status()
+	var apiErr *router.ApiError
+	if status == 0 && errors.As(err, &apiErr) {
+		status = apiErr.Status
+	}
+	return status
+}
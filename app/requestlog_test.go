@@ -0,0 +1,56 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLog(t *testing.T) {
+	var buf bytes.Buffer
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(requestLog(&buf))
+
+	user := core.NewRecord(core.NewAuthCollection("users"))
+	user.Id = "user_id"
+
+	e := &core.RequestEvent{Auth: user}
+	e.Request = httptest.NewRequest(http.MethodGet, "/api/collections/posts/records?page=2", nil)
+	e.Request.Header.Set(requestIDHeader, "request_id")
+	rec := httptest.NewRecorder()
+	e.Response = rec
+
+	err := h.Trigger(e, func(e *core.RequestEvent) error {
+		return router.NewForbiddenError("", nil)
+	})
+	require.Error(t, err)
+	assert.Equal(t, "request_id", rec.Header().Get(requestIDHeader))
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "request", line["msg"])
+	assert.Equal(t, "request_id", line["requestId"])
+	assert.Equal(t, "GET", line["method"])
+	assert.Equal(t, "/api/collections/posts/records?page=2", line["url"])
+	assert.InDelta(t, http.StatusForbidden, line["status"], 0)
+	assert.Contains(t, line, "latencyMs")
+	assert.Equal(t, "user_id", line["auth"])
+	assert.Equal(t, "users", line["authCollection"])
+
+	// a request id is generated when missing
+	e.Request.Header.Del(requestIDHeader)
+	buf.Reset()
+	require.NoError(t, h.Trigger(e, func(*core.RequestEvent) error { return nil }))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.NotEmpty(t, line["requestId"])
+	assert.Equal(t, "INFO", line["level"])
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/osutils"
+	"github.com/spf13/cobra"
+)
+
+// rollbackBackupPrefix is the name prefix of the backups created before a rollback.
+const rollbackBackupPrefix = "@pre_rollback_"
+
+// newRollbackCommand creates the "rollback [n]" command reverting the last n applied app
+// migrations, 1 by default, after a backup. Unlike "migrate down", it can run unattended
+// in production with --yes.
+func newRollbackCommand(app core.App) *cobra.Command {
+	var yes, backup bool
+	command := &cobra.Command{
+		Use:          "rollback [n]",
+		Short:        "Reverts the last n applied migrations, after a backup",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			n, err := rollbackCount(args)
+			if err != nil {
+				return err
+			}
+
+			files, err := lastAppliedMigrations(app, n)
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				fmt.Println("No migrations to revert")
+				return nil
+			}
+			for _, file := range files {
+				fmt.Println(file)
+			}
+			if !yes && !osutils.YesNoPrompt(fmt.Sprintf("Do you really want to revert the last %d applied migration(s)?", len(files)), false) {
+				fmt.Println("The command has been cancelled")
+				return nil
+			}
+
+			if backup {
+				name := rollbackBackupPrefix + time.Now().UTC().Format("20060102150405") + ".zip"
+				if err := app.CreateBackup(context.Background(), name); err != nil {
+					return fmt.Errorf("failed to create backup %q: %w", name, err)
+				}
+				fmt.Printf("Created backup %q\n", name)
+			}
+
+			reverted, err := core.NewMigrationsRunner(app, core.AppMigrations).Down(len(files))
+			if err != nil {
+				return err
+			}
+			for _, file := range reverted {
+				fmt.Printf("Reverted %s\n", file)
+			}
+			return nil
+		},
+	}
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "revert without confirmation")
+	command.Flags().BoolVar(&backup, "backup", true, "create a backup before reverting")
+
+	return command
+}
+
+// rollbackCount returns the number of migrations to revert of the command arguments.
+func rollbackCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid number of migrations %q", args[0])
+	}
+	return n, nil
+}
+
+// lastAppliedMigrations returns the files of the last n applied app migrations, the last first.
+func lastAppliedMigrations(app core.App, n int) ([]string, error) {
+	names := appMigrationFiles()
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var files []string
+	err := app.DB().Select("file").
+		From(core.DefaultMigrationsTable).
+		Where(dbx.HashExp{"file": names}).
+		// unify microseconds and seconds applied time, like the migrations runner
+		OrderBy("substr(applied||'0000000000000000', 0, 17) DESC").
+		AndOrderBy("file DESC").
+		Limit(int64(n)).
+		Column(&files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the applied migrations: %w", err)
+	}
+	return files, nil
+}
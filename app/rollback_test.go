@@ -0,0 +1,23 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackCount(t *testing.T) {
+	n, err := rollbackCount(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = rollbackCount([]string{"3"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	for _, arg := range []string{"0", "-1", "all"} {
+		_, err := rollbackCount([]string{arg})
+		assert.Error(t, err, arg)
+	}
+}
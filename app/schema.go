@@ -0,0 +1,140 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations/schema"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// schemaSnapshotFile is the JSON snapshot of the collections, in the migrations directory.
+const schemaSnapshotFile = "collections.json"
+
+// newSchemaCommand creates the "schema" command, whose "diff" subcommand writes a migration
+// of the changes made to the collections registered with schema.Register, e.g. in the dashboard,
+// "snapshot" subcommand writes the configuration of all collections and "openapi" subcommand
+// describes their record endpoints.
+func newSchemaCommand(app core.App, dir string) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "schema",
+		Short: "Compares and snapshots the collections configuration",
+	}
+
+	command.AddCommand(&cobra.Command{
+		Use:          "diff",
+		Short:        "Creates a migration of the changes made to the declared collections",
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			path, err := writeSchemaDiff(app, migrationsDir(app, dir), time.Now())
+			if errors.Is(err, schema.ErrNoChanges) {
+				fmt.Println("The declared collections are up to date")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Successfully created file %q\n", path)
+			return nil
+		},
+	})
+
+	var snapshotFormat string
+	snapshot := &cobra.Command{
+		Use:          "snapshot",
+		Short:        "Writes the configuration of all collections into the migrations directory",
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			path, err := writeSchemaSnapshot(app, migrationsDir(app, dir), snapshotFormat, time.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Successfully created file %q\n", path)
+			return nil
+		},
+	}
+	snapshot.Flags().StringVar(&snapshotFormat, "format", "json", "snapshot format: json, for reviews, or go, a migration provisioning fresh instances")
+	command.AddCommand(snapshot)
+
+	var serverURL, output string
+	openapi := &cobra.Command{
+		Use:          "openapi",
+		Short:        "Prints an OpenAPI document of the record endpoints of the collections",
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			collections, err := app.FindAllCollections()
+			if err != nil {
+				return err
+			}
+			data, err := json.MarshalIndent(openAPIDocument(collections, serverURL), "", "  ")
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				fmt.Println(string(data))
+				return nil
+			}
+			_, err = writeFile(output, append(data, '\n'))
+			return err
+		},
+	}
+	openapi.Flags().StringVar(&serverURL, "server", "", "URL of the instance, e.g. https://pb.example.com")
+	openapi.Flags().StringVarP(&output, "output", "o", "", "file to write the document to, instead of stdout")
+	command.AddCommand(openapi)
+
+	return command
+}
+
+// migrationsDir returns the directory of the Go migrations, defaulting like migrate.
+func migrationsDir(app core.App, dir string) string {
+	if dir == "" {
+		return filepath.Join(app.DataDir(), "../migrations")
+	}
+	return dir
+}
+
+// writeSchemaDiff writes the migration of the schema changes into dir and returns its path.
+func writeSchemaDiff(app core.App, dir string, now time.Time) (string, error) {
+	src, err := schema.Diff(app, filepath.Base(dir))
+	if err != nil {
+		return "", err
+	}
+	return writeFile(filepath.Join(dir, fmt.Sprintf("%d_schema_diff.go", now.Unix())), []byte(src))
+}
+
+// writeSchemaSnapshot writes the snapshot of the collections into dir in the given format,
+// replacing the previous JSON snapshot, and returns its path.
+func writeSchemaSnapshot(app core.App, dir, format string, now time.Time) (string, error) {
+	switch format {
+	case "json":
+		data, err := schema.Snapshot(app)
+		if err != nil {
+			return "", err
+		}
+		return writeFile(filepath.Join(dir, schemaSnapshotFile), data)
+	case "go":
+		src, err := schema.SnapshotMigration(app, filepath.Base(dir))
+		if err != nil {
+			return "", err
+		}
+		return writeFile(filepath.Join(dir, fmt.Sprintf("%d_schema_snapshot.go", now.Unix())), []byte(src))
+	default:
+		return "", fmt.Errorf("unknown snapshot format %q", format)
+	}
+}
+
+// writeFile writes the file, creating its directory.
+func writeFile(path string, data []byte) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to save file %q: %w", path, err)
+	}
+	return path, nil
+}
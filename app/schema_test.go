@@ -0,0 +1,37 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations/schema"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationsDir(t *testing.T) {
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: "/srv/pb_data"})
+
+	assert.Equal(t, "/srv/migrations", migrationsDir(app, ""))
+	assert.Equal(t, "custom", migrationsDir(app, "custom"))
+}
+
+func TestWriteSchemaDiff_NoChanges(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: t.TempDir()})
+
+	_, err := writeSchemaDiff(app, dir, time.Now())
+	require.ErrorIs(t, err, schema.ErrNoChanges)
+	assert.NoDirExists(t, dir)
+}
+
+func TestWriteSchemaSnapshot_UnknownFormat(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: t.TempDir()})
+
+	_, err := writeSchemaSnapshot(app, dir, "yaml", time.Now())
+	require.ErrorContains(t, err, `unknown snapshot format "yaml"`)
+	assert.NoDirExists(t, dir)
+}
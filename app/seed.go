@@ -0,0 +1,123 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// seedCollectionsFile is the seed file with the collections, as exported by the dashboard.
+const seedCollectionsFile = "collections.json"
+
+// bindSeed returns a serve hook seeding an empty database from the seed directory,
+// which defaults to the seed directory next to the data directory.
+func bindSeed(dir string) func(*core.ServeEvent) error {
+	return func(e *core.ServeEvent) error {
+		if dir == "" {
+			dir = filepath.Join(e.App.DataDir(), "../seed")
+		}
+		if err := seed(e.App, dir); err != nil {
+			return fmt.Errorf("failed to seed from %q: %w", dir, err)
+		}
+		return e.Next()
+	}
+}
+
+// seed imports collections.json and the records of the other JSON files, named after
+// their collection, when the directory exists and none of the collections has records.
+//
+// The record files are imported by name, which can be prefixed by a number to order
+// them, e.g. 1_users.json before 2_posts.json relating to users.
+func seed(app core.App, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	if empty, err := isEmpty(app); err != nil || !empty {
+		return err
+	}
+
+	return app.RunInTransaction(func(txApp core.App) error {
+		data, err := os.ReadFile(filepath.Join(dir, seedCollectionsFile))
+		switch {
+		case err == nil:
+			if err := txApp.ImportCollectionsByMarshaledJSON(data, false); err != nil {
+				return fmt.Errorf("failed to import the collections: %w", err)
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+
+		for _, file := range files {
+			if filepath.Base(file) == seedCollectionsFile {
+				continue
+			}
+			if err := seedRecords(txApp, file); err != nil {
+				return err
+			}
+		}
+
+		app.Logger().Info("Seeded the database", "dir", dir)
+		return nil
+	})
+}
+
+// seedRecords saves the records of a seed file.
+func seedRecords(app core.App, file string) error {
+	name := seedCollectionName(file)
+	collection, err := app.FindCollectionByNameOrId(name)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the collection %q of %s: %w", name, filepath.Base(file), err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var records []map[string]any
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+	}
+
+	for i, data := range records {
+		record := core.NewRecord(collection)
+		record.Load(data)
+		if err := app.Save(record); err != nil {
+			return fmt.Errorf("failed to save record %d of %s: %w", i, filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// seedCollectionName returns the collection of a seed file, without the order prefix.
+func seedCollectionName(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if prefix, rest, ok := strings.Cut(name, "_"); ok && prefix != "" && strings.Trim(prefix, "0123456789") == "" {
+		return rest
+	}
+	return name
+}
+
+// isEmpty reports whether none of the non-system collections has records.
+func isEmpty(app core.App) (bool, error) {
+	collections, err := app.FindAllCollections(core.CollectionTypeBase, core.CollectionTypeAuth)
+	if err != nil {
+		return false, err
+	}
+	for _, collection := range collections {
+		if collection.System {
+			continue
+		}
+		count, err := app.CountRecords(collection)
+		if err != nil || count > 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedCollectionName(t *testing.T) {
+	for file, name := range map[string]string{
+		"seed/users.json":         "users",
+		"seed/1_users.json":       "users",
+		"seed/02_posts_user.json": "posts_user",
+		"seed/posts_user.json":    "posts_user",
+		"seed/_users.json":        "_users",
+	} {
+		assert.Equal(t, name, seedCollectionName(file), file)
+	}
+}
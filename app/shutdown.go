@@ -0,0 +1,105 @@
+package app
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+const (
+	// drainMiddlewareID is the id of the request middleware tracking the in-flight requests.
+	drainMiddlewareID = "drain"
+	// drainTerminatePriority runs the drain before the server shutdown of PocketBase.
+	drainTerminatePriority = -10000
+)
+
+// drainer delays the shutdown of the server until the in-flight requests,
+// backups and realtime messages are done, or the drain period elapsed.
+type drainer struct {
+	period   time.Duration
+	draining atomic.Bool
+	inflight atomic.Int64
+	// poll is the interval of the checks whether the drain is done.
+	poll time.Duration
+}
+
+func newDrainer(period time.Duration) *drainer {
+	return &drainer{period: period, poll: 50 * time.Millisecond}
+}
+
+// bind registers the drainer on the app.
+func (d *drainer) bind(app core.App) {
+	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		e.Router.Bind(d.middleware())
+		return e.Next()
+	})
+	app.OnTerminate().Bind(&hook.Handler[*core.TerminateEvent]{
+		Id:       drainMiddlewareID,
+		Priority: drainTerminatePriority,
+		Func: func(e *core.TerminateEvent) error {
+			if !e.IsRestart {
+				d.drain(e.App)
+			}
+			return e.Next()
+		},
+	})
+}
+
+// middleware tracks the in-flight requests and rejects the new ones while draining.
+// The realtime connections aren't tracked, as they only end with the shutdown, and
+// the liveness probe succeeds until the shutdown.
+func (d *drainer) middleware() *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       drainMiddlewareID,
+		Priority: apis.DefaultActivityLoggerMiddlewarePriority - 2,
+		Func: func(e *core.RequestEvent) error {
+			if e.Request.Method == http.MethodGet && (e.Request.URL.Path == "/api/realtime" || e.Request.URL.Path == livenessPath) {
+				return e.Next()
+			}
+
+			// counted before the check, so the drain can't miss a request accepted meanwhile
+			d.inflight.Add(1)
+			if d.draining.Load() {
+				d.inflight.Add(-1)
+				e.Response.Header().Set("Connection", "close")
+				return e.Error(http.StatusServiceUnavailable, "The server is shutting down.", nil)
+			}
+			defer d.inflight.Add(-1)
+			return e.Next()
+		},
+	}
+}
+
+// drain rejects the new requests and waits until the app is idle or the drain period elapsed.
+func (d *drainer) drain(app core.App) {
+	d.draining.Store(true)
+
+	deadline := time.Now().Add(d.period)
+	for !d.idle(app) {
+		if time.Now().After(deadline) {
+			app.Logger().Warn("Drain period elapsed, shutting down with pending work",
+				"requests", d.inflight.Load(),
+				"backup", app.Store().Has(core.StoreKeyActiveBackup),
+			)
+			return
+		}
+		time.Sleep(d.poll)
+	}
+}
+
+// idle reports whether there are no in-flight requests, backups and pending realtime messages.
+func (d *drainer) idle(app core.App) bool {
+	if d.inflight.Load() > 0 || app.Store().Has(core.StoreKeyActiveBackup) {
+		return false
+	}
+	for _, client := range app.SubscriptionsBroker().Clients() {
+		if len(client.Channel()) > 0 {
+			return false
+		}
+	}
+	return true
+}
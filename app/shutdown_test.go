@@ -0,0 +1,89 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainer(t *testing.T) {
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: t.TempDir()})
+	d := newDrainer(time.Second)
+	d.poll = time.Millisecond
+
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(d.middleware())
+	request := func(handler func(*core.RequestEvent) error) error {
+		e := &core.RequestEvent{App: app}
+		e.Request = httptest.NewRequest(http.MethodPost, "/api/collections/posts/records", nil)
+		e.Response = httptest.NewRecorder()
+		return h.Trigger(e, handler)
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	finished := make(chan error)
+	go func() {
+		finished <- request(func(*core.RequestEvent) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	drained := make(chan struct{})
+	go func() {
+		d.drain(app)
+		close(drained)
+	}()
+
+	// new requests are rejected, while the in-flight one delays the shutdown
+	require.Eventually(t, d.draining.Load, time.Second, time.Millisecond)
+	err := request(func(*core.RequestEvent) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shutting down")
+
+	select {
+	case <-drained:
+		t.Fatal("drained with an in-flight request")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-finished)
+	<-drained
+}
+
+func TestDrainer_Period(t *testing.T) {
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: t.TempDir()})
+	app.Store().Set(core.StoreKeyActiveBackup, "backup.zip")
+	d := newDrainer(20 * time.Millisecond)
+	d.poll = time.Millisecond
+
+	start := time.Now()
+	d.drain(app)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestDrainer_Liveness(t *testing.T) {
+	d := newDrainer(time.Second)
+	d.draining.Store(true)
+
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(d.middleware())
+	request := func(path string) error {
+		e := &core.RequestEvent{}
+		e.Request = httptest.NewRequest(http.MethodGet, path, nil)
+		e.Response = httptest.NewRecorder()
+		return h.Trigger(e, func(*core.RequestEvent) error { return nil })
+	}
+
+	assert.NoError(t, request(livenessPath))
+	assert.Error(t, request(readinessPath))
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// bootstrapSuperuser returns a serve hook upserting the superuser with the
+// given credentials, so deployments never need the interactive setup step.
+func bootstrapSuperuser(email, password string) func(*core.ServeEvent) error {
+	return func(e *core.ServeEvent) error {
+		if err := upsertSuperuser(e.App, email, password); err != nil {
+			return err
+		}
+		return e.Next()
+	}
+}
+
+// upsertSuperuser creates the superuser, or updates its password if it changed.
+func upsertSuperuser(app core.App, email, password string) error {
+	superusers, err := app.FindCachedCollectionByNameOrId(core.CollectionNameSuperusers)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %q collection: %w", core.CollectionNameSuperusers, err)
+	}
+
+	superuser, err := app.FindAuthRecordByEmail(superusers, email)
+	switch {
+	case err == nil && superuser.ValidatePassword(password):
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		superuser = core.NewRecord(superusers)
+		superuser.SetEmail(email)
+	case err != nil:
+		return fmt.Errorf("failed to fetch superuser %q: %w", email, err)
+	}
+
+	superuser.SetPassword(password)
+	if err := app.Save(superuser); err != nil {
+		return fmt.Errorf("failed to upsert superuser %q: %w", email, err)
+	}
+
+	app.Logger().Info("Bootstrapped superuser", "email", email)
+	return nil
+}
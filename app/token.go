@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// newTokenCommand creates the "token <email>" command printing a non-refreshable auth token
+// of the superuser, or of the auth record of another collection, with the given duration,
+// e.g. for WithAdminToken in CI instead of storing the password in the pipeline secrets.
+func newTokenCommand(app core.App) *cobra.Command {
+	var collection string
+	var duration time.Duration
+	command := &cobra.Command{
+		Use:          "token <email>",
+		Example:      "token ci@example.com --duration 30m",
+		Short:        "Prints a static auth token of a superuser or auth record",
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing email argument")
+			}
+			if duration <= 0 {
+				return fmt.Errorf("invalid token duration %s", duration)
+			}
+
+			record, err := app.FindAuthRecordByEmail(collection, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to fetch %s record %q: %w", collection, args[0], err)
+			}
+			token, err := record.NewStaticAuthToken(duration)
+			if err != nil {
+				return fmt.Errorf("failed to create the token: %w", err)
+			}
+			fmt.Println(token)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&collection, "collection", core.CollectionNameSuperusers, "auth collection of the record")
+	command.Flags().DurationVar(&duration, "duration", time.Hour, "validity of the token")
+
+	return command
+}
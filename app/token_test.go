@@ -0,0 +1,24 @@
+package app
+
+import (
+	"io"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenCommand_Args(t *testing.T) {
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: t.TempDir()})
+	run := func(args ...string) error {
+		command := newTokenCommand(app)
+		command.SetArgs(args)
+		command.SetOut(io.Discard)
+		command.SetErr(io.Discard)
+		return command.Execute()
+	}
+
+	assert.ErrorContains(t, run(), "missing email argument")
+	assert.ErrorContains(t, run("ci@example.com", "--duration", "-1h"), "invalid token duration")
+	assert.ErrorContains(t, run("ci@example.com", "--duration", "0s"), "invalid token duration")
+}
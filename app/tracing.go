@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingMiddlewareID is the id of the request middleware creating the server spans.
+const tracingMiddlewareID = "otelTracing"
+
+// bindTracing is a serve hook exporting a span per request via OTLP/HTTP.
+// The exporter, sampler and resource are configured by the standard OTEL_* env vars.
+func bindTracing(e *core.ServeEvent) error {
+	exporter, err := otlptracehttp.New(context.Background())
+	if err != nil {
+		return err
+	}
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName("pocketbase")),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	e.App.OnTerminate().BindFunc(func(te *core.TerminateEvent) error {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			te.App.Logger().Warn("Failed to flush the trace spans", "error", err)
+		}
+		return te.Next()
+	})
+
+	e.Router.Bind(tracingMiddleware(provider.Tracer("github.com/Forty2Co/pocketbase/app")))
+	return e.Next()
+}
+
+// tracingMiddleware starts a server span per request, continuing the trace
+// propagated by the client, e.g. by the SDK, in the traceparent header.
+func tracingMiddleware(tracer trace.Tracer) *hook.Handler[*core.RequestEvent] {
+	return &hook.Handler[*core.RequestEvent]{
+		Id:       tracingMiddlewareID,
+		Priority: apis.DefaultActivityLoggerMiddlewarePriority - 1,
+		Func: func(e *core.RequestEvent) error {
+			ctx := otel.GetTextMapPropagator().Extract(e.Request.Context(), propagation.HeaderCarrier(e.Request.Header))
+
+			name := e.Request.Method
+			attrs := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(e.Request.Method),
+					semconv.URLPath(e.Request.URL.Path),
+					semconv.UserAgentOriginal(e.Request.UserAgent()),
+					semconv.NetworkPeerAddress(e.RemoteIP()),
+				),
+			}
+			if _, route, ok := strings.Cut(e.Request.Pattern, " "); ok {
+				name += " " + route
+				attrs = append(attrs, trace.WithAttributes(semconv.HTTPRoute(route)))
+			}
+
+			ctx, span := tracer.Start(ctx, name, attrs...)
+			defer span.End()
+			e.Request = e.Request.WithContext(ctx)
+
+			err := e.Next()
+
+			status := responseStatus(e, err)
+			if err != nil {
+				span.RecordError(err)
+			}
+			if status != 0 {
+				span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			}
+			if status >= 500 || (err != nil && status == 0) {
+				span.SetStatus(codes.Error, "")
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	h := &hook.Hook[*core.RequestEvent]{}
+	h.Bind(tracingMiddleware(provider.Tracer("test")))
+
+	e := &core.RequestEvent{}
+	e.Request = httptest.NewRequest(http.MethodGet, "/api/collections/posts/records/abc", nil)
+	e.Request.Pattern = "GET /api/collections/{collection}/records/{id}"
+	e.Request.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	e.Response = httptest.NewRecorder()
+
+	err := h.Trigger(e, func(e *core.RequestEvent) error {
+		return router.NewNotFoundError("", nil)
+	})
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /api/collections/{collection}/records/{id}", span.Name)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext.TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", span.Parent.SpanID().String())
+	assert.Contains(t, span.Attributes, attribute.Int("http.response.status_code", http.StatusNotFound))
+	assert.Contains(t, span.Attributes, attribute.String("http.route", "/api/collections/{collection}/records/{id}"))
+	assert.Equal(t, codes.Unset, span.Status.Code)
+}
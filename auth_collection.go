@@ -0,0 +1,148 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// AuthCollection is a type-safe wrapper around a PocketBase auth collection.
+//
+// Besides the CRUD and auth methods of Collection it provides the auth-specific
+// methods which only make sense for auth collections, like OTP and impersonation,
+// all scoped to the wrapped collection.
+type AuthCollection[T any] struct {
+	*Collection[T]
+}
+
+// AuthCollectionSet creates a new type-safe wrapper for the specified auth collection.
+func AuthCollectionSet[T any](client *Client, collection string) *AuthCollection[T] {
+	return &AuthCollection[T]{
+		Collection: CollectionSet[T](client, collection),
+	}
+}
+
+type (
+	// RequestOTPResponse represents the response from requesting a one-time password.
+	RequestOTPResponse struct {
+		OTPID string `json:"otpId"`
+	}
+
+	// AuthWithOTPResponse represents the response from one-time password authentication.
+	AuthWithOTPResponse struct {
+		Record Record `json:"record"`
+		Token  string `json:"token"`
+	}
+
+	// ImpersonateResponse represents the response from impersonating an auth record.
+	ImpersonateResponse struct {
+		Record Record `json:"record"`
+		Token  string `json:"token"`
+	}
+)
+
+// RequestOTP sends a one-time password to the email of an auth record.
+//
+// The returned OTPID must be passed to AuthWithOTP together with the received password.
+func (c *AuthCollection[T]) RequestOTP(email string) (RequestOTPResponse, error) {
+	var response RequestOTPResponse
+	if err := c.Authorize(); err != nil {
+		return response, err
+	}
+
+	request := c.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]string{
+			"email": email,
+		})
+
+	resp, err := request.Post(c.BaseCollectionPath + "/request-otp")
+	if err != nil {
+		return response, fmt.Errorf("[records] can't send request-otp request to pocketbase, err %w", err)
+	}
+
+	if resp.IsError() {
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at request-otp: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
+		return response, fmt.Errorf("[records] can't unmarshal request-otp-response, err %w", err)
+	}
+	return response, nil
+}
+
+// AuthWithOTP authenticate a single auth collection record with a one-time password.
+//
+// On success the token is stored for the subsequent record auth calls, like AuthWithPassword does.
+func (c *AuthCollection[T]) AuthWithOTP(otpID string, password string) (AuthWithOTPResponse, error) {
+	var response AuthWithOTPResponse
+	if err := c.Authorize(); err != nil {
+		return response, err
+	}
+
+	request := c.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]string{
+			"otpId":    otpID,
+			"password": password,
+		})
+
+	resp, err := request.Post(c.BaseCollectionPath + "/auth-with-otp")
+	if err != nil {
+		return response, fmt.Errorf("[records] can't send auth-with-otp request to pocketbase, err %w", err)
+	}
+
+	if resp.IsError() {
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at auth-with-otp: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
+		return response, fmt.Errorf("[records] can't unmarshal auth-with-otp-response, err %w", err)
+	}
+
+	c.token = response.Token
+	return response, nil
+}
+
+// Impersonate issues a non-refreshable auth token for another record of the collection.
+//
+// Only superusers can impersonate. A zero duration uses the collection's default
+// auth token duration.
+func (c *AuthCollection[T]) Impersonate(recordID string, duration time.Duration) (ImpersonateResponse, error) {
+	var response ImpersonateResponse
+	if err := c.Authorize(); err != nil {
+		return response, err
+	}
+
+	request := c.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]int64{
+			"duration": int64(duration / time.Second),
+		})
+
+	resp, err := request.Post(c.BaseCollectionPath + "/impersonate/" + url.PathEscape(recordID))
+	if err != nil {
+		return response, fmt.Errorf("[records] can't send impersonate request to pocketbase, err %w", err)
+	}
+
+	if resp.IsError() {
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at impersonate: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
+		return response, fmt.Errorf("[records] can't unmarshal impersonate-response, err %w", err)
+	}
+	return response, nil
+}
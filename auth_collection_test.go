@@ -0,0 +1,74 @@
+package pocketbase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthCollection_Impersonate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	admin := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	users := AuthCollectionSet[map[string]any](admin, "users")
+
+	list, err := users.List(ParamsList{Filters: "email='" + migrations.UserEmailPassword + "'"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	userID := list.Items[0]["id"].(string)
+
+	resp, err := users.Impersonate(userID, time.Hour)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	assert.Equal(t, userID, resp.Record.ID)
+	assert.Equal(t, migrations.UserEmailPassword, resp.Record.Email)
+
+	userResp, err := NewClient(defaultURL).Send(context.Background(), Request{
+		Path:    "/api/collections/" + migrations.PostsUser + "/records",
+		Headers: map[string]string{"Authorization": resp.Token},
+	})
+	require.NoError(t, err)
+	var posts ResponseList[map[string]any]
+	require.NoError(t, userResp.Decode(&posts))
+	assert.Positive(t, posts.TotalItems)
+
+	t.Run("requires superuser", func(t *testing.T) {
+		anonymous := AuthCollectionSet[map[string]any](NewClient(defaultURL), "users")
+		_, err := anonymous.Impersonate(userID, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthCollection_OTP(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	users := AuthCollectionSet[map[string]any](NewClient(defaultURL), "users")
+
+	// OTP is disabled for the users collection of the test instance
+	_, err := users.RequestOTP(migrations.UserEmailPassword)
+	assert.Error(t, err)
+
+	_, err = users.AuthWithOTP("non_existing_otp", "123456")
+	assert.Error(t, err)
+}
+
+func TestAuthCollection_AuthWithPassword(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	users := AuthCollectionSet[map[string]any](NewClient(defaultURL), "users")
+
+	resp, err := users.AuthWithPassword(migrations.UserEmailPassword, migrations.UserEmailPassword)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+
+	refreshed, err := users.AuthRefresh()
+	require.NoError(t, err)
+	assert.Equal(t, resp.Record.ID, refreshed.Record.ID)
+}
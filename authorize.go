@@ -1,26 +1,45 @@
 package pocketbase
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"golang.org/x/sync/singleflight"
 )
 
-type authStore interface {
-	authorizer
-	IsValid() bool
+// Authorizer provides the credentials of a client.
+//
+// Authorize is called before every SDK call and must be safe for concurrent use.
+// It should return immediately while the current token is still valid and obtain
+// a new one otherwise (e.g. by logging in, refreshing or asking a secrets manager).
+//
+// Token returns the value sent in the Authorization header of every request,
+// an empty token sends anonymous requests.
+type Authorizer interface {
+	Authorize() error
 	Token() string
 }
 
-type authorizer interface {
-	authorize() error
+// AuthStore holds the authorization state of a client.
+//
+// It can be used to persist sessions or render the logged-in identity.
+type AuthStore interface {
+	Authorizer
+	// IsValid reports whether the token is present and not expired.
+	IsValid() bool
+	// Model returns the authenticated record, nil if unknown.
+	Model() map[string]any
+	// Clear drops the token and the record.
+	Clear()
 }
 
 type authorizeNoOp struct{}
 
-func (a authorizeNoOp) authorize() error {
+func (a authorizeNoOp) Authorize() error {
 	return nil
 }
 
@@ -32,19 +51,29 @@ func (a authorizeNoOp) Token() string {
 	return ""
 }
 
+func (a authorizeNoOp) Model() map[string]any {
+	return nil
+}
+
+func (a authorizeNoOp) Clear() {}
+
 type authorizeEmailPassword struct {
 	email       string
 	password    string
+	mu          sync.RWMutex
 	token       string
 	tokenValid  time.Time
+	model       map[string]any
 	client      *resty.Client
+	clock       *serverClock
 	url         string
 	tokenSingle singleflight.Group
 }
 
-func newAuthorizeEmailPassword(c *resty.Client, url string, email string, password string) authStore {
+func newAuthorizeEmailPassword(c *resty.Client, clock *serverClock, url string, email string, password string) AuthStore {
 	return &authorizeEmailPassword{
 		client:      c,
+		clock:       clock,
 		email:       email,
 		password:    password,
 		url:         url,
@@ -52,17 +81,19 @@ func newAuthorizeEmailPassword(c *resty.Client, url string, email string, passwo
 	}
 }
 
-func (a *authorizeEmailPassword) authorize() error {
+func (a *authorizeEmailPassword) Authorize() error {
 	type authResponse struct {
-		Token string `json:"token"`
+		Token  string         `json:"token"`
+		Record map[string]any `json:"record"`
 	}
 
 	_, err, _ := a.tokenSingle.Do("auth", func() (interface{}, error) {
-		if time.Now().Before(a.tokenValid) {
+		if a.IsValid() {
 			return nil, nil
 		}
 
 		resp, err := a.client.R().
+			SetContext(retrySafe(context.Background())).
 			SetHeader("Content-Type", "application/json").
 			SetBody(map[string]interface{}{
 				"identity": a.email,
@@ -77,17 +108,19 @@ func (a *authorizeEmailPassword) authorize() error {
 		}
 
 		if resp.IsError() {
-			return nil, fmt.Errorf("[auth] pocketbase returned status: %d, msg: %s, err %w",
+			return nil, newAPIError(resp, fmt.Errorf("[auth] pocketbase returned status: %d, msg: %s, err %w",
 				resp.StatusCode(),
-				resp.String(),
+				responseBody(resp),
 				ErrInvalidResponse,
-			)
+			))
 		}
 
 		auth := *resp.Result().(*authResponse)
+		a.mu.Lock()
 		a.token = auth.Token
-		a.client.SetHeader("Authorization", auth.Token)
-		a.tokenValid = time.Now().Add(60 * time.Minute)
+		a.model = auth.Record
+		a.tokenValid = a.clock.tokenValidUntil(auth.Token)
+		a.mu.Unlock()
 
 		return nil, nil
 	})
@@ -95,9 +128,42 @@ func (a *authorizeEmailPassword) authorize() error {
 }
 
 func (a *authorizeEmailPassword) IsValid() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return time.Now().Before(a.tokenValid)
 }
 
 func (a *authorizeEmailPassword) Token() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return a.token
 }
+
+func (a *authorizeEmailPassword) Model() map[string]any {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return maps.Clone(a.model)
+}
+
+func (a *authorizeEmailPassword) Clear() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+	a.model = nil
+	a.tokenValid = time.Time{}
+}
+
+// authorizeCustom adapts an Authorizer which doesn't implement the whole AuthStore.
+type authorizeCustom struct {
+	Authorizer
+}
+
+func (a authorizeCustom) IsValid() bool {
+	return a.Token() != ""
+}
+
+func (a authorizeCustom) Model() map[string]any {
+	return nil
+}
+
+func (a authorizeCustom) Clear() {}
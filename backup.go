@@ -1,7 +1,6 @@
 package pocketbase
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -38,14 +37,14 @@ func (b Backup) FullList() ([]ResponseBackupFullList, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[backup] pocketbase returned status: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[backup] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := b.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[backup] can't unmarshal response, err %w", err)
 	}
 
@@ -77,11 +76,11 @@ func (b Backup) Create(key ...string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[backup] pocketbase returned status at creating a new backup: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[backup] pocketbase returned status at creating a new backup: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
 	return nil
@@ -106,11 +105,11 @@ func (b Backup) Upload(key string, reader io.Reader) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[backup] pocketbase returned status at uploading a new backup: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[backup] pocketbase returned status at uploading a new backup: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
 	return nil
@@ -137,11 +136,11 @@ func (b Backup) Delete(key string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[backup] pocketbase returned status at deleting a backup: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[backup] pocketbase returned status at deleting a backup: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
 	return nil
@@ -166,11 +165,11 @@ func (b Backup) Restore(key string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[backup] pocketbase returned status at creating a new backup: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[backup] pocketbase returned status at creating a new backup: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
 	return nil
@@ -0,0 +1,289 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maxBatchRequests is the number of requests sent in a batch, the default limit of PocketBase.
+const maxBatchRequests = 50
+
+type (
+	// batchRequest is a request of a batch transaction.
+	batchRequest struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		Body   any    `json:"body,omitempty"`
+	}
+
+	// batchResult is the response of a request of a batch transaction.
+	batchResult struct {
+		Status int             `json:"status"`
+		Body   json.RawMessage `json:"body"`
+	}
+
+	// BatchError is returned when a request fails a batch transaction, so none of its
+	// requests is applied. It wraps the APIError of the batch response.
+	BatchError struct {
+		// Index is the index of the failed request in the batch.
+		Index int
+		// StatusCode is the status of the failed request, e.g. 404.
+		StatusCode int
+		// Message is the message of the failed request, e.g. "Failed to update record.".
+		Message string
+		// Fields are the validation failures of the failed request by field name.
+		Fields map[string]FieldError
+
+		err error
+	}
+)
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("[batch] request %d failed with status %d: %s, err %v", e.Index, e.StatusCode, e.Message, e.err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.err
+}
+
+// batch sends the requests in a single transaction of /api/batch, applied all or none.
+func (c *Client) batch(ctx context.Context, requests []batchRequest) ([]batchResult, error) {
+	if err := c.Authorize(); err != nil {
+		return nil, err
+	}
+
+	request := c.client.R().SetContext(ctx)
+	if err := c.setJSONBody(request, map[string]any{"requests": requests}); err != nil {
+		return nil, fmt.Errorf("[batch] can't marshal body, err %w", err)
+	}
+	resp, err := request.Post(c.url + "/api/batch")
+	if err != nil {
+		return nil, fmt.Errorf("[batch] can't send batch request to pocketbase, err %w", err)
+	}
+	if resp.IsError() {
+		return nil, newBatchError(resp, newAPIError(resp, fmt.Errorf("[batch] pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		)))
+	}
+
+	var results []batchResult
+	if err := c.codec.unmarshalJSON(resp.Body(), &results); err != nil {
+		return nil, fmt.Errorf("[batch] can't unmarshal response, err %w", err)
+	}
+	return results, nil
+}
+
+// newBatchError wraps err into a BatchError when resp is a 400 response naming the failed
+// request, and returns err unchanged otherwise, e.g. when batch requests are disabled.
+func newBatchError(resp *resty.Response, err error) error {
+	if resp.StatusCode() != http.StatusBadRequest {
+		return err
+	}
+	var body struct {
+		Data struct {
+			Requests map[string]struct {
+				Response struct {
+					Status  int                        `json:"status"`
+					Message string                     `json:"message"`
+					Data    map[string]json.RawMessage `json:"data"`
+				} `json:"response"`
+			} `json:"requests"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(resp.Body(), &body) != nil {
+		return err
+	}
+	for key, failed := range body.Data.Requests {
+		index, convErr := strconv.Atoi(key)
+		if convErr != nil {
+			continue
+		}
+		berr := &BatchError{
+			Index:      index,
+			StatusCode: failed.Response.Status,
+			Message:    failed.Response.Message,
+			err:        err,
+		}
+		for name, raw := range failed.Response.Data {
+			var field FieldError
+			if json.Unmarshal(raw, &field) == nil && field.Code != "" {
+				if berr.Fields == nil {
+					berr.Fields = map[string]FieldError{}
+				}
+				berr.Fields[name] = field
+			}
+		}
+		return berr
+	}
+	return err
+}
+
+type (
+	// Batch builds a transaction of record operations sent at once with Send, applied all
+	// or none:
+	//
+	//	b := client.Batch()
+	//	b.Create("posts", post)
+	//	b.Update("users", id, user)
+	//	results, err := b.Send(ctx)
+	//
+	// The bodies are validated, encoded and mutated like Create and Update when sent. The
+	// number of operations is limited by the batch settings of PocketBase, 50 by default,
+	// which must also enable the batch API.
+	Batch struct {
+		client     *Client
+		operations []batchOperation
+	}
+
+	// batchOperation is an operation added to a Batch.
+	batchOperation struct {
+		method     string
+		collection string
+		id         string
+		body       any
+	}
+
+	// BatchResult is the result of an operation of a Batch, in the order of the operations.
+	BatchResult struct {
+		// Status is the status of the operation, e.g. 200 for a create or 204 for a delete.
+		Status int
+		// Collection is the collection of the operation.
+		Collection string
+		// Record is the created or updated record, nil for a delete.
+		Record map[string]any
+
+		body  json.RawMessage
+		codec *recordCodec
+	}
+)
+
+// Batch returns a new batch transaction of the client.
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Create adds the creation of a record to the batch.
+func (b *Batch) Create(collection string, body any) *Batch {
+	return b.add(http.MethodPost, collection, "", body)
+}
+
+// Update adds the update of a record to the batch.
+func (b *Batch) Update(collection string, id string, body any) *Batch {
+	return b.add(http.MethodPatch, collection, id, body)
+}
+
+// Upsert adds the update of the record with the id of the body to the batch, or its
+// creation when there is no such record.
+func (b *Batch) Upsert(collection string, body any) *Batch {
+	return b.add(http.MethodPut, collection, "", body)
+}
+
+// Delete adds the deletion of a record to the batch.
+func (b *Batch) Delete(collection string, id string) *Batch {
+	return b.add(http.MethodDelete, collection, id, nil)
+}
+
+// Len returns the number of operations of the batch.
+func (b *Batch) Len() int {
+	return len(b.operations)
+}
+
+func (b *Batch) add(method, collection, id string, body any) *Batch {
+	b.operations = append(b.operations, batchOperation{method: method, collection: collection, id: id, body: body})
+	return b
+}
+
+// Send sends the operations of the batch in a single transaction and returns their results.
+//
+// When an operation fails, none of them is applied and the returned error is a *BatchError
+// with the index of the failed operation. A body failing its validation or mutation fails
+// Send before the batch is sent, with an error naming the index of its operation.
+func (b *Batch) Send(ctx context.Context) ([]BatchResult, error) {
+	if len(b.operations) == 0 {
+		return nil, nil
+	}
+	c := b.client
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	if err := c.Authorize(); err != nil {
+		return nil, err
+	}
+
+	requests := make([]batchRequest, len(b.operations))
+	for i, op := range b.operations {
+		request, err := c.batchRequest(op)
+		if err != nil {
+			return nil, fmt.Errorf("[batch] can't prepare request %d, err %w", i, err)
+		}
+		requests[i] = request
+	}
+
+	responses, err := c.batch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) != len(b.operations) {
+		return nil, fmt.Errorf("[batch] expected %d results, got %d, err %w", len(b.operations), len(responses), ErrInvalidResponse)
+	}
+
+	results := make([]BatchResult, len(responses))
+	for i, response := range responses {
+		results[i] = BatchResult{
+			Status:     response.Status,
+			Collection: b.operations[i].collection,
+			body:       response.Body,
+			codec:      c.codec,
+		}
+		if len(response.Body) > 0 && string(response.Body) != "null" {
+			if err := c.codec.unmarshalJSON(response.Body, &results[i].Record); err != nil {
+				return results, fmt.Errorf("[batch] can't unmarshal result %d, err %w", i, err)
+			}
+		}
+	}
+	return results, nil
+}
+
+// batchRequest validates, encodes and mutates the body of the operation into its request.
+func (c *Client) batchRequest(op batchOperation) (batchRequest, error) {
+	records := "/api/collections/" + url.PathEscape(op.collection) + "/records"
+	if op.method == http.MethodDelete {
+		return batchRequest{Method: op.method, URL: records + "/" + url.PathEscape(op.id)}, nil
+	}
+
+	if err := c.validate(op.collection, op.body, op.method == http.MethodPatch); err != nil {
+		return batchRequest{}, err
+	}
+	body, err := c.codec.encode(op.body)
+	if err != nil {
+		return batchRequest{}, fmt.Errorf("can't marshal body, err %w", err)
+	}
+	mutators := c.createMutators
+	if op.method == http.MethodPatch {
+		mutators = c.updateMutators
+		records += "/" + url.PathEscape(op.id)
+	}
+	if body, err = c.mutate(mutators, op.collection, op.id, body); err != nil {
+		return batchRequest{}, fmt.Errorf("can't mutate body, err %w", err)
+	}
+	return batchRequest{Method: op.method, URL: records, Body: body}, nil
+}
+
+// Decode unmarshals the record of the result into v, like the records of a typed collection.
+func (r BatchResult) Decode(v any) error {
+	codec := r.codec
+	if codec == nil {
+		codec = defaultCodec
+	}
+	if err := codec.decode(r.body, v); err != nil {
+		return fmt.Errorf("[batch] can't unmarshal record, err %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+)
+
+func TestClient_Batch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field" validate:"required"`
+	}
+	ctx := context.Background()
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	marker := fmt.Sprintf("batch-%d", time.Now().UnixNano())
+	filter := Filter("field ~ {:marker}", map[string]any{"marker": marker})
+
+	existing, err := client.Create(migrations.PostsPublic, map[string]any{"field": marker + "-existing"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		records, _ := client.FullList(migrations.PostsPublic, ParamsList{Filters: filter})
+		for _, record := range records.Items {
+			_ = client.Delete(migrations.PostsPublic, record["id"].(string))
+		}
+	})
+
+	t.Run("all or none", func(t *testing.T) {
+		_, err := client.Batch().
+			Create(migrations.PostsPublic, post{Field: marker + "-rolled-back"}).
+			Update(migrations.PostsPublic, "missing", post{Field: marker}).
+			Send(ctx)
+		var berr *BatchError
+		require.ErrorAs(t, err, &berr)
+		assert.Equal(t, 1, berr.Index)
+		assert.Equal(t, http.StatusNotFound, berr.StatusCode)
+
+		records, err := client.List(migrations.PostsPublic, ParamsList{Filters: filter})
+		require.NoError(t, err)
+		assert.Equal(t, 1, records.TotalItems)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := client.Clone(WithTagValidation()).Batch().Create(migrations.PostsPublic, post{}).Send(ctx)
+		assert.ErrorIs(t, err, ErrInvalidRecord)
+		assert.ErrorContains(t, err, "request 0")
+	})
+
+	t.Run("results", func(t *testing.T) {
+		b := client.Batch()
+		b.Create(migrations.PostsPublic, post{Field: marker + "-created"})
+		b.Update(migrations.PostsPublic, existing.ID, map[string]any{"field": marker + "-updated"})
+		b.Upsert(migrations.PostsPublic, post{Field: marker + "-upserted"})
+		require.Equal(t, 3, b.Len())
+		results, err := b.Send(ctx)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		var created post
+		require.NoError(t, results[0].Decode(&created))
+		assert.Equal(t, marker+"-created", created.Field)
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, http.StatusOK, results[1].Status)
+		assert.Equal(t, marker+"-updated", results[1].Record["field"])
+		assert.Equal(t, migrations.PostsPublic, results[2].Collection)
+
+		results, err = client.Batch().Delete(migrations.PostsPublic, created.ID).Send(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, results[0].Status)
+		assert.Nil(t, results[0].Record)
+
+		results, err = client.Batch().Send(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,97 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// UpdateResult is the outcome of an UpdateWhere.
+type UpdateResult struct {
+	// Matched is the number of records matching the filter.
+	Matched int
+	// Updated is the number of updated records.
+	Updated int
+	// Failed are the failures of the records which weren't updated, by record id,
+	// as *BatchError with the status and field errors of their update.
+	Failed map[string]error
+}
+
+// UpdateWhere applies the patch to all records matching the filter, e.g. for maintenance:
+//
+//	result, err := collection.UpdateWhere(pocketbase.Filter("status = {:s}", map[string]any{"s": "draft"}),
+//		map[string]any{"status": "archived"})
+//
+// The ids of the matching records are listed first, so records no longer matching once
+// patched don't shift the pages, and updated by batch requests of 50 records, which
+// requires the batch API to be enabled in the settings of PocketBase. As a batch is applied
+// all or none, a record failing its update is reported in Failed and the rest of its batch
+// is sent again without it. The validators and update mutators of the client are applied
+// to the patch of every record.
+//
+// The returned error is a failure of the whole operation, e.g. a network error, with the
+// result of the batches applied before it.
+func (c *Collection[T]) UpdateWhere(filter string, patch map[string]any) (UpdateResult, error) {
+	var result UpdateResult
+	ctx, cancel := c.callContext(context.Background())
+	defer cancel()
+
+	ids, err := c.matchingIDs(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("[update-where] can't list records, err %w", err)
+	}
+	result.Matched = len(ids)
+
+	for start := 0; start < len(ids); start += maxBatchRequests {
+		pending := ids[start:min(start+maxBatchRequests, len(ids))]
+		for len(pending) > 0 {
+			requests := make([]batchRequest, len(pending))
+			for i, id := range pending {
+				request, err := c.batchRequest(batchOperation{method: http.MethodPatch, collection: c.Name, id: id, body: patch})
+				if err != nil {
+					return result, fmt.Errorf("[update-where] can't prepare update of %s, err %w", id, err)
+				}
+				requests[i] = request
+			}
+
+			_, err := c.batch(ctx, requests)
+			var berr *BatchError
+			switch {
+			case err == nil:
+				result.Updated += len(pending)
+				pending = nil
+			case errors.As(err, &berr) && berr.Index < len(pending):
+				if result.Failed == nil {
+					result.Failed = map[string]error{}
+				}
+				result.Failed[pending[berr.Index]] = berr
+				pending = append(pending[:berr.Index:berr.Index], pending[berr.Index+1:]...)
+			default:
+				return result, fmt.Errorf("[update-where] can't update records, err %w", err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// matchingIDs returns the ids of the records matching the filter.
+func (c *Collection[T]) matchingIDs(ctx context.Context, filter string) ([]string, error) {
+	params := ParamsList{Page: 1, Size: 500, Filters: filter, Sort: "id", Fields: "id"}
+	var ids []string
+	for {
+		var page ResponseList[struct {
+			ID string `json:"id"`
+		}]
+		if err := c.Client.list(ctx, c.Name, params, &page); err != nil {
+			return nil, err
+		}
+		for _, record := range page.Items {
+			ids = append(ids, record.ID)
+		}
+		if params.Page >= page.TotalPages {
+			return ids, nil
+		}
+		params.Page++
+	}
+}
@@ -0,0 +1,75 @@
+package pocketbase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+)
+
+func TestCollection_UpdateWhere(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	marker := fmt.Sprintf("update-where-%d", time.Now().UnixNano())
+
+	var failing string
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithUpdateMutator(func(m Mutation) error {
+			if m.ID == failing {
+				m.Fields["id"] = "changed"
+			}
+			return nil
+		}),
+	)
+	collection := CollectionSet[post](client, migrations.PostsPublic)
+
+	var ids []string
+	for range 3 {
+		created, err := collection.Create(post{Field: marker})
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+	defer func() {
+		for _, id := range ids {
+			_ = collection.Delete(id)
+		}
+	}()
+	failing = ids[1]
+
+	filter := Filter("field = {:marker}", map[string]any{"marker": marker})
+	result, err := collection.UpdateWhere(filter, map[string]any{"field": marker + "-done"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Matched)
+	assert.Equal(t, 2, result.Updated)
+	require.Len(t, result.Failed, 1)
+
+	var berr *BatchError
+	require.ErrorAs(t, result.Failed[failing], &berr)
+	assert.Equal(t, 400, berr.StatusCode)
+	assert.Contains(t, berr.Fields, "id")
+	assert.ErrorIs(t, berr, ErrInvalidResponse)
+
+	for _, id := range ids {
+		record, err := collection.One(id)
+		require.NoError(t, err)
+		if id == failing {
+			assert.Equal(t, marker, record.Field)
+		} else {
+			assert.Equal(t, marker+"-done", record.Field)
+		}
+	}
+
+	result, err = collection.UpdateWhere(filter+" && field = 'none'", map[string]any{"field": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, UpdateResult{}, result)
+}
@@ -0,0 +1,102 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// defaultFilterLength bounds the URL-encoded length of the chunked filters, well
+	// below the usual URL limits of servers and proxies of 8KB.
+	defaultFilterLength = 4000
+	// minChunkLength is the smallest length left to the chunks of ListIn by its base filter.
+	minChunkLength = 500
+	// listInConcurrency is the number of chunks ListIn fetches at once.
+	listInConcurrency = 4
+)
+
+// InFilters returns filters matching the records whose field equals one of the values,
+// e.g. (id = 'a' || id = 'b'), chunked so that every URL-encoded filter stays under
+// maxLength, 4000 when 0. The duplicated values are dropped. A value longer than
+// maxLength gets a filter of its own.
+func InFilters[V any](field string, values []V, maxLength int) []string {
+	if maxLength <= 0 {
+		maxLength = defaultFilterLength
+	}
+
+	var filters []string
+	var chunk []string
+	length := 0
+	seen := map[string]bool{}
+	flush := func() {
+		if len(chunk) > 0 {
+			filters = append(filters, "("+strings.Join(chunk, " || ")+")")
+			chunk, length = nil, 0
+		}
+	}
+	for _, value := range values {
+		condition := field + " = " + filterLiteral(value)
+		if seen[condition] {
+			continue
+		}
+		seen[condition] = true
+
+		// the separator, or the parentheses of the first condition
+		size := len(url.QueryEscape(condition)) + len(url.QueryEscape(" || "))
+		if length > 0 && length+size > maxLength {
+			flush()
+		}
+		chunk = append(chunk, condition)
+		length += size
+	}
+	flush()
+	return filters
+}
+
+// ListIn lists the records of the collection whose field equals one of the values, e.g.
+// the records of a large set of ids, in chunks fetched concurrently with InFilters.
+// The params filter, sort, expand and fields apply to every chunk; the records are
+// merged in the order of the chunks. ListIn fails when the params filter leaves less than
+// 500 of the 4000 bytes of the URL-encoded filters to the chunks.
+//
+//	posts, err := pocketbase.ListIn(ctx, collection, "id", ids, pocketbase.ParamsList{Fields: "id,title"})
+func ListIn[T any, V any](ctx context.Context, c *Collection[T], field string, values []V, params ParamsList) ([]T, error) {
+	length := defaultFilterLength
+	if params.Filters != "" {
+		length -= len(url.QueryEscape("(" + params.Filters + ") && "))
+	}
+	if length < minChunkLength {
+		return nil, fmt.Errorf("[list] can't chunk the values of %s, the filter is too long: %d bytes", field, len(url.QueryEscape(params.Filters)))
+	}
+	filters := InFilters(field, values, length)
+	results := make([][]T, len(filters))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(listInConcurrency)
+	for i, filter := range filters {
+		chunk := params
+		chunk.Page = 1
+		chunk.Filters = filter
+		if params.Filters != "" {
+			chunk.Filters = "(" + params.Filters + ") && " + filter
+		}
+		g.Go(func() error {
+			r, _, err := c.FullListPartial(ctx, chunk)
+			results[i] = r.Items
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var items []T
+	for _, r := range results {
+		items = append(items, r...)
+	}
+	return items, nil
+}
@@ -0,0 +1,94 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFilters(t *testing.T) {
+	assert.Equal(t, []string{"(id = 'a' || id = 'b\\'')"}, InFilters("id", []string{"a", "b'", "a"}, 0))
+	assert.Equal(t, []string{"(n = 1 || n = 2)", "(n = 3)"}, InFilters("n", []int{1, 2, 3}, 30))
+	assert.Empty(t, InFilters("id", []string{}, 0))
+
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%015d", i)
+	}
+	filters := InFilters("id", ids, 0)
+	assert.Greater(t, len(filters), 1)
+	for _, filter := range filters {
+		assert.LessOrEqual(t, len(url.QueryEscape(filter)), 4000)
+	}
+}
+
+func TestListIn(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	collection := CollectionSet[post](client, migrations.PostsPublic)
+
+	ids := []string{"missing"}
+	for i := range 5 {
+		created, err := collection.Create(post{Field: fmt.Sprintf("chunk_%d", i)})
+		require.NoError(t, err)
+		defer func() {
+			_ = collection.Delete(created.ID)
+		}()
+		ids = append(ids, created.ID)
+	}
+
+	posts, err := ListIn(context.Background(), collection, "id", ids, ParamsList{Filters: "field != 'chunk_0'"})
+	require.NoError(t, err)
+	assert.Len(t, posts, 4)
+
+	fields, err := ListIn(context.Background(), collection, "field", []string{"chunk_1", "chunk_2"}, ParamsList{Sort: "field"})
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+}
+
+func TestListIn_FilterLength(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		lengths []int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lengths = append(lengths, len(url.QueryEscape(r.URL.Query().Get("filter"))))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 500, "totalItems": 0, "totalPages": 1, "items": []}`)
+	}))
+	t.Cleanup(srv.Close)
+	collection := CollectionSet[map[string]any](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+	ids := make([]string, 500)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%015d", i)
+	}
+
+	_, err := ListIn(context.Background(), collection, "id", ids, ParamsList{Filters: "title != '" + strings.Repeat("a", 3000) + "'"})
+	require.NoError(t, err)
+	assert.Greater(t, len(lengths), 1)
+	for _, length := range lengths {
+		assert.LessOrEqual(t, length, 4000, "the chunks leave room to the base filter")
+	}
+
+	lengths = nil
+	_, err = ListIn(context.Background(), collection, "id", ids, ParamsList{Filters: "title != '" + strings.Repeat("a", 3900) + "'"})
+	assert.ErrorContains(t, err, "the filter is too long")
+	assert.Empty(t, lengths)
+}
@@ -15,9 +15,13 @@
 package pocketbase
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	neturl "net/url"
 	"os"
 	"strings"
 	"time"
@@ -27,18 +31,59 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 )
 
-// ErrInvalidResponse is returned when PocketBase returns an invalid response.
-var ErrInvalidResponse = errors.New("invalid response")
+var (
+	// ErrInvalidResponse is returned when PocketBase returns an invalid response.
+	ErrInvalidResponse = errors.New("invalid response")
+	// ErrInvalidURL is returned when the client URL is not an absolute http(s) URL.
+	ErrInvalidURL = errors.New("invalid url")
+	// ErrNotAuthenticated is returned when the token of a token auth store was cleared.
+	ErrNotAuthenticated = errors.New("not authenticated")
+)
 
 type (
 	// Client represents a PocketBase API client with authentication and HTTP capabilities.
 	Client struct {
-		client     *resty.Client
-		url        string
-		authorizer authStore
-		token      string
-		sseDebug   bool
-		restDebug  bool
+		client        *resty.Client
+		url           string
+		authorizer    AuthStore
+		token         string
+		sseDebug      bool
+		restDebug     bool
+		opts          []ClientOption
+		realtime      *Realtime
+		observers     []Observer
+		timingHooks   []TimingHook
+		responseHooks []ResponseHook
+		redaction     Redaction
+		resolver      *net.Resolver
+		dnsCache      *DNSCache
+		hooks         *hookRegistry
+		validators    []Validator
+		tagValidation bool
+		codec         *recordCodec
+
+		createMutators []Mutator
+		updateMutators []Mutator
+
+		retryBudget        *RetryBudget
+		retryMaxElapsed    time.Duration
+		retryNonIdempotent bool
+		idempotencyKeys    bool
+		callTimeout        time.Duration
+
+		// compressThreshold is the size of the smallest gzipped request bodies, 0 without compression.
+		compressThreshold int
+		// maxResponseSize caps the size of the responses, 0 without cap.
+		maxResponseSize int
+		// paging adapts the page size of FullList, nil for pages of 500 records.
+		paging *AdaptivePaging
+		// snapshot is the consistency mode of FullList.
+		snapshot Snapshot
+
+		clock *serverClock
+		// inheritAuth skips the auth options replayed by Clone, which keeps the authorization
+		// of the original client.
+		inheritAuth bool
 	}
 	// ClientOption is a function type for configuring Client instances.
 	ClientOption func(*Client)
@@ -50,8 +95,34 @@ func EnvIsTruthy(key string) bool {
 	return val == "1" || val == "true" || val == "yes"
 }
 
+// NewClientE creates a new PocketBase API client like NewClient,
+// but returns ErrInvalidURL when the URL is not an absolute http(s) URL.
+func NewClientE(rawURL string, opts ...ClientOption) (*Client, error) {
+	u, err := normalizeURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(u, opts...), nil
+}
+
 // NewClient creates a new PocketBase API client with the specified URL and options.
+//
+// Trailing slashes are stripped from the URL. Use NewClientE to also validate it.
 func NewClient(url string, opts ...ClientOption) *Client {
+	if u, err := normalizeURL(url); err == nil {
+		url = u
+	} else {
+		url = strings.TrimRight(strings.TrimSpace(url), "/")
+	}
+
+	c := newClient(url)
+	c.opts = append([]ClientOption{}, opts...)
+	c.configure(c.opts)
+	return c
+}
+
+// newClient returns a client of the URL with the default configuration.
+func newClient(url string) *Client {
 	client := resty.New()
 	client.
 		SetRetryCount(3).
@@ -62,20 +133,101 @@ func NewClient(url string, opts ...ClientOption) *Client {
 		client:     client,
 		url:        url,
 		authorizer: authorizeNoOp{},
+		realtime:   newRealtime(),
+		codec:      defaultCodec,
+		clock:      &serverClock{},
+		redaction:  DefaultRedaction,
+		hooks:      &hookRegistry{},
+	}
+	client.OnBeforeRequest(c.setAuthorization)
+	client.OnBeforeRequest(markRequestStart)
+	client.OnBeforeRequest(c.boundRequest)
+	client.OnBeforeRequest(c.bindRedaction)
+	client.OnBeforeRequest(c.setIdempotencyKey)
+	client.SetRetryAfter(c.retryAfter)
+	client.AddRetryHook(markResponseTooLarge)
+	client.OnAfterResponse(c.observeClock)
+	client.OnAfterResponse(c.limitResponseSize)
+	client.OnSuccess(c.observeSuccess)
+	client.OnError(c.observeError)
+	client.OnInvalid(c.observeError)
+	client.OnSuccess(func(_ *resty.Client, resp *resty.Response) { releaseRequest(resp.Request) })
+	client.OnError(func(r *resty.Request, _ error) { releaseRequest(r) })
+	client.OnInvalid(func(r *resty.Request, _ error) { releaseRequest(r) })
+	c.redactLogs()
+	return c
+}
+
+// configure applies the options, then the debug options enabled by the environment.
+func (c *Client) configure(opts []ClientOption) {
+	for _, opt := range opts {
+		opt(c)
 	}
-	opts = append([]ClientOption{}, opts...)
 	if EnvIsTruthy("REST_DEBUG") {
-		opts = append(opts, WithRestDebug())
+		WithRestDebug()(c)
 	}
 	if EnvIsTruthy("SSE_DEBUG") {
-		opts = append(opts, WithSseDebug())
+		WithSseDebug()(c)
 	}
+}
 
+// Clone returns a new client with the same configuration, optionally overridden by opts.
+//
+// The clone shares the HTTP transport (connection pool) and the authorization of the
+// original client, unless an auth option is passed. It is cheap enough to derive
+// request-scoped clients, e.g. per tenant or per impersonated user:
+//
+//	userClient := client.Clone(pocketbase.WithUserToken(token), pocketbase.WithTimeout(5*time.Second))
+func (c *Client) Clone(opts ...ClientOption) *Client {
+	clone := newClient(c.url)
+	clone.inheritAuth = true
+	clone.configure(c.opts)
+	clone.inheritAuth = false
+	clone.client.SetTransport(c.client.GetClient().Transport)
+	clone.authorizer = c.authorizer
+	clone.clock = c.clock
+	clone.token = c.token
+	clone.hooks = c.hooks
+
+	clone.opts = append(append([]ClientOption{}, c.opts...), opts...)
 	for _, opt := range opts {
-		opt(c)
+		opt(clone)
 	}
 
-	return c
+	return clone
+}
+
+// normalizeURL validates the client URL and strips trailing slashes.
+func normalizeURL(rawURL string) (string, error) {
+	rawURL = strings.TrimRight(strings.TrimSpace(rawURL), "/")
+
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", ErrInvalidURL, rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%w %q: scheme must be http or https", ErrInvalidURL, rawURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%w %q: missing host", ErrInvalidURL, rawURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w %q: query and fragment are not allowed", ErrInvalidURL, rawURL)
+	}
+
+	return u.String(), nil
+}
+
+// setAuthorization is a request middleware adding the current auth token
+// to every request which doesn't set the Authorization header explicitly.
+func (c *Client) setAuthorization(_ *resty.Client, r *resty.Request) error {
+	if _, ok := r.Header["Authorization"]; ok {
+		return nil
+	}
+	if token := c.authorizer.Token(); token != "" {
+		r.SetHeader("Authorization", token)
+	}
+	return nil
 }
 
 // WithRestDebug enables REST API debug logging for the client.
@@ -95,8 +247,25 @@ func WithSseDebug() ClientOption {
 
 // WithAdminEmailPassword22 configures admin authentication using email and password (legacy version).
 func WithAdminEmailPassword22(email, password string) ClientOption {
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeEmailPassword(c.client, c.clock, c.url+"/api/admins/auth-with-password", email, password)
+	})
+}
+
+// WithHeader sets a header sent with every request, e.g. for auth proxies or tenant routing.
+func WithHeader(key, value string) ClientOption {
 	return func(c *Client) {
-		c.authorizer = newAuthorizeEmailPassword(c.client, c.url+"/api/admins/auth-with-password", email, password)
+		c.client.SetHeader(key, value)
+	}
+}
+
+// WithTransport sets the HTTP transport, e.g. to share a connection pool between clients.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		if gate, ok := c.client.GetClient().Transport.(*healthGate); ok {
+			transport = gate.withNext(transport)
+		}
+		c.client.SetTransport(transport)
 	}
 }
 
@@ -107,7 +276,8 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
-// WithRetry set the retry settings for requests (defaults: count=3, waitTime=3s, maxWaitTime=10s)
+// WithRetry set the retry settings for requests (defaults: count=3, waitTime=3s, maxWaitTime=10s).
+// Only the idempotent requests are retried, see WithRetryNonIdempotent.
 func WithRetry(count int, waitTime, maxWaitTime time.Duration) ClientOption {
 	return func(c *Client) {
 		c.client.SetRetryCount(count)
@@ -118,72 +288,113 @@ func WithRetry(count int, waitTime, maxWaitTime time.Duration) ClientOption {
 
 // WithAdminEmailPassword configures admin authentication using email and password.
 func WithAdminEmailPassword(email, password string) ClientOption {
-	return func(c *Client) {
-		c.authorizer = newAuthorizeEmailPassword(c.client, c.url+fmt.Sprintf("/api/collections/%s/auth-with-password", core.CollectionNameSuperusers), email, password)
-	}
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeEmailPassword(c.client, c.clock, c.url+fmt.Sprintf("/api/collections/%s/auth-with-password", core.CollectionNameSuperusers), email, password)
+	})
 }
 
 // WithUserEmailPassword configures user authentication using email and password.
 func WithUserEmailPassword(email, password string) ClientOption {
-	return func(c *Client) {
-		c.authorizer = newAuthorizeEmailPassword(c.client, c.url+"/api/collections/users/auth-with-password", email, password)
-	}
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeEmailPassword(c.client, c.clock, c.url+"/api/collections/users/auth-with-password", email, password)
+	})
 }
 
 // WithUserEmailPasswordAndCollection configures user authentication for a specific collection.
 func WithUserEmailPasswordAndCollection(email, password, collection string) ClientOption {
-	return func(c *Client) {
-		c.authorizer = newAuthorizeEmailPassword(c.client, c.url+"/api/collections/"+collection+"/auth-with-password", email, password)
-	}
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeEmailPassword(c.client, c.clock, c.url+"/api/collections/"+collection+"/auth-with-password", email, password)
+	})
 }
 
 // WithAdminToken22 configures admin authentication using a token (legacy version).
 func WithAdminToken22(token string) ClientOption {
-	return func(c *Client) {
-		c.authorizer = newAuthorizeToken(c.client, c.url+"/api/admins/auth-refresh", token)
-	}
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeToken(c.client, c.clock, c.url+"/api/admins/auth-refresh", token)
+	})
 }
 
 // WithAdminToken configures admin authentication using a token.
 func WithAdminToken(token string) ClientOption {
-	return func(c *Client) {
-		c.authorizer = newAuthorizeToken(c.client, c.url+fmt.Sprintf("/api/collections/%s/auth-refresh", core.CollectionNameSuperusers), token)
-	}
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeToken(c.client, c.clock, c.url+fmt.Sprintf("/api/collections/%s/auth-refresh", core.CollectionNameSuperusers), token)
+	})
 }
 
 // WithUserToken configures user authentication using a token.
 func WithUserToken(token string) ClientOption {
+	return authOption(func(c *Client) AuthStore {
+		return newAuthorizeToken(c.client, c.clock, c.url+"/api/collections/users/auth-refresh", token)
+	})
+}
+
+// WithAuthorizer configures a custom authorization method,
+// e.g. fetching tokens from a secrets manager or an SSO sidecar.
+//
+// If the authorizer doesn't implement AuthStore, the token is considered
+// valid while it is not empty, and Model and Clear are no-ops.
+func WithAuthorizer(authorizer Authorizer) ClientOption {
+	return authOption(func(*Client) AuthStore {
+		if store, ok := authorizer.(AuthStore); ok {
+			return store
+		}
+		return authorizeCustom{Authorizer: authorizer}
+	})
+}
+
+// authOption returns the option setting the authorizer built by build, unless it is replayed
+// by Clone.
+func authOption(build func(c *Client) AuthStore) ClientOption {
 	return func(c *Client) {
-		c.authorizer = newAuthorizeToken(c.client, c.url+"/api/collections/users/auth-refresh", token)
+		if !c.inheritAuth {
+			c.authorizer = build(c)
+		}
 	}
 }
 
 // Authorize performs authentication using the configured authorization method.
 func (c *Client) Authorize() error {
-	return c.authorizer.authorize()
+	return c.authorizer.Authorize()
 }
 
 // Update updates a record in the specified collection.
 func (c *Client) Update(collection string, id string, body any) error {
+	if err := c.validate(collection, body, true); err != nil {
+		return err
+	}
+	body, err := c.codec.encode(body)
+	if err != nil {
+		return fmt.Errorf("[update] can't marshal body, err %w", err)
+	}
+	return c.update(collection, id, body)
+}
+
+// update sends the encoded body of an update.
+func (c *Client) update(collection string, id string, body any) error {
 	if err := c.Authorize(); err != nil {
 		return err
 	}
+	body, err := c.mutate(c.updateMutators, collection, id, body)
+	if err != nil {
+		return fmt.Errorf("[update] can't mutate body, err %w", err)
+	}
 
 	request := c.client.R().
-		SetHeader("Content-Type", "application/json").
-		SetPathParam("collection", collection).
-		SetBody(body)
+		SetPathParam("collection", collection)
+	if err := c.setJSONBody(request, body); err != nil {
+		return fmt.Errorf("[update] can't marshal body, err %w", err)
+	}
 
 	resp, err := request.Patch(c.url + "/api/collections/{collection}/records/" + id)
 	if err != nil {
 		return fmt.Errorf("[update] can't send update request to pocketbase, err %w", err)
 	}
 	if resp.IsError() {
-		return fmt.Errorf("[update] pocketbase returned status: %d, msg: %s, err %w",
+		return newValidationError(resp, newAPIError(resp, fmt.Errorf("[update] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		)))
 	}
 
 	return nil
@@ -209,14 +420,14 @@ func (c *Client) Get(path string, result any, onRequest func(*resty.Request), on
 		onResponse(resp)
 	}
 	if resp.IsError() {
-		return fmt.Errorf("[get] pocketbase returned status: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[get] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), result); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), result); err != nil {
 		return fmt.Errorf("[get] failed to unmarshal response: %w", err)
 	}
 
@@ -224,34 +435,53 @@ func (c *Client) Get(path string, result any, onRequest func(*resty.Request), on
 }
 
 // Create creates a new record in the specified collection.
-func (c *Client) Create(collection string, body any) (ResponseCreate, error) {
-	var response ResponseCreate
+func (c *Client) Create(collection string, body any) (ResponseCreate[map[string]any], error) {
+	var response ResponseCreate[map[string]any]
+	err := c.create(collection, body, &response)
+	return response, err
+}
 
+// create creates a new record and unmarshals the created record into result.
+func (c *Client) create(collection string, body any, result any) error {
+	if err := c.validate(collection, body, false); err != nil {
+		return err
+	}
+	body, err := c.codec.encode(body)
+	if err != nil {
+		return fmt.Errorf("[create] can't marshal body, err %w", err)
+	}
 	if err := c.Authorize(); err != nil {
-		return response, err
+		return err
+	}
+	body, err = c.mutate(c.createMutators, collection, "", body)
+	if err != nil {
+		return fmt.Errorf("[create] can't mutate body, err %w", err)
 	}
 
 	request := c.client.R().
-		SetHeader("Content-Type", "application/json").
-		SetPathParam("collection", collection).
-		SetBody(body).
-		SetResult(&response)
+		SetPathParam("collection", collection)
+	if err := c.setJSONBody(request, body); err != nil {
+		return fmt.Errorf("[create] can't marshal body, err %w", err)
+	}
 
 	resp, err := request.Post(c.url + "/api/collections/{collection}/records")
 	if err != nil {
-		return response, fmt.Errorf("[create] can't send update request to pocketbase, err %w", err)
+		return fmt.Errorf("[create] can't send update request to pocketbase, err %w", err)
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[create] pocketbase returned status: %d, msg: %s, body: %s, err %w",
+		return newValidationError(resp, newAPIError(resp, fmt.Errorf("[create] pocketbase returned status: %d, msg: %s, body: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
-			fmt.Sprintf("%+v", body), // TODO remove that after debugging
+			responseBody(resp),
+			c.redaction.redactValue(body),
 			ErrInvalidResponse,
-		)
+		)))
 	}
 
-	return *resp.Result().(*ResponseCreate), nil
+	if err := c.codec.unmarshalJSON(resp.Body(), result); err != nil {
+		return fmt.Errorf("[create] can't unmarshal response, err %w", err)
+	}
+	return nil
 }
 
 // Delete removes a record from the specified collection.
@@ -271,11 +501,11 @@ func (c *Client) Delete(collection string, id string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[delete] pocketbase returned status: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[delete] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
 	return nil
@@ -300,14 +530,14 @@ func (c *Client) One(collection string, id string) (map[string]any, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[one] pocketbase returned status: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[one] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[one] can't unmarshal response, err %w", err)
 	}
 
@@ -331,14 +561,14 @@ func (c *Client) OneTo(collection string, id string, result any) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[oneTo] pocketbase returned status: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[oneTo] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), result); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), result); err != nil {
 		return fmt.Errorf("[oneTo] can't unmarshal response, err %w", err)
 	}
 
@@ -349,11 +579,22 @@ func (c *Client) OneTo(collection string, id string, result any) error {
 func (c *Client) List(collection string, params ParamsList) (ResponseList[map[string]any], error) {
 	var response ResponseList[map[string]any]
 
+	var responseRef any = &response
+	if params.hackResponseRef != nil {
+		responseRef = params.hackResponseRef
+	}
+	err := c.list(context.Background(), collection, params, responseRef)
+	return response, err
+}
+
+// list retrieves a page of records and unmarshals it into result.
+func (c *Client) list(ctx context.Context, collection string, params ParamsList, result any) error {
 	if err := c.Authorize(); err != nil {
-		return response, err
+		return err
 	}
 
 	request := c.client.R().
+		SetContext(ctx).
 		SetHeader("Content-Type", "application/json").
 		SetPathParam("collection", collection)
 
@@ -375,31 +616,32 @@ func (c *Client) List(collection string, params ParamsList) (ResponseList[map[st
 	if params.Fields != "" {
 		request.SetQueryParam("fields", params.Fields)
 	}
+	if params.skipTotal {
+		request.SetQueryParam("skipTotal", "1")
+	}
 
 	resp, err := request.Get(c.url + "/api/collections/{collection}/records")
 	if err != nil {
-		return response, fmt.Errorf("[list] can't send update request to pocketbase, err %w", err)
+		return fmt.Errorf("[list] can't send update request to pocketbase, err %w", err)
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[list] pocketbase returned status: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[list] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	var responseRef any = &response
-	if params.hackResponseRef != nil {
-		responseRef = params.hackResponseRef
-	}
-	if err := json.Unmarshal(resp.Body(), responseRef); err != nil {
-		return response, fmt.Errorf("[list] can't unmarshal response, err %w", err)
+	if err := c.codec.unmarshalJSON(resp.Body(), result); err != nil {
+		return fmt.Errorf("[list] can't unmarshal response, err %w", err)
 	}
-	return response, nil
+	return nil
 }
 
-// FullList retrieves all records from the specified collection without pagination.
+// FullList retrieves all records from the specified collection without pagination, in pages
+// of 500 records, or of the adaptive size of WithAdaptivePaging, and with the consistency
+// mode of WithSnapshot.
 func (c *Client) FullList(collection string, params ParamsList) (ResponseList[map[string]any], error) {
 	var response ResponseList[map[string]any]
 	params.Page = 1
@@ -409,8 +651,29 @@ func (c *Client) FullList(collection string, params ParamsList) (ResponseList[ma
 		return response, err
 	}
 
-	r, e := c.List(collection, params)
-	if e != nil {
+	ctx, cancel := c.callContext(c.sizedContext(context.Background()))
+	defer cancel()
+	switch c.snapshot {
+	case SnapshotKeyset:
+		return keysetList(ctx, c, collection, params, func(raw json.RawMessage, item *map[string]any) error {
+			return c.codec.unmarshalJSON(raw, item)
+		})
+	case SnapshotCreated:
+		pinned, err := c.pinCreated(ctx, collection, params)
+		if err != nil {
+			return response, err
+		}
+		params = pinned
+	}
+	if c.paging != nil {
+		return adaptiveList(ctx, c, params, func(ctx context.Context, params ParamsList) (ResponseList[map[string]any], error) {
+			var r ResponseList[map[string]any]
+			err := c.list(ctx, collection, params, &r)
+			return r, err
+		})
+	}
+	var r ResponseList[map[string]any]
+	if e := c.list(ctx, collection, params, &r); e != nil {
 		return response, e
 	}
 	response.Items = append(response.Items, r.Items...)
@@ -421,8 +684,8 @@ func (c *Client) FullList(collection string, params ParamsList) (ResponseList[ma
 
 	for i := 2; i <= r.TotalPages; i++ { // Start from page 2 because first page is already fetched
 		params.Page = i
-		r, e := c.List(collection, params)
-		if e != nil {
+		var r ResponseList[map[string]any]
+		if e := c.list(ctx, collection, params, &r); e != nil {
 			return response, e
 		}
 		response.Items = append(response.Items, r.Items...)
@@ -432,10 +695,15 @@ func (c *Client) FullList(collection string, params ParamsList) (ResponseList[ma
 }
 
 // AuthStore returns the client's authentication store.
-func (c *Client) AuthStore() authStore {
+func (c *Client) AuthStore() AuthStore {
 	return c.authorizer
 }
 
+// Realtime returns the manager of the client's realtime subscriptions.
+func (c *Client) Realtime() *Realtime {
+	return c.realtime
+}
+
 // Backup returns a Backup instance for managing backup operations.
 func (c *Client) Backup() Backup {
 	return Backup{
@@ -443,6 +711,13 @@ func (c *Client) Backup() Backup {
 	}
 }
 
+// Custom returns a Custom instance for calling the custom routes of the cmd/pocketbase server.
+func (c *Client) Custom() Custom {
+	return Custom{
+		Client: c,
+	}
+}
+
 // Files returns a Files instance for managing file operations.
 func (c *Client) Files() Files {
 	return Files{
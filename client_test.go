@@ -1,9 +1,14 @@
 package pocketbase
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -481,3 +486,258 @@ func TestClient_OneTo(t *testing.T) {
 	err = client.Delete(migrations.PostsPublic, resultCreated.ID)
 	assert.NoError(t, err)
 }
+
+func TestClient_GetResponseMeta(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+
+	var meta ResponseMeta
+	var result map[string]any
+	err := client.Get("/api/health", &result, nil, func(r *resty.Response) {
+		meta = NewResponseMeta(r)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.False(t, meta.Date.IsZero())
+	assert.Positive(t, meta.Duration)
+}
+
+func TestClient_Clone(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	admin := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	require.NoError(t, admin.Authorize())
+
+	t.Run("clone shares authorization", func(t *testing.T) {
+		clone := admin.Clone()
+		assert.Equal(t, admin.AuthStore().Token(), clone.AuthStore().Token())
+
+		r, err := clone.List(migrations.PostsAdmin, ParamsList{})
+		assert.NoError(t, err)
+		assert.Positive(t, r.TotalItems)
+	})
+
+	t.Run("clone overrides authorization", func(t *testing.T) {
+		clone := admin.Clone(WithUserEmailPassword(migrations.UserEmailPassword, migrations.UserEmailPassword))
+		_, err := clone.List(migrations.PostsAdmin, ParamsList{})
+		assert.Error(t, err)
+
+		r, err := clone.List(migrations.PostsUser, ParamsList{})
+		assert.NoError(t, err)
+		assert.Positive(t, r.TotalItems)
+
+		// the original client keeps its admin session
+		r, err = admin.List(migrations.PostsAdmin, ParamsList{})
+		assert.NoError(t, err)
+		assert.Positive(t, r.TotalItems)
+	})
+}
+
+func TestClient_CloneOptions(t *testing.T) {
+	t.Setenv("REST_DEBUG", "1")
+	built := 0
+	counted := authOption(func(c *Client) AuthStore {
+		built++
+		return newAuthorizeToken(c.client, c.clock, c.url+"/api/collections/users/auth-refresh", "token")
+	})
+	base := NewClient("http://127.0.0.1:8090", counted, WithTimeout(time.Second))
+	require.Equal(t, 1, built)
+
+	clone := base.Clone(WithHeader("X-Tenant", "clone"))
+	assert.Equal(t, 1, built, "the auth options aren't replayed")
+	assert.Same(t, base.AuthStore(), clone.AuthStore())
+	assert.Len(t, clone.opts, 3, "the debug options of the environment aren't kept")
+	assert.True(t, clone.restDebug)
+
+	_ = clone.Clone(counted)
+	assert.Equal(t, 2, built, "the auth options of the clone are applied")
+}
+
+func TestClient_CloneHeaders(t *testing.T) {
+	headers := make(chan http.Header, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/health" {
+			headers <- r.Header.Clone()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"token":"refreshed-token"}`))
+	}))
+	defer server.Close()
+
+	base := NewClient(server.URL, WithHeader("X-Tenant", "base"), WithUserToken("base-token"))
+	clone := base.Clone(WithHeader("X-Tenant", "clone"))
+
+	_, err := clone.Send(context.Background(), Request{Path: "/api/health"})
+	require.NoError(t, err)
+	h := <-headers
+	assert.Equal(t, "clone", h.Get("X-Tenant"))
+	assert.Equal(t, "refreshed-token", h.Get("Authorization"))
+
+	_, err = base.Send(context.Background(), Request{Path: "/api/health"})
+	require.NoError(t, err)
+	assert.Equal(t, "base", (<-headers).Get("X-Tenant"))
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"plain", "http://127.0.0.1:8090", "http://127.0.0.1:8090", false},
+		{"trailing slash", "http://127.0.0.1:8090/", "http://127.0.0.1:8090", false},
+		{"multiple trailing slashes and spaces", " https://pb.example.com// ", "https://pb.example.com", false},
+		{"sub path", "https://example.com/pb/", "https://example.com/pb", false},
+		{"missing scheme", "127.0.0.1:8090", "", true},
+		{"host only", "pb.example.com", "", true},
+		{"unsupported scheme", "ftp://pb.example.com", "", true},
+		{"missing host", "http://", "", true},
+		{"query", "http://pb.example.com?x=1", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeURL(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidURL)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewClientE(t *testing.T) {
+	c, err := NewClientE("http://127.0.0.1:8090/")
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8090", c.url)
+
+	c, err = NewClientE("127.0.0.1:8090")
+	assert.ErrorIs(t, err, ErrInvalidURL)
+	assert.Nil(t, c)
+
+	// NewClient never fails, but still strips trailing slashes
+	assert.Equal(t, "http://127.0.0.1:8090", NewClient("http://127.0.0.1:8090/").url)
+	assert.Equal(t, "localhost:8090", NewClient("localhost:8090/").url)
+}
+
+func TestClient_AuthStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Run("anonymous", func(t *testing.T) {
+		store := NewClient(defaultURL).AuthStore()
+		assert.Empty(t, store.Token())
+		assert.False(t, store.IsValid())
+		assert.Nil(t, store.Model())
+	})
+
+	t.Run("email and password", func(t *testing.T) {
+		c := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+		store := c.AuthStore()
+		assert.False(t, store.IsValid())
+
+		require.NoError(t, c.Authorize())
+		assert.True(t, store.IsValid())
+		assert.NotEmpty(t, store.Token())
+		assert.Equal(t, migrations.AdminEmailPassword, store.Model()["email"])
+
+		store.Clear()
+		assert.False(t, store.IsValid())
+		assert.Empty(t, store.Token())
+		assert.Nil(t, store.Model())
+
+		// the next call authorizes again
+		_, err := c.List(migrations.PostsAdmin, ParamsList{})
+		assert.NoError(t, err)
+		assert.True(t, store.IsValid())
+	})
+
+	t.Run("token", func(t *testing.T) {
+		c := NewClient(defaultURL, WithUserEmailPassword(migrations.UserEmailPassword, migrations.UserEmailPassword))
+		require.NoError(t, c.Authorize())
+
+		tc := NewClient(defaultURL, WithUserToken(c.AuthStore().Token()))
+		require.NoError(t, tc.Authorize())
+		assert.True(t, tc.AuthStore().IsValid())
+		assert.Equal(t, migrations.UserEmailPassword, tc.AuthStore().Model()["email"])
+
+		tc.AuthStore().Clear()
+		assert.ErrorIs(t, tc.Authorize(), ErrNotAuthenticated)
+		_, err := tc.List(migrations.PostsUser, ParamsList{})
+		assert.ErrorIs(t, err, ErrNotAuthenticated)
+	})
+}
+
+// secretAuthorizer simulates an authorizer fetching tokens from an external secrets store.
+type secretAuthorizer struct {
+	fetch func() (string, error)
+	token string
+}
+
+func (a *secretAuthorizer) Authorize() error {
+	if a.token != "" {
+		return nil
+	}
+	token, err := a.fetch()
+	if err != nil {
+		return err
+	}
+	a.token = token
+	return nil
+}
+
+func (a *secretAuthorizer) Token() string {
+	return a.token
+}
+
+func TestWithAuthorizer(t *testing.T) {
+	t.Run("authorization error", func(t *testing.T) {
+		errSecret := errors.New("secret store unavailable")
+		c := NewClient(defaultURL, WithAuthorizer(&secretAuthorizer{
+			fetch: func() (string, error) { return "", errSecret },
+		}))
+		_, err := c.List(migrations.PostsAdmin, ParamsList{})
+		assert.ErrorIs(t, err, errSecret)
+		assert.False(t, c.AuthStore().IsValid())
+		assert.Nil(t, c.AuthStore().Model())
+	})
+
+	t.Run("custom auth store is used as is", func(t *testing.T) {
+		store := NewClient(defaultURL, WithUserToken("token")).AuthStore()
+		c := NewClient(defaultURL, WithAuthorizer(store))
+		assert.Same(t, store, c.AuthStore())
+	})
+
+	t.Run("token from secret store", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration test in short mode")
+		}
+		source := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+		fetches := 0
+		c := NewClient(defaultURL, WithAuthorizer(&secretAuthorizer{
+			fetch: func() (string, error) {
+				fetches++
+				if err := source.Authorize(); err != nil {
+					return "", err
+				}
+				return source.AuthStore().Token(), nil
+			},
+		}))
+
+		for range 2 {
+			r, err := c.List(migrations.PostsAdmin, ParamsList{})
+			require.NoError(t, err)
+			assert.Positive(t, r.TotalItems)
+		}
+		assert.Equal(t, 1, fetches)
+		assert.True(t, c.AuthStore().IsValid())
+	})
+}
@@ -0,0 +1,78 @@
+package pocketbase
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// tokenRefreshInterval is the longest time a token is used before being refreshed.
+	tokenRefreshInterval = 60 * time.Minute
+	// tokenRefreshMargin is how long before its expiry a token is refreshed.
+	tokenRefreshMargin = time.Minute
+)
+
+// serverClock estimates the clock skew between the client and the server from the
+// Date header of the responses.
+type serverClock struct {
+	// skew is the server time minus the local time, in nanoseconds.
+	skew atomic.Int64
+}
+
+// Skew returns the estimated server time minus the local time, zero until a response with
+// a Date header is received.
+func (sc *serverClock) Skew() time.Duration {
+	return time.Duration(sc.skew.Load())
+}
+
+// observe is a response middleware updating the skew from the Date header. The header has
+// a precision of one second, so the server time is taken in the middle of its second, and
+// compared to the local time in the middle of the exchange.
+func (sc *serverClock) observe(_ *resty.Client, resp *resty.Response) error {
+	date, err := http.ParseTime(resp.Header().Get("Date"))
+	if err != nil {
+		return nil
+	}
+	received := resp.ReceivedAt()
+	if received.IsZero() {
+		received = time.Now()
+	}
+	local := received.Add(-resp.Time() / 2)
+	sc.skew.Store(int64(date.Add(500 * time.Millisecond).Sub(local)))
+	return nil
+}
+
+// tokenValidUntil returns the local time until which the token is used, before its
+// expiry in local time with a margin, and at most tokenRefreshInterval from now.
+func (sc *serverClock) tokenValidUntil(token string) time.Time {
+	now := time.Now()
+	valid := now.Add(tokenRefreshInterval)
+
+	claims, err := ParseToken(token)
+	if err != nil || claims.ExpiresAt.IsZero() {
+		return valid
+	}
+	expires := claims.ExpiresAt.Add(-sc.Skew())
+	// short-lived tokens are refreshed after 9/10 of their remaining lifetime
+	margin := min(tokenRefreshMargin, expires.Sub(now)/10)
+	if expires = expires.Add(-margin); expires.Before(valid) {
+		return expires
+	}
+	return valid
+}
+
+// ClockSkew returns the estimated difference between the server clock and the local clock,
+// positive when the server is ahead, from the Date header of the last response. It is
+// factored into the expiry checks of the auth tokens, so clients with drifted clocks
+// refresh them neither too late nor too often.
+func (c *Client) ClockSkew() time.Duration {
+	return c.clock.Skew()
+}
+
+// observeClock is a response middleware updating the clock skew of the client, shared with its clones.
+func (c *Client) observeClock(rc *resty.Client, resp *resty.Response) error {
+	return c.clock.observe(rc, resp)
+}
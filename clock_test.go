@@ -0,0 +1,57 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkew(t *testing.T) {
+	tests := []struct {
+		name     string
+		skew     time.Duration
+		lifetime time.Duration
+		valid    time.Duration
+	}{
+		{name: "server ahead", skew: 2 * time.Hour, lifetime: 30 * time.Minute, valid: 29 * time.Minute},
+		{name: "server behind", skew: -2 * time.Hour, lifetime: 30 * time.Minute, valid: 29 * time.Minute},
+		{name: "long-lived token", skew: -2 * time.Hour, lifetime: 24 * time.Hour, valid: tokenRefreshInterval},
+		{name: "short-lived token", skew: 90 * time.Second, lifetime: 5 * time.Minute, valid: 4*time.Minute + 30*time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var auths atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				now := time.Now().Add(tt.skew)
+				w.Header().Set("Date", now.UTC().Format(http.TimeFormat))
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/collections/users/auth-with-password" {
+					auths.Add(1)
+					token := testToken(fmt.Sprintf(`{"id":"u1","type":"auth","exp":%d}`, now.Add(tt.lifetime).Unix()))
+					_, _ = fmt.Fprintf(w, `{"token": %q, "record": {"id": "u1"}}`, token)
+					return
+				}
+				_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 30, "totalItems": 0, "totalPages": 0, "items": []}`)
+			}))
+			t.Cleanup(srv.Close)
+
+			client := NewClient(srv.URL, WithRetry(0, 0, 0), WithUserEmailPassword("user@example.com", "password"))
+			for range 3 {
+				_, err := client.List("posts", ParamsList{})
+				require.NoError(t, err)
+			}
+			assert.EqualValues(t, 1, auths.Load())
+			assert.InDelta(t, tt.skew, client.ClockSkew(), float64(2*time.Second))
+			assert.Equal(t, client.ClockSkew(), client.Clone().ClockSkew())
+
+			store := client.AuthStore().(*authorizeEmailPassword)
+			assert.WithinDuration(t, time.Now().Add(tt.valid), store.tokenValid, 2*time.Second)
+		})
+	}
+}
@@ -1,18 +1,17 @@
 // Package main provides the PocketBase server executable.
 //
 // This is the main entry point for running a PocketBase server instance
-// with custom migrations and configurations.
+// with custom migrations and configurations, see the app package.
 package main
 
 import (
-	"github.com/pocketbase/pocketbase"
+	"github.com/Forty2Co/pocketbase/app"
 
 	_ "github.com/Forty2Co/pocketbase/migrations"
+	_ "github.com/Forty2Co/pocketbase/routes"
 )
 
 func main() {
-	app := pocketbase.New()
-
 	if err := app.Start(); err != nil {
 		panic(err)
 	}
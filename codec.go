@@ -0,0 +1,56 @@
+package pocketbase
+
+import "encoding/json"
+
+// WithJSONCodec replaces encoding/json for the bodies of the requests and responses of the
+// client and for its records, e.g. with a faster drop-in codec:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithJSONCodec(gojson.Marshal, gojson.Unmarshal))
+//
+// The functions must behave like encoding/json: follow the json tags, call the
+// json.Marshaler and json.Unmarshaler of the types and keep json.RawMessage as is. A nil
+// function keeps the one of encoding/json. The error responses, the redaction and the HAR
+// recordings still use encoding/json.
+func WithJSONCodec(marshal func(v any) ([]byte, error), unmarshal func(data []byte, v any) error) ClientOption {
+	return func(c *Client) {
+		if marshal == nil {
+			marshal = json.Marshal
+		}
+		if unmarshal == nil {
+			unmarshal = json.Unmarshal
+		}
+		codec := c.codec.clone()
+		codec.marshal, codec.unmarshal = marshal, unmarshal
+		c.codec = codec
+		c.client.SetJSONMarshaler(marshal)
+		c.client.SetJSONUnmarshaler(unmarshal)
+	}
+}
+
+// clone returns a codec with the options of rc and empty caches.
+func (rc *recordCodec) clone() *recordCodec {
+	return &recordCodec{
+		naming:      rc.naming,
+		strict:      rc.strict,
+		timeLayouts: rc.timeLayouts,
+		timeFormat:  rc.timeFormat,
+		marshal:     rc.marshal,
+		unmarshal:   rc.unmarshal,
+	}
+}
+
+// marshalJSON marshals v with the JSON codec of the client.
+func (rc *recordCodec) marshalJSON(v any) ([]byte, error) {
+	if rc.marshal == nil {
+		return json.Marshal(v)
+	}
+	return rc.marshal(v)
+}
+
+// unmarshalJSON unmarshals data into v with the JSON codec of the client.
+func (rc *recordCodec) unmarshalJSON(data []byte, v any) error {
+	if rc.unmarshal == nil {
+		return json.Unmarshal(data, v)
+	}
+	return rc.unmarshal(data, v)
+}
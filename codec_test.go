@@ -0,0 +1,108 @@
+package pocketbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCodec is encoding/json decoding the numbers as json.Number, counting its calls.
+type countingCodec struct {
+	marshals, unmarshals atomic.Int32
+}
+
+func (c *countingCodec) marshal(v any) ([]byte, error) {
+	c.marshals.Add(1)
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) unmarshal(data []byte, v any) error {
+	c.unmarshals.Add(1)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func TestClient_JSONCodec(t *testing.T) {
+	record := `{"id": "p1", "collectionName": "posts", "title": "hello", "views": 12345678901234567,
+		"author": "u1", "expand": {"author": {"id": "u1", "name": "ann"}}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		case r.URL.Path == "/api/collections/posts/records/p1":
+			_, _ = fmt.Fprint(w, record)
+		case r.URL.Path == "/api/collections/posts/records":
+			_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 30, "totalItems": 1, "totalPages": 1, "items": [%s]}`, record)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	codec := &countingCodec{}
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithJSONCodec(codec.marshal, codec.unmarshal))
+
+	one, err := client.One("posts", "p1")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("12345678901234567"), one["views"], "the responses are decoded by the codec")
+
+	type author struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	type post struct {
+		ID     string `json:"id"`
+		Title  string `pb:"title"`
+		Views  any    `json:"views"`
+		Author author `json:"-" pb:"relation=author"`
+	}
+	posts := CollectionSet[post](client.Clone(WithStrictDecoding()), "posts")
+	list, err := posts.List(ParamsList{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, post{ID: "p1", Title: "hello", Views: json.Number("12345678901234567"), Author: author{ID: "u1", Name: "ann"}},
+		list.Items[0], "the records are decoded by the codec, kept by the later options")
+	assert.NotZero(t, codec.unmarshals.Load())
+
+	created, err := posts.Create(post{ID: "p2", Title: "new"})
+	require.NoError(t, err)
+	assert.Equal(t, "new", created.Record.Title)
+	assert.NotZero(t, codec.marshals.Load(), "the bodies are encoded by the codec")
+}
+
+func TestClient_JSONCodec_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	codec := &countingCodec{}
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithJSONCodec(codec.marshal, codec.unmarshal))
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+
+	created, err := posts.Create(post{Field: "codec"})
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+	one, err := posts.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post{ID: created.ID, Field: "codec"}, one)
+	assert.NotZero(t, codec.marshals.Load())
+	assert.NotZero(t, codec.unmarshals.Load())
+}
@@ -1,9 +1,11 @@
 package pocketbase
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"reflect"
 )
 
 // Collection represents a type-safe wrapper around a PocketBase collection.
@@ -24,37 +26,156 @@ func CollectionSet[T any](client *Client, collection string) *Collection[T] {
 
 // Update updates a record in the collection with the specified ID.
 func (c *Collection[T]) Update(id string, body T) error {
-	return c.Client.Update(c.Name, id, body)
+	hooks := c.collectionHooks()
+	if err := hooks.runBeforeUpdate(id, &body); err != nil {
+		return err
+	}
+	if err := c.Client.Update(c.Name, id, body); err != nil {
+		return err
+	}
+	hooks.runAfterUpdate(id)
+	return nil
 }
 
 // Create creates a new record in the collection.
-func (c *Collection[T]) Create(body T) (ResponseCreate, error) {
-	return c.Client.Create(c.Name, body)
+func (c *Collection[T]) Create(body T) (ResponseCreate[T], error) {
+	var response ResponseCreate[T]
+	hooks := c.collectionHooks()
+	if err := hooks.runBeforeCreate(&body); err != nil {
+		return response, err
+	}
+	var raw json.RawMessage
+	if err := c.Client.create(c.Name, body, &raw); err != nil {
+		return response, err
+	}
+	if err := response.decode(raw, c.codec); err != nil {
+		return response, fmt.Errorf("[create] can't unmarshal response, err %w", err)
+	}
+	hooks.runAfterCreate(response.Record)
+	return response, nil
 }
 
 // Delete removes a record from the collection by ID.
 func (c *Collection[T]) Delete(id string) error {
-	return c.Client.Delete(c.Name, id)
+	if err := c.Client.Delete(c.Name, id); err != nil {
+		return err
+	}
+	c.collectionHooks().runAfterDelete(id)
+	return nil
 }
 
 // List retrieves a paginated list of records from the collection.
+//
+// The fields of T tagged with a relation, e.g.
+//
+//	Author User `pb:"relation=author,collection=users"`
+//
+// are preloaded: the relation is added to params.Expand and the expanded record
+// is decoded into the field, for all reads of the collection. Multiple relations
+// decode into slices, e.g. Tags []Tag `pb:"relation=tags"`.
 func (c *Collection[T]) List(params ParamsList) (ResponseList[T], error) {
+	return c.list(context.Background(), params)
+}
+
+// list retrieves a page of records, decoded with the relation fields and field mapping of T.
+func (c *Collection[T]) list(ctx context.Context, params ParamsList) (ResponseList[T], error) {
 	var response ResponseList[T]
-	params.hackResponseRef = &response
+	t := reflect.TypeFor[T]()
+	if !c.codec.isRecordType(relationType(t)) {
+		if err := c.Client.list(ctx, c.Name, params, &response); err != nil {
+			return response, err
+		}
+		return response, c.collectionHooks().runAfterList(response.Items)
+	}
 
-	_, err := c.Client.List(c.Name, params)
-	return response, err
+	var raw ResponseList[json.RawMessage]
+	if err := c.Client.list(ctx, c.Name, withRelations(params, relationType(t)), &raw); err != nil {
+		return response, err
+	}
+	response.Page, response.PerPage = raw.Page, raw.PerPage
+	response.TotalItems, response.TotalPages = raw.TotalItems, raw.TotalPages
+	response.Items = make([]T, len(raw.Items))
+	for i, item := range raw.Items {
+		if err := c.codec.decode(item, &response.Items[i]); err != nil {
+			return response, fmt.Errorf("[list] can't unmarshal record, err %w", err)
+		}
+	}
+	return response, c.collectionHooks().runAfterList(response.Items)
 }
 
-// FullList retrieves all records from the collection without pagination.
+// FullList retrieves all records from the collection without pagination, in pages of 500
+// records, or of the adaptive size of WithAdaptivePaging, and with the consistency mode of
+// WithSnapshot.
 func (c *Collection[T]) FullList(params ParamsList) (ResponseList[T], error) {
-	var response ResponseList[T]
-	params.hackResponseRef = &response
+	ctx, cancel := c.callContext(c.sizedContext(context.Background()))
+	defer cancel()
+	switch c.snapshot {
+	case SnapshotKeyset:
+		params = withRelations(params, relationType(reflect.TypeFor[T]()))
+		response, err := keysetList(ctx, c.Client, c.Name, params, func(raw json.RawMessage, item *T) error {
+			return c.codec.decode(raw, item)
+		})
+		if err != nil {
+			return response, err
+		}
+		return response, c.collectionHooks().runAfterList(response.Items)
+	case SnapshotCreated:
+		pinned, err := c.pinCreated(ctx, c.Name, params)
+		if err != nil {
+			return ResponseList[T]{}, err
+		}
+		params = pinned
+	}
+	if c.paging != nil {
+		return adaptiveList(ctx, c.Client, params, c.list)
+	}
+	params.Page = 1
+	params.Size = 500
 
-	_, err := c.Client.FullList(c.Name, params)
+	response, _, err := c.FullListPartial(ctx, params)
 	return response, err
 }
 
+// FullListPartial retrieves all records from the collection like FullList, starting at
+// params.Page, but stops when ctx is done or a page fails and returns the records
+// fetched so far with the continuation to resume from, e.g. to checkpoint batch jobs:
+//
+//	records, next, err := collection.FullListPartial(ctx, params)
+//	// store records, then later resume with
+//	records, next, err = collection.FullListPartial(ctx, next.Params)
+//
+// The continuation is nil when all records were fetched.
+func (c *Collection[T]) FullListPartial(ctx context.Context, params ParamsList) (ResponseList[T], *Continuation, error) {
+	var response ResponseList[T]
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Size < 1 {
+		params.Size = 500
+	}
+	ctx, cancel := c.callContext(c.sizedContext(ctx))
+	defer cancel()
+
+	for {
+		r, err := c.list(ctx, params)
+		if err != nil {
+			return response, &Continuation{Params: params}, err
+		}
+		if response.Items == nil {
+			response.Page = r.Page
+			response.PerPage = r.PerPage
+			response.TotalItems = r.TotalItems
+			response.TotalPages = r.TotalPages
+		}
+		response.Items = append(response.Items, r.Items...)
+
+		if params.Page >= r.TotalPages {
+			return response, nil, nil
+		}
+		params.Page++
+	}
+}
+
 // One retrieves a single record from the collection by ID.
 func (c *Collection[T]) One(id string) (T, error) {
 	var response T
@@ -67,6 +188,9 @@ func (c *Collection[T]) One(id string) (T, error) {
 		SetHeader("Content-Type", "application/json").
 		SetPathParam("collection", c.Name).
 		SetPathParam("id", id)
+	if expand := withRelations(ParamsList{}, relationType(reflect.TypeFor[T]())).Expand; expand != "" {
+		request.SetQueryParam("expand", expand)
+	}
 
 	resp, err := request.Get(c.url + "/api/collections/{collection}/records/{id}")
 	if err != nil {
@@ -74,14 +198,14 @@ func (c *Collection[T]) One(id string) (T, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[one] pocketbase returned status: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[one] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.decode(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[one] can't unmarshal response, err %w", err)
 	}
 	return response, nil
@@ -96,6 +220,7 @@ func (c *Collection[T]) OneWithParams(id string, params ParamsList) (T, error) {
 		return response, err
 	}
 
+	params = withRelations(params, relationType(reflect.TypeFor[T]()))
 	request := c.client.R().
 		SetHeader("Content-Type", "application/json").
 		SetPathParam("collection", c.Name).
@@ -109,14 +234,14 @@ func (c *Collection[T]) OneWithParams(id string, params ParamsList) (T, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[one] pocketbase returned status: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[one] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.decode(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[one] can't unmarshal response, err %w", err)
 	}
 	return response, nil
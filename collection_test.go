@@ -1,6 +1,10 @@
 package pocketbase
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -207,6 +211,26 @@ func TestCollection_Create(t *testing.T) {
 	}
 }
 
+func TestCollection_CreateRecord(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID      string `json:"id"`
+		Field   string `json:"field"`
+		Created string `json:"created"`
+	}
+	collection := CollectionSet[post](NewClient(defaultURL), migrations.PostsPublic)
+	field := "value_" + time.Now().Format(time.StampMilli)
+
+	r, err := collection.Create(post{Field: field})
+	require.NoError(t, err)
+	assert.NotEmpty(t, r.ID)
+	assert.NotEmpty(t, r.Created)
+	assert.Equal(t, r.Created, r.Updated)
+	assert.Equal(t, post{ID: r.ID, Field: field, Created: r.Created}, r.Record)
+}
+
 func TestCollection_One(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -242,3 +266,70 @@ func TestCollection_One(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, field+"_updated", item["field"])
 }
+
+func TestCollection_FullList_AllPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"page": %s, "perPage": 2, "totalItems": 5, "totalPages": 3, "items": [{"id": "%[1]s-a"}, {"id": "%[1]s-b"}]}`, page)
+	}))
+	t.Cleanup(srv.Close)
+	collection := CollectionSet[map[string]any](NewClient(srv.URL), "posts")
+
+	response, err := collection.FullList(ParamsList{})
+	require.NoError(t, err)
+	var ids []any
+	for _, item := range response.Items {
+		ids = append(ids, item["id"])
+	}
+	assert.Equal(t, []any{"1-a", "1-b", "2-a", "2-b", "3-a", "3-b"}, ids, "the items of all the pages are kept")
+	assert.Equal(t, 1, response.Page)
+	assert.Equal(t, 5, response.TotalItems)
+}
+
+func TestCollection_FullListPartial(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	client := NewClient(defaultURL, WithObserver(func(op Operation, _ time.Duration, _ error) {
+		if op.Name == "list" {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+		}
+	}))
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+
+	field := "partial_" + time.Now().Format(time.StampMilli)
+	for range 4 {
+		_, err := collection.Create(map[string]any{"field": field})
+		require.NoError(t, err)
+	}
+	params := ParamsList{Size: 1, Filters: "field='" + field + "'", Sort: "id"}
+
+	first, next, err := collection.FullListPartial(ctx, params)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, next)
+	assert.Len(t, first.Items, 2)
+	assert.Equal(t, 4, first.TotalItems)
+	assert.Equal(t, 3, next.Params.Page)
+
+	resumed, err := ParseContinuation(next.Token())
+	require.NoError(t, err)
+	assert.Equal(t, *next, resumed)
+
+	rest, next, err := collection.FullListPartial(context.Background(), resumed.Params)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+	require.Len(t, rest.Items, 2)
+
+	ids := map[any]bool{}
+	for _, item := range append(first.Items, rest.Items...) {
+		ids[item["id"]] = true
+	}
+	assert.Len(t, ids, 4)
+}
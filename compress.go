@@ -0,0 +1,74 @@
+package pocketbase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// defaultCompressionThreshold is the size of the smallest compressed bodies, when
+// WithRequestCompression is given no threshold.
+const defaultCompressionThreshold = 1024
+
+// WithRequestCompression gzips the JSON bodies of the creates, updates and batches at least
+// threshold bytes long, sent with a gzip Content-Encoding, e.g. to speed up the imports of
+// records with large json fields over slow links:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithRequestCompression(64<<10))
+//
+// A zero threshold defaults to 1KB. The server must decode the gzip request bodies, which
+// the server of this module does and stock PocketBase doesn't.
+func WithRequestCompression(threshold int) ClientOption {
+	return func(c *Client) {
+		if threshold <= 0 {
+			threshold = defaultCompressionThreshold
+		}
+		c.compressThreshold = threshold
+	}
+}
+
+// setJSONBody sets the JSON body of the request, gzipped when the client compresses the
+// bodies of its size.
+func (c *Client) setJSONBody(request *resty.Request, body any) error {
+	request.SetHeader("Content-Type", "application/json")
+	if c.compressThreshold <= 0 {
+		request.SetBody(body)
+		return nil
+	}
+
+	raw, err := c.codec.marshalJSON(body)
+	if err != nil {
+		return err
+	}
+	if len(raw) < c.compressThreshold {
+		request.SetBody(body)
+		return nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	request.SetHeader("Content-Encoding", "gzip").SetBody(buf.Bytes())
+	return nil
+}
+
+// decodeRequestBody returns a request body, decoded when it is gzipped.
+func decodeRequestBody(body []byte, encoding string) ([]byte, error) {
+	if !strings.EqualFold(encoding, "gzip") {
+		return body, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("[compress] can't decode body, err %w", err)
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,122 @@
+package pocketbase
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestCompression(t *testing.T) {
+	type request struct {
+		encoding string
+		body     map[string]any
+	}
+	var (
+		mu       sync.Mutex
+		requests []request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = zr
+		}
+		var decoded map[string]any
+		require.NoError(t, json.NewDecoder(body).Decode(&decoded))
+		mu.Lock()
+		requests = append(requests, request{encoding: r.Header.Get("Content-Encoding"), body: decoded})
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/batch" {
+			_, _ = fmt.Fprint(w, `[{"status": 200, "body": {"id": "p1"}}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id": "p1"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	large := strings.Repeat("a", 2048)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithRequestCompression(0))
+	_, err := client.Create("posts", map[string]any{"field": "small"})
+	require.NoError(t, err)
+	_, err = client.Create("posts", map[string]any{"field": large})
+	require.NoError(t, err)
+	require.NoError(t, client.Update("posts", "p1", map[string]any{"field": large}))
+	_, err = client.Batch().Create("posts", map[string]any{"field": large}).Send(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, requests, 4)
+	assert.Equal(t, request{body: map[string]any{"field": "small"}}, requests[0])
+	assert.Equal(t, request{encoding: "gzip", body: map[string]any{"field": large}}, requests[1])
+	assert.Equal(t, request{encoding: "gzip", body: map[string]any{"field": large}}, requests[2])
+	assert.Equal(t, "gzip", requests[3].encoding)
+
+	// without the option, the bodies are sent as they are
+	requests = nil
+	_, err = NewClient(srv.URL, WithRetry(0, 0, 0)).Create("posts", map[string]any{"field": large})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Empty(t, requests[0].encoding)
+
+	// the threshold is configurable
+	requests = nil
+	_, err = NewClient(srv.URL, WithRetry(0, 0, 0), WithRequestCompression(8<<10)).Create("posts", map[string]any{"field": large})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Empty(t, requests[0].encoding)
+}
+
+func TestWithRequestCompression_HAR(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id": "p1"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := NewHARRecorder()
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithRequestCompression(1), WithHARRecorder(recorder))
+	_, err := client.Create("posts", map[string]any{"field": "test", "password": "hunter22"})
+	require.NoError(t, err)
+
+	entry := recorder.entries[0]
+	require.NotNil(t, entry.Request.PostData)
+	assert.JSONEq(t, `{"field": "test", "password": "REDACTED"}`, entry.Request.PostData.Text)
+}
+
+func TestWithRequestCompression_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithRequestCompression(1))
+	marker := fmt.Sprintf("gzip-%d-%s", time.Now().UnixNano(), strings.Repeat("a", 2048))
+
+	created, err := client.Create(migrations.PostsPublic, map[string]any{"field": marker})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Delete(migrations.PostsPublic, created.ID) })
+	require.NoError(t, client.Update(migrations.PostsPublic, created.ID, map[string]any{"field": marker + "-updated"}))
+
+	record, err := client.One(migrations.PostsPublic, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, marker+"-updated", record["field"])
+
+	results, err := client.Batch().Update(migrations.PostsPublic, created.ID, map[string]any{"field": marker}).Send(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, http.StatusOK, results[0].Status)
+}
@@ -0,0 +1,67 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrConflict is returned by UpdateIfUnchanged when the record was changed since it was read.
+var ErrConflict = errors.New("record changed concurrently")
+
+// UpdateIfUnchanged updates the record like Update when its updated field still equals
+// updated, the value of the record as last read, and returns ErrConflict otherwise,
+// so concurrent writers don't silently overwrite each other's changes:
+//
+//	err := client.UpdateIfUnchanged("posts", post.ID, post.Updated, body)
+//	if errors.Is(err, pocketbase.ErrConflict) {
+//		// reload the record and merge or report the conflict
+//	}
+//
+// The record is fetched again to compare the updated fields before the update, so a write
+// landing between both requests isn't detected. The collection needs an updated autodate field.
+func (c *Client) UpdateIfUnchanged(collection, id, updated string, body any) error {
+	if err := c.checkUnchanged(collection, id, updated); err != nil {
+		return err
+	}
+	return c.Update(collection, id, body)
+}
+
+// UpdateIfUnchanged updates the record like Update when its updated field still equals
+// updated, and returns ErrConflict otherwise, see Client.UpdateIfUnchanged.
+func (c *Collection[T]) UpdateIfUnchanged(id, updated string, body T) error {
+	hooks := c.collectionHooks()
+	if err := hooks.runBeforeUpdate(id, &body); err != nil {
+		return err
+	}
+	if err := c.Client.UpdateIfUnchanged(c.Name, id, updated, body); err != nil {
+		return err
+	}
+	hooks.runAfterUpdate(id)
+	return nil
+}
+
+// checkUnchanged returns ErrConflict when the updated field of the record differs from updated.
+func (c *Client) checkUnchanged(collection, id, updated string) error {
+	resp, err := c.Send(context.Background(), Request{
+		Path:  "/api/collections/" + url.PathEscape(collection) + "/records/" + url.PathEscape(id),
+		Query: url.Values{"fields": {"updated"}},
+	})
+	if err != nil {
+		return fmt.Errorf("[update] can't fetch record, err %w", err)
+	}
+	var current struct {
+		Updated *string `json:"updated"`
+	}
+	if err := resp.Decode(&current); err != nil {
+		return err
+	}
+	if current.Updated == nil {
+		return fmt.Errorf("[update] collection %s has no updated field, err %w", collection, ErrInvalidResponse)
+	}
+	if *current.Updated != updated {
+		return fmt.Errorf("[update] record %s was updated at %s, not %s, err %w", id, *current.Updated, updated, ErrConflict)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package pocketbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_UpdateIfUnchanged(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID      string `json:"id,omitempty"`
+		Field   string `json:"field"`
+		Updated string `json:"updated,omitempty"`
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	collection := CollectionSet[post](client, migrations.PostsPublic)
+	created, err := collection.Create(post{Field: "first"})
+	require.NoError(t, err)
+	defer func() {
+		_ = collection.Delete(created.ID)
+	}()
+	require.NotEmpty(t, created.Updated)
+	time.Sleep(5 * time.Millisecond) // the updated field has a millisecond precision
+
+	require.NoError(t, collection.UpdateIfUnchanged(created.ID, created.Updated, post{Field: "second"}))
+
+	err = collection.UpdateIfUnchanged(created.ID, created.Updated, post{Field: "third"})
+	assert.ErrorIs(t, err, ErrConflict)
+	one, err := collection.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "second", one.Field)
+}
@@ -0,0 +1,268 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidMapping is returned when a CSV mapping doesn't match the columns of the file.
+var ErrInvalidMapping = errors.New("invalid mapping")
+
+// csvTimeLayouts are the layouts of the date cells, tried in order.
+var csvTimeLayouts = []string{
+	time.RFC3339Nano,
+	dateTimeLayout,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+type (
+	// CSVMapping maps the columns of a CSV file to the fields of the records created by
+	// ImportCSV. It is declarative, e.g. loaded from a JSON file:
+	//
+	//	{
+	//		"comma": ";",
+	//		"fields": [
+	//			{"column": "Title", "field": "title"},
+	//			{"column": "Price", "field": "price", "type": "number", "default": 0},
+	//			{"column": "Tags", "field": "tags", "separator": "|"},
+	//			{"column": "Author email", "field": "author", "relation": {"collection": "users", "key": "email"}}
+	//		]
+	//	}
+	CSVMapping struct {
+		// Comma is the separator of the columns, "," when empty.
+		Comma string `json:"comma,omitempty"`
+		// Fields are the mapped columns, the other columns are ignored.
+		Fields []CSVField `json:"fields"`
+	}
+
+	// CSVField maps a column of a CSV file to a field of a record.
+	CSVField struct {
+		// Column is the name of the column in the header of the file.
+		Column string `json:"column"`
+		// Field is the name of the field of the record.
+		Field string `json:"field"`
+		// Type coerces the cells: "text" when empty, "number", "bool", "date" or "json".
+		Type string `json:"type,omitempty"`
+		// Separator splits the cells into lists, e.g. for select and relation fields with
+		// multiple values, with every value coerced to Type.
+		Separator string `json:"separator,omitempty"`
+		// Default is the value of the empty cells, which are left out of the records when nil.
+		// The column can be missing from the file when it has a default.
+		Default any `json:"default,omitempty"`
+		// Relation replaces the cells with the ids of the related records found by a unique key.
+		Relation *CSVRelation `json:"relation,omitempty"`
+	}
+
+	// CSVRelation looks up the related records of a relation field by a unique field.
+	CSVRelation struct {
+		// Collection is the collection of the related records.
+		Collection string `json:"collection"`
+		// Key is the unique field of the related records holding the cells, e.g. email.
+		Key string `json:"key"`
+	}
+
+	// csvMapper maps the rows of a file with its mapping.
+	csvMapper struct {
+		mapping CSVMapping
+		// columns are the indexes of the mapped columns, -1 for the missing ones.
+		columns []int
+		// relations caches the ids of the related records by collection, key and value.
+		relations sync.Map
+	}
+)
+
+// ImportCSV creates a record in the collection from every row of the CSV file, whose first row
+// is the header, with the fields mapped from its columns. The rows are imported like the
+// records of Import, with the same rate limiting, checkpoint and options; the rows which
+// can't be mapped, e.g. with an invalid number or an unknown related record, fail like
+// the invalid records, with the indexes of the rows after the header.
+func ImportCSV(ctx context.Context, c *Client, collection string, r io.Reader, mapping CSVMapping, opts ImportOptions) (ImportResult, error) {
+	reader := csv.NewReader(r)
+	if mapping.Comma != "" {
+		comma, size := utf8.DecodeRuneInString(mapping.Comma)
+		if size != len(mapping.Comma) {
+			return ImportResult{}, fmt.Errorf("[csv] invalid comma %q, err %w", mapping.Comma, ErrInvalidMapping)
+		}
+		reader.Comma = comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("[csv] can't read header, err %w", err)
+	}
+	mapper, err := newCSVMapper(mapping, header)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	var readErr error
+	rows := func(yield func([]string) bool) {
+		for {
+			row, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				readErr = fmt.Errorf("[csv] can't read row, err %w", err)
+				return
+			}
+			if !yield(row) {
+				return
+			}
+		}
+	}
+
+	result, err := importSource(ctx, c, rows, opts, func(client *Client, row []string) error {
+		record, err := mapper.record(client, row)
+		if err != nil {
+			return err
+		}
+		_, err = client.Create(collection, record)
+		return err
+	})
+	if err == nil {
+		err = readErr
+	}
+	return result, err
+}
+
+// newCSVMapper checks the mapping against the header of the file.
+func newCSVMapper(mapping CSVMapping, header []string) (*csvMapper, error) {
+	m := &csvMapper{mapping: mapping, columns: make([]int, len(mapping.Fields))}
+	for i, field := range mapping.Fields {
+		if field.Field == "" {
+			return nil, fmt.Errorf("[csv] missing field of column %q, err %w", field.Column, ErrInvalidMapping)
+		}
+		switch field.Type {
+		case "", "text", "number", "bool", "date", "json":
+		default:
+			return nil, fmt.Errorf("[csv] unknown type %q of field %s, err %w", field.Type, field.Field, ErrInvalidMapping)
+		}
+		if field.Relation != nil && (field.Relation.Collection == "" || field.Relation.Key == "") {
+			return nil, fmt.Errorf("[csv] missing relation collection or key of field %s, err %w", field.Field, ErrInvalidMapping)
+		}
+
+		m.columns[i] = -1
+		for j, column := range header {
+			if strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")) == field.Column {
+				m.columns[i] = j
+				break
+			}
+		}
+		if m.columns[i] < 0 && field.Default == nil {
+			return nil, fmt.Errorf("[csv] missing column %q of field %s, err %w", field.Column, field.Field, ErrInvalidMapping)
+		}
+	}
+	return m, nil
+}
+
+// record maps a row to the fields of a record, looking up the related records with the client.
+func (m *csvMapper) record(client *Client, row []string) (map[string]any, error) {
+	record := make(map[string]any, len(m.mapping.Fields))
+	for i, field := range m.mapping.Fields {
+		cell := ""
+		if column := m.columns[i]; column >= 0 && column < len(row) {
+			cell = row[column]
+		}
+		if cell == "" {
+			if field.Default != nil {
+				record[field.Field] = field.Default
+			}
+			continue
+		}
+
+		if field.Separator == "" {
+			value, err := m.value(client, field, cell)
+			if err != nil {
+				return nil, err
+			}
+			record[field.Field] = value
+			continue
+		}
+		var values []any
+		for _, part := range strings.Split(cell, field.Separator) {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			value, err := m.value(client, field, part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		record[field.Field] = values
+	}
+	return record, nil
+}
+
+// value coerces a cell to the type of the field, or to the id of the related record.
+func (m *csvMapper) value(client *Client, field CSVField, cell string) (any, error) {
+	if field.Relation != nil {
+		return m.relation(client, field, cell)
+	}
+
+	switch field.Type {
+	case "number":
+		n, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		if err != nil {
+			return nil, fmt.Errorf("[csv] invalid number %q of column %q, err %w", cell, field.Column, err)
+		}
+		return n, nil
+	case "bool":
+		b, err := strconv.ParseBool(strings.TrimSpace(cell))
+		if err != nil {
+			return nil, fmt.Errorf("[csv] invalid bool %q of column %q, err %w", cell, field.Column, err)
+		}
+		return b, nil
+	case "date":
+		for _, layout := range csvTimeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(cell)); err == nil {
+				return t.UTC().Format(dateTimeLayout), nil
+			}
+		}
+		return nil, fmt.Errorf("[csv] invalid date %q of column %q", cell, field.Column)
+	case "json":
+		var v any
+		if err := json.Unmarshal([]byte(cell), &v); err != nil {
+			return nil, fmt.Errorf("[csv] invalid json of column %q, err %w", field.Column, err)
+		}
+		return v, nil
+	}
+	return cell, nil
+}
+
+// relation returns the id of the related record whose key is the cell.
+func (m *csvMapper) relation(client *Client, field CSVField, cell string) (string, error) {
+	rel := field.Relation
+	cacheKey := rel.Collection + "\x00" + rel.Key + "\x00" + cell
+	if id, ok := m.relations.Load(cacheKey); ok {
+		return id.(string), nil
+	}
+
+	records, err := client.List(rel.Collection, ParamsList{
+		Size:    1,
+		Fields:  "id",
+		Filters: Filter(rel.Key+" = {:value}", map[string]any{"value": cell}),
+	})
+	if err != nil {
+		return "", fmt.Errorf("[csv] can't look up %s of column %q, err %w", rel.Collection, field.Column, err)
+	}
+	if len(records.Items) == 0 {
+		return "", fmt.Errorf("[csv] no %s record with %s %q for column %q", rel.Collection, rel.Key, cell, field.Column)
+	}
+	id, _ := records.Items[0]["id"].(string)
+	m.relations.Store(cacheKey, id)
+	return id, nil
+}
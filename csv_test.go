@@ -0,0 +1,115 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV(t *testing.T) {
+	var lookups atomic.Int32
+	var created []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/collections/users/records":
+			lookups.Add(1)
+			items := ""
+			if r.URL.Query().Get("filter") == "email = 'ann@example.com'" {
+				items = `{"id": "u1"}`
+			}
+			_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 1, "items": [%s]}`, items)
+		case r.URL.Path == "/api/collections/posts/records" && r.Method == http.MethodPost:
+			var record map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&record)
+			created = append(created, record)
+			_, _ = fmt.Fprint(w, `{"id": "p1"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	mapping := CSVMapping{Comma: ";"}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"comma": ";",
+		"fields": [
+			{"column": "Title", "field": "title"},
+			{"column": "Price", "field": "price", "type": "number", "default": 0},
+			{"column": "Published", "field": "published", "type": "bool"},
+			{"column": "Date", "field": "date", "type": "date"},
+			{"column": "Tags", "field": "tags", "separator": "|"},
+			{"column": "Author", "field": "author", "relation": {"collection": "users", "key": "email"}},
+			{"column": "Status", "field": "status", "default": "draft"}
+		]
+	}`), &mapping))
+
+	file := "\ufeffTitle;Price;Published;Date;Tags;Author;Ignored\n" +
+		"First;9.5;true;2024-03-01;a | b;ann@example.com;x\n" +
+		"Second;;false;2024-03-01T10:00:00+02:00;;ann@example.com;y\n" +
+		"Third;cheap;true;;;;z\n" +
+		"Fourth;1;true;;;bob@example.com;z\n"
+
+	var failed []string
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	result, err := ImportCSV(context.Background(), client, "posts", strings.NewReader(file), mapping, ImportOptions{
+		OnError: func(index int, err error) error {
+			failed = append(failed, fmt.Sprintf("%d: %v", index, err))
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: 2, Skipped: 2}, result)
+	assert.Equal(t, []string{
+		`2: [csv] invalid number "cheap" of column "Price", err strconv.ParseFloat: parsing "cheap": invalid syntax`,
+		`3: [csv] no users record with email "bob@example.com" for column "Author"`,
+	}, failed)
+	assert.EqualValues(t, 2, lookups.Load(), "the related ids are cached")
+
+	require.Len(t, created, 2)
+	assert.Equal(t, map[string]any{
+		"title":     "First",
+		"price":     9.5,
+		"published": true,
+		"date":      "2024-03-01 00:00:00.000Z",
+		"tags":      []any{"a", "b"},
+		"author":    "u1",
+		"status":    "draft",
+	}, created[0])
+	assert.Equal(t, map[string]any{
+		"title":     "Second",
+		"price":     0.0,
+		"published": false,
+		"date":      "2024-03-01 08:00:00.000Z",
+		"author":    "u1",
+		"status":    "draft",
+	}, created[1])
+}
+
+func TestImportCSV_InvalidMapping(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1")
+	tests := []struct {
+		name    string
+		mapping CSVMapping
+	}{
+		{name: "missing column", mapping: CSVMapping{Fields: []CSVField{{Column: "Missing", Field: "missing"}}}},
+		{name: "missing field", mapping: CSVMapping{Fields: []CSVField{{Column: "Title"}}}},
+		{name: "unknown type", mapping: CSVMapping{Fields: []CSVField{{Column: "Title", Field: "title", Type: "money"}}}},
+		{name: "incomplete relation", mapping: CSVMapping{Fields: []CSVField{{Column: "Title", Field: "title", Relation: &CSVRelation{}}}}},
+		{name: "invalid comma", mapping: CSVMapping{Comma: ";;"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportCSV(context.Background(), client, "posts", strings.NewReader("Title\nFirst\n"), tt.mapping, ImportOptions{})
+			assert.ErrorIs(t, err, ErrInvalidMapping)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package pocketbase
+
+import (
+	"fmt"
+)
+
+type (
+	// Custom provides methods for the custom routes of the cmd/pocketbase server, under /api/custom.
+	Custom struct {
+		*Client
+	}
+
+	// ResponseStats represents the response of the stats route.
+	ResponseStats struct {
+		// Records is the number of records of every non-system collection.
+		Records map[string]int `json:"records"`
+	}
+)
+
+// Stats returns the record counts of the collections. It requires a superuser authorization.
+func (c Custom) Stats() (ResponseStats, error) {
+	var response ResponseStats
+	if err := c.Authorize(); err != nil {
+		return response, err
+	}
+
+	request := c.client.R().
+		SetHeader("Content-Type", "application/json")
+
+	resp, err := request.Get(c.url + "/api/custom/stats")
+	if err != nil {
+		return response, fmt.Errorf("[custom] can't send stats request to pocketbase, err %w", err)
+	}
+
+	if resp.IsError() {
+		return response, newAPIError(resp, fmt.Errorf("[custom] pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
+		return response, fmt.Errorf("[custom] can't unmarshal response, err %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,35 @@
+package pocketbase
+
+import (
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustom_Stats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	t.Run("without authorization", func(t *testing.T) {
+		defaultClient := NewClient(defaultURL)
+		_, err := defaultClient.Custom().Stats()
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("with superuser authorization", func(t *testing.T) {
+		defaultClient := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+		created, err := defaultClient.Create(migrations.PostsPublic, map[string]any{"field": "stats"})
+		require.NoError(t, err)
+		defer func() {
+			_ = defaultClient.Delete(migrations.PostsPublic, created.ID)
+		}()
+
+		stats, err := defaultClient.Custom().Stats()
+		require.NoError(t, err)
+		assert.Positive(t, stats.Records[migrations.PostsPublic])
+		assert.Contains(t, stats.Records, migrations.PostsUser)
+		assert.NotContains(t, stats.Records, "_superusers")
+	})
+}
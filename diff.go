@@ -0,0 +1,187 @@
+package pocketbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DiffUpdate updates the record with the fields changed between its before and after states
+// only, compared like Diff, e.g. the record as fetched and as edited, so concurrent updates
+// of the other fields aren't overwritten and the body stays small. Fields emptied with
+// omitempty are cleared.
+// Nothing is sent when no field changed.
+//
+//	post, err := collection.One(id)
+//	edited := post
+//	edited.Title = "New title"
+//	err = collection.DiffUpdate(id, post, edited) // sends {"title": "New title"}
+func (c *Collection[T]) DiffUpdate(id string, before, after T) error {
+	hooks := c.collectionHooks()
+	if err := hooks.runBeforeUpdate(id, &after); err != nil {
+		return err
+	}
+	if err := c.validate(c.Name, after, false); err != nil {
+		return err
+	}
+	patch, err := c.diff(before, after)
+	if err != nil {
+		return fmt.Errorf("[update] can't diff record, err %w", err)
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	if err := c.Client.update(c.Name, id, patch); err != nil {
+		return err
+	}
+	hooks.runAfterUpdate(id)
+	return nil
+}
+
+// Diff returns the fields of b differing from a, keyed by the PocketBase field names, e.g.
+// for audit logs or to resolve the conflicts of a sync:
+//
+//	changes, err := pocketbase.Diff(stored, edited) // {"title": "New title", "tags": ["go"]}
+//
+// The values are the JSON values of b, nil for the fields missing from b. The comparison
+// follows PocketBase: the zero values, e.g. "" and 0, equal null and the missing fields,
+// which PocketBase stores as zero values; the dates equal their instant in any format,
+// e.g. "2024-03-01 10:00:00.000Z" and a time.Time; and the lists of relation ids or file
+// names equal a single id or name when they hold only it.
+func Diff[T any](a, b T) (map[string]any, error) {
+	patch, err := diffFields(defaultCodec, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("[diff] can't diff records, err %w", err)
+	}
+	changes := make(map[string]any, len(patch))
+	for key, raw := range patch {
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("[diff] can't unmarshal field %s, err %w", key, err)
+		}
+		changes[key] = value
+	}
+	return changes, nil
+}
+
+// diff returns the fields of the after state differing from the before state,
+// null for the fields missing from it.
+func (c *Collection[T]) diff(before, after T) (map[string]json.RawMessage, error) {
+	return diffFields(c.codec, before, after)
+}
+
+// diffFields returns the encoded fields of the after record differing from the before
+// record, null for the fields missing from it.
+func diffFields(codec *recordCodec, before, after any) (map[string]json.RawMessage, error) {
+	from, err := encodedFields(codec, before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := encodedFields(codec, after)
+	if err != nil {
+		return nil, err
+	}
+
+	null := json.RawMessage("null")
+	patch := map[string]json.RawMessage{}
+	for key, value := range to {
+		if previous, ok := from[key]; !ok && !sameField(null, value) || ok && !sameField(previous, value) {
+			patch[key] = value
+		}
+	}
+	for key, value := range from {
+		if _, ok := to[key]; !ok && !sameField(value, null) {
+			patch[key] = null
+		}
+	}
+	return patch, nil
+}
+
+// encodedFields returns the encoded fields of the record.
+func encodedFields(codec *recordCodec, record any) (map[string]json.RawMessage, error) {
+	body, err := codec.encode(record)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := codec.marshalJSON(body)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := codec.unmarshalJSON(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// sameField reports whether two encoded values of a field are the same for PocketBase.
+func sameField(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var x, y any
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	x, y = normalizeField(x), normalizeField(y)
+	if xs, ok := x.(string); ok {
+		if ys, ok := y.(string); ok {
+			tx, okx := parseDateTime(xs)
+			ty, oky := parseDateTime(ys)
+			if okx && oky {
+				return tx.Equal(ty)
+			}
+		}
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// normalizeField returns nil for the zero values and the zero dates, and the single value of
+// the lists of one value.
+func normalizeField(value any) any {
+	switch v := value.(type) {
+	case string:
+		if t, ok := parseDateTime(v); ok && t.IsZero() {
+			return nil
+		}
+		if v == "" {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case []any:
+		switch len(v) {
+		case 0:
+			return nil
+		case 1:
+			if s, ok := v[0].(string); ok {
+				return normalizeField(s)
+			}
+		}
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+// parseDateTime parses the dates formatted by PocketBase, e.g. "2024-03-01 10:00:00.000Z",
+// or RFC 3339, e.g. by time.Time.
+func parseDateTime(s string) (time.Time, bool) {
+	// the shortest date is "2006-01-02 15:04:05Z"
+	if len(s) < 20 || s[4] != '-' || s[7] != '-' {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, strings.Replace(s, " ", "T", 1))
+	return t, err == nil
+}
@@ -0,0 +1,98 @@
+package pocketbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_Diff(t *testing.T) {
+	type post struct {
+		ID    string   `json:"id"`
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+		Note  string   `json:"note,omitempty"`
+	}
+	collection := CollectionSet[post](NewClient(defaultURL), migrations.PostsPublic)
+
+	patch, err := collection.diff(
+		post{ID: "p1", Title: "a", Tags: []string{"x"}, Note: "n"},
+		post{ID: "p1", Title: "b", Tags: []string{"x", "y"}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"title": `"b"`, "tags": `["x","y"]`, "note": "null"}, rawStrings(patch))
+
+	patch, err = collection.diff(post{Title: "a"}, post{Title: "a"})
+	require.NoError(t, err)
+	assert.Empty(t, patch)
+}
+
+func TestDiff(t *testing.T) {
+	type post struct {
+		ID        string    `json:"id"`
+		Title     string    `json:"title" pb:"field"`
+		Views     int       `json:"views,omitempty"`
+		Published time.Time `json:"published"`
+		Updated   string    `json:"updated"`
+		Author    any       `json:"author"`
+		Files     []string  `json:"files"`
+	}
+	published := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	a := post{ID: "p1", Title: "a", Views: 3, Updated: "2024-03-01 10:00:00.000Z", Author: []any{"u1"}, Files: []string{"a.png"}}
+	b := post{ID: "p1", Title: "b", Published: published, Updated: "2024-03-01T10:00:00Z", Author: "u1", Files: []string{"a.png", "b.png"}}
+
+	changes, err := Diff(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"field":     "b",
+		"views":     nil,
+		"published": "2024-03-01T10:00:00Z",
+		"files":     []any{"a.png", "b.png"},
+	}, changes)
+
+	// the zero values equal the missing fields and null
+	changes, err = Diff(map[string]any{"title": "", "views": 0, "tags": []string{}, "draft": false}, map[string]any{"title": nil})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	changes, err = Diff(post{}, post{Updated: "", Author: []string{}, Published: time.Time{}})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func rawStrings[T ~[]byte](m map[string]T) map[string]string {
+	result := map[string]string{}
+	for key, value := range m {
+		result[key] = string(value)
+	}
+	return result
+}
+
+func TestCollection_DiffUpdate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	collection := CollectionSet[post](client, migrations.PostsPublic)
+	created, err := collection.Create(post{Field: "before"})
+	require.NoError(t, err)
+	defer func() {
+		_ = collection.Delete(created.ID)
+	}()
+
+	before := created.Record
+	after := before
+	after.Field = "after"
+	require.NoError(t, collection.DiffUpdate(created.ID, before, after))
+	require.NoError(t, collection.DiffUpdate(created.ID, after, after))
+
+	one, err := collection.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "after", one.Field)
+}
@@ -0,0 +1,190 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DNSCache caches the addresses of the hosts resolved by the clients, so the high-QPS clients
+// don't resolve the PocketBase host on every new connection. The hosts not found are cached
+// as well, for a shorter time; the other lookup failures, e.g. timeouts, aren't.
+//
+// A cache can be shared by several clients, and by the transports dialing with DialContext.
+type DNSCache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	// lookup resolves a host, with the resolver of the clients or net.DefaultResolver.
+	lookup func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error)
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+	lookups singleflight.Group
+}
+
+// dnsEntry is a cached lookup.
+type dnsEntry struct {
+	addrs   []string
+	err     error
+	expires time.Time
+}
+
+// NewDNSCache creates a cache keeping the addresses of a host for ttl, and the hosts not
+// found for negativeTTL; a zero negativeTTL doesn't cache them.
+func NewDNSCache(ttl, negativeTTL time.Duration) *DNSCache {
+	return &DNSCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		lookup: func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+			return resolver.LookupHost(ctx, host)
+		},
+		now:     time.Now,
+		entries: map[string]dnsEntry{},
+	}
+}
+
+// WithResolver resolves the PocketBase host with the resolver, e.g. a resolver querying a
+// service discovery DNS server:
+//
+//	resolver := &net.Resolver{
+//		PreferGo: true,
+//		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+//			return (&net.Dialer{}).DialContext(ctx, network, "10.0.0.2:53")
+//		},
+//	}
+//	client := pocketbase.NewClient(url, pocketbase.WithResolver(resolver))
+//
+// Like WithDNSCache, it sets the dialer of a copy of the HTTP transport of the client, and
+// must follow WithTransport. Transports other than *http.Transport are left alone.
+func WithResolver(resolver *net.Resolver) ClientOption {
+	return func(c *Client) {
+		c.resolver = resolver
+		c.setDialContext()
+	}
+}
+
+// WithDNSCache resolves the PocketBase host through the cache:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithDNSCache(pocketbase.NewDNSCache(time.Minute, 5*time.Second)))
+//
+// It sets the dialer of a copy of the HTTP transport of the client, and must follow
+// WithTransport. A transport shared by clients, e.g. of a ClientPool, can dial through
+// the cache itself:
+//
+//	transport.DialContext = cache.DialContext
+func WithDNSCache(cache *DNSCache) ClientOption {
+	return func(c *Client) {
+		c.dnsCache = cache
+		c.setDialContext()
+	}
+}
+
+// setDialContext dials the connections of the client with its resolver and DNS cache, on a
+// copy of its transport to leave the shared transports alone.
+func (c *Client) setDialContext() {
+	next := c.client.GetClient().Transport
+	gate, gated := next.(*healthGate)
+	if gated {
+		next = gate.transport()
+	}
+	transport, ok := next.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport = transport.Clone()
+
+	resolver, cache := c.resolver, c.dnsCache
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Resolver: resolver}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if cache == nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		return cache.dial(ctx, dialer, resolver, network, addr)
+	}
+
+	if gated {
+		c.client.SetTransport(gate.withNext(transport))
+		return
+	}
+	c.client.SetTransport(transport)
+}
+
+// DialContext dials the address with the addresses of its host from the cache, like
+// net.Dialer.DialContext, e.g. for the DialContext of an http.Transport.
+func (d *DNSCache) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return d.dial(ctx, &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}, nil, network, addr)
+}
+
+// dial dials the addresses of the host in turn, until a connection succeeds.
+func (d *DNSCache) dial(ctx context.Context, dialer *net.Dialer, resolver *net.Resolver, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, network, addr)
+	}
+	addrs, err := d.lookupHost(ctx, resolver, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, ip := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// lookupHost returns the addresses of the host, from the cache while they are fresh.
+func (d *DNSCache) lookupHost(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+	d.mu.Lock()
+	entry, ok := d.entries[host]
+	d.mu.Unlock()
+	if ok && d.now().Before(entry.expires) {
+		return entry.addrs, entry.err
+	}
+
+	result, err, _ := d.lookups.Do(host, func() (any, error) {
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		addrs, err := d.lookup(ctx, resolver, host)
+		now := d.now()
+		entry := dnsEntry{addrs: addrs, err: err}
+		var dnsErr *net.DNSError
+		switch {
+		case err == nil && len(addrs) > 0:
+			entry.expires = now.Add(d.ttl)
+		case errors.As(err, &dnsErr) && dnsErr.IsNotFound && d.negativeTTL > 0:
+			entry.expires = now.Add(d.negativeTTL)
+		default:
+			return addrs, err
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		for key, cached := range d.entries {
+			if !now.Before(cached.expires) {
+				delete(d.entries, key)
+			}
+		}
+		d.entries[host] = entry
+		return addrs, err
+	})
+	addrs, _ := result.([]string)
+	return addrs, err
+}
@@ -0,0 +1,113 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSCache(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	var lookups atomic.Int32
+	cache := NewDNSCache(time.Minute, 5*time.Second)
+	cache.now = func() time.Time { return now }
+	cache.lookup = func(_ context.Context, _ *net.Resolver, host string) ([]string, error) {
+		lookups.Add(1)
+		switch host {
+		case "pocketbase.test":
+			return []string{"127.0.0.1"}, nil
+		case "down.test":
+			return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true}
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	ctx := context.Background()
+
+	addrs, err := cache.lookupHost(ctx, nil, "pocketbase.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1"}, addrs)
+	_, _ = cache.lookupHost(ctx, nil, "pocketbase.test")
+	assert.Equal(t, int32(1), lookups.Load())
+	now = now.Add(time.Minute)
+	_, _ = cache.lookupHost(ctx, nil, "pocketbase.test")
+	assert.Equal(t, int32(2), lookups.Load(), "the expired addresses are resolved again")
+
+	lookups.Store(0)
+	_, err = cache.lookupHost(ctx, nil, "missing.test")
+	var dnsErr *net.DNSError
+	require.ErrorAs(t, err, &dnsErr)
+	assert.True(t, dnsErr.IsNotFound)
+	_, _ = cache.lookupHost(ctx, nil, "missing.test")
+	assert.Equal(t, int32(1), lookups.Load(), "the hosts not found are cached")
+	now = now.Add(5 * time.Second)
+	_, _ = cache.lookupHost(ctx, nil, "missing.test")
+	assert.Equal(t, int32(2), lookups.Load())
+
+	lookups.Store(0)
+	_, err = cache.lookupHost(ctx, nil, "down.test")
+	require.Error(t, err)
+	_, _ = cache.lookupHost(ctx, nil, "down.test")
+	assert.Equal(t, int32(2), lookups.Load(), "the timeouts aren't cached")
+}
+
+func TestWithDNSCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 30, "totalItems": 0, "totalPages": 0, "items": []}`)
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var lookups atomic.Int32
+	cache := NewDNSCache(time.Minute, 0)
+	cache.lookup = func(_ context.Context, _ *net.Resolver, host string) ([]string, error) {
+		lookups.Add(1)
+		if host != "pocketbase.test" {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		// the unreachable address is skipped
+		return []string{"::1", "127.0.0.1"}, nil
+	}
+	if conn, err := net.Dial("tcp", net.JoinHostPort("::1", u.Port())); err == nil {
+		_ = conn.Close()
+		t.Skip("the server listens on ::1")
+	}
+
+	client := NewClient("http://pocketbase.test:"+u.Port(), WithRetry(0, 0, 0), WithHealthGate(0), WithDNSCache(cache))
+	_, err = client.List("posts", ParamsList{})
+	require.NoError(t, err)
+	_, err = client.Clone(WithTransport(&http.Transport{DialContext: cache.DialContext})).List("posts", ParamsList{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), lookups.Load())
+	_, isGate := client.client.GetClient().Transport.(*healthGate)
+	assert.True(t, isGate, "the health gate is kept")
+
+	_, err = NewClient("http://missing.test", WithRetry(0, 0, 0), WithDNSCache(cache)).List("posts", ParamsList{})
+	var dnsErr *net.DNSError
+	assert.ErrorAs(t, err, &dnsErr)
+}
+
+func TestWithResolver(t *testing.T) {
+	errDNS := errors.New("fake dns unreachable")
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(context.Context, string, string) (net.Conn, error) {
+			return nil, errDNS
+		},
+	}
+	_, err := NewClient("http://pocketbase.invalid", WithRetry(0, 0, 0), WithResolver(resolver)).List("posts", ParamsList{})
+	assert.ErrorContains(t, err, errDNS.Error())
+	_, err = NewClient("http://pocketbase.invalid", WithRetry(0, 0, 0), WithResolver(resolver), WithDNSCache(NewDNSCache(time.Minute, 0))).List("posts", ParamsList{})
+	assert.ErrorContains(t, err, errDNS.Error())
+}
@@ -0,0 +1,204 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaMismatch is wrapped by the SchemaError of a struct not matching its collection.
+var ErrSchemaMismatch = errors.New("schema mismatch")
+
+// recordMetaFields are the keys of the records which aren't fields of their collection, and
+// the write-only keys of the auth records.
+var recordMetaFields = map[string]bool{
+	"collectionId":    true,
+	"collectionName":  true,
+	"expand":          true,
+	"passwordConfirm": true,
+	"oldPassword":     true,
+}
+
+type (
+	// SchemaError is returned by ValidateSchema when the struct type of the records has
+	// drifted from the schema of the collection, e.g. to fail at the startup of a service:
+	//
+	//	if err := posts.ValidateSchema(ctx); err != nil {
+	//		log.Fatal(err)
+	//	}
+	SchemaError struct {
+		Collection string
+		// Missing are the required fields of the collection missing from the struct, which
+		// can't be created with it.
+		Missing []string
+		// Unknown are the fields of the struct unknown to the collection, always decoded as
+		// zero values.
+		Unknown []string
+		// Mismatches are the fields whose Go type can't hold the values of the field.
+		Mismatches []FieldMismatch
+	}
+
+	// FieldMismatch is a struct field whose Go type can't hold the values of its field.
+	FieldMismatch struct {
+		Field  string
+		GoType reflect.Type
+		// Schema is the field of the collection.
+		Schema SchemaField
+	}
+)
+
+func (e *SchemaError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing fields "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown fields "+strings.Join(e.Unknown, ", "))
+	}
+	for _, m := range e.Mismatches {
+		problems = append(problems, m.String())
+	}
+	return fmt.Sprintf("[schema] the records don't match the collection %s: %s, err %v",
+		e.Collection, strings.Join(problems, "; "), ErrSchemaMismatch)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrSchemaMismatch
+}
+
+func (m FieldMismatch) String() string {
+	kind := m.Schema.Type
+	if m.Schema.Multiple() {
+		kind = "multiple " + kind
+	}
+	return fmt.Sprintf("field %s: %s can't hold a %s field", m.Field, m.GoType, kind)
+}
+
+// ValidateSchema compares the fields of T, with their json and pb tags, to the live schema of
+// the collection, which requires a superuser, so the drifts are caught at the startup of a
+// service instead of as silent zero values. It returns a *SchemaError with:
+//   - the fields of T unknown to the collection,
+//   - the required fields of the collection missing from T, except the system and
+//     automatic fields,
+//   - the fields of T whose Go type can't hold the values of the field, e.g. an int for a
+//     text field, or a string for a relation field with multiple values.
+//
+// The relation fields of T, e.g. `pb:"relation=author"`, must be relation fields of the
+// collection. The time.Time fields are compatible with the date and autodate fields only
+// with WithTimeLayouts parsing DateTimeLayout. The types with their own JSON decoding,
+// interfaces and the json fields are compatible with all the fields. Nothing is checked
+// when T isn't a struct, e.g. a map.
+func (c *Collection[T]) ValidateSchema(ctx context.Context) error {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	schema, err := c.collectionSchema(ctx, c.Name)
+	if err != nil {
+		return err
+	}
+
+	serr := &SchemaError{Collection: c.Name}
+	covered := map[string]bool{}
+	fields, _ := c.codec.appendMappedFields(nil, t, nil)
+	for _, f := range fields {
+		if recordMetaFields[f.key] {
+			continue
+		}
+		field, ok := schemaField(schema, f.key)
+		if !ok {
+			serr.Unknown = append(serr.Unknown, f.key)
+			continue
+		}
+		covered[field.Name] = true
+		if goType := t.FieldByIndex(f.index).Type; !c.codec.compatibleField(goType, field) {
+			serr.Mismatches = append(serr.Mismatches, FieldMismatch{Field: f.key, GoType: goType, Schema: field})
+		}
+	}
+	for _, rel := range relationFields(t) {
+		// the back-relations, e.g. comments_via_post, are fields of the other collection
+		if strings.Contains(rel.relation, "_via_") {
+			continue
+		}
+		field, ok := schemaField(schema, rel.relation)
+		switch {
+		case !ok:
+			serr.Unknown = append(serr.Unknown, rel.relation)
+		case field.Type != "relation":
+			serr.Mismatches = append(serr.Mismatches, FieldMismatch{Field: rel.relation, GoType: t.FieldByIndex(rel.index).Type, Schema: field})
+		default:
+			covered[field.Name] = true
+		}
+	}
+	for _, field := range schema.Fields {
+		if field.Required && !covered[field.Name] && !field.System && !field.Hidden && field.Type != "autodate" {
+			serr.Missing = append(serr.Missing, field.Name)
+		}
+	}
+
+	if len(serr.Missing) == 0 && len(serr.Unknown) == 0 && len(serr.Mismatches) == 0 {
+		return nil
+	}
+	sort.Strings(serr.Missing)
+	sort.Strings(serr.Unknown)
+	return serr
+}
+
+// schemaField returns the field of the schema by name, matched like encoding/json: exactly
+// first and then case-insensitively.
+func schemaField(schema CollectionSchema, name string) (SchemaField, bool) {
+	if field, ok := schema.Field(name); ok {
+		return field, true
+	}
+	for _, field := range schema.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return SchemaField{}, false
+}
+
+// compatibleField reports whether the Go type can hold the values of the field.
+func (rc *recordCodec) compatibleField(t reflect.Type, field SchemaField) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if field.Type == "json" {
+		return true
+	}
+	if t == timeType {
+		return (field.Type == "date" || field.Type == "autodate") && rc.decodesDateTimes()
+	}
+	if t.Kind() == reflect.Interface || reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		switch field.Type {
+		case "number", "bool", "geoPoint":
+			return false
+		}
+		return !field.Multiple()
+	case reflect.Bool:
+		return field.Type == "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return field.Type == "number"
+	case reflect.Slice, reflect.Array:
+		return field.Multiple() && rc.compatibleField(t.Elem(), SchemaField{Type: field.Type})
+	case reflect.Struct, reflect.Map:
+		return field.Type == "geoPoint"
+	}
+	return false
+}
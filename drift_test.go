@@ -0,0 +1,114 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_ValidateSchema(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"name": "posts", "type": "base", "fields": [
+			{"name": "id", "type": "text", "required": true, "system": true},
+			{"name": "title", "type": "text", "required": true},
+			{"name": "slug", "type": "text", "required": true},
+			{"name": "views", "type": "number"},
+			{"name": "draft", "type": "bool"},
+			{"name": "published", "type": "date"},
+			{"name": "tags", "type": "relation", "maxSelect": 5},
+			{"name": "author", "type": "relation", "maxSelect": 1},
+			{"name": "meta", "type": "json"},
+			{"name": "place", "type": "geoPoint"},
+			{"name": "created", "type": "autodate"}
+		]}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithTimeLayouts(DateTimeLayout))
+
+	type author struct {
+		ID string `json:"id"`
+	}
+	type post struct {
+		ID             string    `json:"id"`
+		Title          string    `json:"title"`
+		Slug           string    `json:"Slug"`
+		Views          *float64  `json:"views"`
+		Draft          bool      `json:"draft"`
+		Published      time.Time `json:"published"`
+		Tags           []string  `json:"tags"`
+		AuthorID       string    `json:"author"`
+		Author         author    `json:"-" pb:"relation=author"`
+		Meta           any       `json:"meta"`
+		Place          struct{ Lon, Lat float64 }
+		Created        string `json:"created"`
+		CollectionName string `json:"collectionName"`
+	}
+	require.NoError(t, CollectionSet[post](client, "posts").ValidateSchema(context.Background()))
+	require.NoError(t, CollectionSet[map[string]any](client, "posts").ValidateSchema(context.Background()))
+
+	// without the layout of PocketBase, the time fields can't decode the date fields
+	err := CollectionSet[post](client.Clone(WithTimeLayouts("", time.DateOnly)), "posts").ValidateSchema(context.Background())
+	require.ErrorIs(t, err, ErrSchemaMismatch)
+	assert.ErrorContains(t, err, "field published: time.Time can't hold a date field")
+	err = CollectionSet[post](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts").ValidateSchema(context.Background())
+	require.ErrorIs(t, err, ErrSchemaMismatch)
+	assert.ErrorContains(t, err, "field published: time.Time can't hold a date field")
+
+	type drifted struct {
+		ID        string `json:"id"`
+		Headline  string `json:"headline"`
+		Views     string `json:"views"`
+		Published int64  `json:"published"`
+		Tags      string `json:"tags"`
+		Author    author `pb:"relation=writer"`
+		Draft     bool   `json:"draft" pb:"place"`
+	}
+	err = CollectionSet[drifted](client, "posts").ValidateSchema(context.Background())
+	require.ErrorIs(t, err, ErrSchemaMismatch)
+	var serr *SchemaError
+	require.ErrorAs(t, err, &serr)
+	assert.Equal(t, []string{"slug", "title"}, serr.Missing)
+	assert.Equal(t, []string{"headline", "writer"}, serr.Unknown)
+	var mismatches []string
+	for _, m := range serr.Mismatches {
+		mismatches = append(mismatches, m.String())
+	}
+	assert.Equal(t, []string{
+		"field views: string can't hold a number field",
+		"field published: int64 can't hold a date field",
+		"field tags: string can't hold a multiple relation field",
+		"field place: bool can't hold a geoPoint field",
+	}, mismatches)
+	assert.ErrorContains(t, err, "[schema] the records don't match the collection posts: missing fields slug, title; unknown fields headline, writer; field views: string")
+}
+
+func TestCollection_ValidateSchema_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithTimeLayouts(DateTimeLayout))
+	type post struct {
+		ID      string    `json:"id"`
+		Field   string    `json:"field"`
+		Created time.Time `json:"created"`
+	}
+	require.NoError(t, CollectionSet[post](client, migrations.PostsPublic).ValidateSchema(context.Background()))
+
+	type user struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+		Name  int    `json:"name"`
+	}
+	err := CollectionSet[user](client, "users").ValidateSchema(context.Background())
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+	assert.ErrorContains(t, err, "field name: int can't hold a text field")
+}
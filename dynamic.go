@@ -0,0 +1,226 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DynamicRecord is a record of any collection, as unmarshaled from JSON, with typed getters
+// for the code handling collections unknown at compile time, e.g. admin tools:
+//
+//	posts := pocketbase.CollectionSet[pocketbase.DynamicRecord](client, collection)
+//	post, err := posts.One(id)
+//	title, published := post.GetString("title"), post.GetTime("published")
+//
+// The getters convert the values like PocketBase, and return the zero value of their type
+// for the missing fields and the values they can't convert.
+type DynamicRecord map[string]any
+
+// Get returns the value of the field, nil when missing.
+func (r DynamicRecord) Get(key string) any {
+	return r[key]
+}
+
+// Set sets the value of the field, e.g. to create or update the record.
+func (r DynamicRecord) Set(key string, value any) {
+	r[key] = value
+}
+
+// GetString returns the value of a text field, or the numbers and booleans formatted.
+func (r DynamicRecord) GetString(key string) string {
+	return stringValue(r[key])
+}
+
+// stringValue converts a value of a field to a string, empty when it can't.
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool, int, int64, json.Number:
+		return fmt.Sprint(v)
+	case time.Time:
+		return v.UTC().Format(dateTimeLayout)
+	}
+	return ""
+}
+
+// GetBool returns the value of a bool field, true for the non-zero numbers and the strings
+// parsed as true.
+func (r DynamicRecord) GetBool(key string) bool {
+	switch v := r[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return r.GetFloat(key) != 0
+}
+
+// GetInt returns the value of a number field truncated to an int.
+func (r DynamicRecord) GetInt(key string) int {
+	f := r.GetFloat(key)
+	if math.IsNaN(f) || f > math.MaxInt || f < math.MinInt {
+		return 0
+	}
+	return int(f)
+}
+
+// GetFloat returns the value of a number field, or the strings parsed as numbers.
+func (r DynamicRecord) GetFloat(key string) float64 {
+	switch v := r[key].(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	case bool:
+		if v {
+			return 1
+		}
+	}
+	return 0
+}
+
+// GetTime returns the value of a date field, the zero time when empty.
+func (r DynamicRecord) GetTime(key string) time.Time {
+	switch v := r[key].(type) {
+	case time.Time:
+		return v
+	case string:
+		t, _ := parseDateTime(v)
+		return t
+	}
+	return time.Time{}
+}
+
+// GetStringSlice returns the values of a field with multiple values, e.g. relation ids,
+// select values or file names, and the non-empty single value as a slice.
+func (r DynamicRecord) GetStringSlice(key string) []string {
+	switch v := r[key].(type) {
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for i := range v {
+			if s := stringValue(v[i]); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	if s := r.GetString(key); s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// Expand returns the expanded record of a relation field, the first of multiple relations,
+// and nil when the relation isn't expanded.
+func (r DynamicRecord) Expand(name string) DynamicRecord {
+	records := r.ExpandAll(name)
+	if len(records) == 0 {
+		return nil
+	}
+	return records[0]
+}
+
+// ExpandAll returns the expanded records of a relation field, e.g. with multiple relations
+// or a back-relation like comments_via_post.
+func (r DynamicRecord) ExpandAll(name string) []DynamicRecord {
+	var expand map[string]any
+	switch v := r["expand"].(type) {
+	case map[string]any:
+		expand = v
+	case DynamicRecord:
+		expand = v
+	}
+	switch v := expand[name].(type) {
+	case map[string]any:
+		return []DynamicRecord{v}
+	case DynamicRecord:
+		return []DynamicRecord{v}
+	case []any:
+		records := make([]DynamicRecord, 0, len(v))
+		for _, item := range v {
+			if record, ok := item.(map[string]any); ok {
+				records = append(records, record)
+			}
+		}
+		return records
+	case []DynamicRecord:
+		return v
+	}
+	return nil
+}
+
+// GetPath returns the value at a dotted path of the record, with indices for the lists,
+// nil when missing, e.g. to reach into the expanded relations:
+//
+//	name := post.GetPath("expand.author.name")
+//	first := post.GetPath("expand.tags.0.title") // or "expand.tags[0].title"
+func (r DynamicRecord) GetPath(path string) any {
+	var value any = r
+	for _, segment := range strings.Split(path, ".") {
+		name, indices, _ := strings.Cut(segment, "[")
+		if name == "" && indices == "" {
+			return nil
+		}
+		if name != "" {
+			var ok bool
+			if value, ok = pathValue(value, name); !ok {
+				return nil
+			}
+		}
+		if indices == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indices, "]"), "][") {
+			var ok bool
+			if value, ok = pathValue(value, index); !ok {
+				return nil
+			}
+		}
+	}
+	return value
+}
+
+// pathValue returns the field of a record or the element of a list at the index.
+func pathValue(value any, key string) (any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		value, ok := v[key]
+		return value, ok
+	case DynamicRecord:
+		value, ok := v[key]
+		return value, ok
+	}
+
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return nil, false
+	}
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 || i >= list.Len() {
+		return nil, false
+	}
+	return list.Index(i).Interface(), true
+}
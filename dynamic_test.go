@@ -0,0 +1,108 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicRecord(t *testing.T) {
+	var record DynamicRecord
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "p1", "title": "hello", "views": 42.9, "draft": true,
+		"published": "2024-03-01 10:00:00.000Z", "tags": ["t1", "t2"], "author": "u1", "empty": "", "nothing": null,
+		"expand": {"author": {"id": "u1", "name": "ann"}, "tags": [{"id": "t1"}, {"id": "t2"}]}}`), &record))
+
+	assert.Equal(t, "hello", record.GetString("title"))
+	assert.Equal(t, "42.9", record.GetString("views"))
+	assert.Equal(t, "true", record.GetString("draft"))
+	assert.Empty(t, record.GetString("missing"))
+	assert.Empty(t, record.GetString("tags"))
+
+	assert.True(t, record.GetBool("draft"))
+	assert.True(t, record.GetBool("views"))
+	assert.False(t, record.GetBool("title"))
+	assert.False(t, record.GetBool("missing"))
+
+	assert.Equal(t, 42, record.GetInt("views"))
+	assert.Equal(t, 42.9, record.GetFloat("views"))
+	assert.Equal(t, 1, record.GetInt("draft"))
+	assert.Zero(t, record.GetInt("title"))
+
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), record.GetTime("published"))
+	assert.True(t, record.GetTime("empty").IsZero())
+	assert.True(t, record.GetTime("title").IsZero())
+
+	assert.Equal(t, []string{"t1", "t2"}, record.GetStringSlice("tags"))
+	assert.Equal(t, []string{"u1"}, record.GetStringSlice("author"))
+	assert.Nil(t, record.GetStringSlice("empty"))
+	assert.Nil(t, record.GetStringSlice("nothing"))
+
+	assert.Equal(t, "ann", record.Expand("author").GetString("name"))
+	assert.Equal(t, "t1", record.Expand("tags").GetString("id"))
+	assert.Len(t, record.ExpandAll("tags"), 2)
+	assert.Nil(t, record.Expand("missing"))
+	assert.Empty(t, record.Expand("missing").GetString("name"))
+
+	published := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	record.Set("published", published)
+	record.Set("views", 7)
+	record.Set("expand", DynamicRecord{"author": DynamicRecord{"id": "u2"}})
+	assert.Equal(t, published, record.GetTime("published"))
+	assert.Equal(t, "2025-01-02 03:04:05.000Z", record.GetString("published"))
+	assert.Equal(t, 7, record.GetInt("views"))
+	assert.Equal(t, "u2", record.Expand("author").Get("id"))
+}
+
+func TestDynamicRecord_GetPath(t *testing.T) {
+	var record DynamicRecord
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "p1", "meta": {"matrix": [[1, 2], [3, 4]]},
+		"expand": {"author": {"name": "ann", "expand": {"team": {"name": "core"}}}, "tags": [{"title": "go"}, {"title": "db"}]}}`), &record))
+
+	for path, expected := range map[string]any{
+		"id":                              "p1",
+		"expand.author.name":              "ann",
+		"expand.author.expand.team.name":  "core",
+		"expand.tags.1.title":             "db",
+		"expand.tags[0].title":            "go",
+		"meta.matrix[1][0]":               3.0,
+		"meta.matrix.0.1":                 2.0,
+		"expand.tags":                     []any{map[string]any{"title": "go"}, map[string]any{"title": "db"}},
+		"missing":                         nil,
+		"expand.author.missing.name":      nil,
+		"expand.tags.2.title":             nil,
+		"expand.tags[-1].title":           nil,
+		"expand.tags.first":               nil,
+		"id.0":                            nil,
+		"expand.author.expand.team.name.": nil,
+	} {
+		assert.Equal(t, expected, record.GetPath(path), path)
+	}
+
+	record.Set("extra", DynamicRecord{"list": []DynamicRecord{{"id": "x"}}})
+	assert.Equal(t, "x", record.GetPath("extra.list[0].id"))
+}
+
+func TestDynamicRecord_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	posts := CollectionSet[DynamicRecord](client, migrations.PostsPublic)
+
+	record := DynamicRecord{}
+	record.Set("field", "dynamic")
+	created, err := posts.Create(record)
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+
+	one, err := posts.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "dynamic", one.GetString("field"))
+	assert.WithinDuration(t, time.Now(), one.GetTime("created"), time.Minute)
+}
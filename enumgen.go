@@ -0,0 +1,136 @@
+package pocketbase
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SelectValues returns the options of a select field, nil for the other fields.
+func (f SchemaField) SelectValues() []string {
+	if f.Type != "select" {
+		return nil
+	}
+	values, _ := f.Options["values"].([]any)
+	options := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			options = append(options, s)
+		}
+	}
+	return options
+}
+
+// GenerateSelectEnums writes the Go source of the package pkg declaring a typed enum for
+// every select field of the collections, with a constant per option, e.g. for a status
+// field of a posts collection:
+//
+//	type PostsStatus string
+//
+//	const (
+//		PostsStatusDraft     PostsStatus = "draft"
+//		PostsStatusPublished PostsStatus = "published"
+//	)
+//
+// Valid reports whether a value is an option of the field, or empty when the field isn't
+// required, and MarshalJSON fails on the other values, so they are caught before the
+// request. The values decoded from the records aren't checked, to keep decoding the options
+// added later to the collection. The schemas can be read with Collections().FullList(), e.g.
+// in a program run by go generate:
+//
+//	schemas, err := client.Collections().FullList()
+//	// ...
+//	err = pocketbase.GenerateSelectEnums(f, "models", schemas)
+func GenerateSelectEnums(w io.Writer, pkg string, schemas []CollectionSchema) error {
+	schemas = append([]CollectionSchema{}, schemas...)
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Name < schemas[j].Name })
+
+	var enums bytes.Buffer
+	for _, schema := range schemas {
+		for _, field := range schema.Fields {
+			if field.Type == "select" {
+				writeSelectEnum(&enums, schema.Name, field)
+			}
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by pocketbase.GenerateSelectEnums. DO NOT EDIT.\n\npackage %s\n", pkg)
+	if enums.Len() > 0 {
+		fmt.Fprint(&b, "\nimport (\n\"encoding/json\"\n\"fmt\"\n)\n")
+	}
+	b.Write(enums.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("[codegen] can't format source, err %w", err)
+	}
+	if _, err := w.Write(src); err != nil {
+		return fmt.Errorf("[codegen] can't write source, err %w", err)
+	}
+	return nil
+}
+
+// writeSelectEnum writes the enum type of the select field.
+func writeSelectEnum(b *bytes.Buffer, collection string, field SchemaField) {
+	typeName := goIdentifier(collection) + goIdentifier(field.Name)
+	if r := []rune(typeName); len(r) == 0 || !unicode.IsLetter(r[0]) {
+		typeName = "Select" + typeName
+	}
+	values := field.SelectValues()
+	names := make([]string, len(values))
+	seen := map[string]bool{}
+	for i, value := range values {
+		name := typeName + goIdentifier(value)
+		if name == typeName || seen[name] {
+			name = typeName + strconv.Itoa(i+1)
+		}
+		seen[name] = true
+		names[i] = name
+	}
+
+	fmt.Fprintf(b, "\n// %s is a value of the %s select field of the %s collection.\ntype %s string\n", typeName, field.Name, collection, typeName)
+	if len(values) > 0 {
+		fmt.Fprint(b, "\nconst (\n")
+		for i, value := range values {
+			fmt.Fprintf(b, "%s %s = %s\n", names[i], typeName, strconv.Quote(value))
+		}
+		fmt.Fprint(b, ")\n")
+	}
+
+	valid := append([]string{}, names...)
+	if !field.Required {
+		valid = append(valid, `""`)
+	}
+	fmt.Fprintf(b, "\n// Valid reports whether the value is an option of the field")
+	if !field.Required {
+		fmt.Fprint(b, ", or empty")
+	}
+	fmt.Fprintf(b, ".\nfunc (v %s) Valid() bool {\nswitch v {\ncase %s:\nreturn true\n}\nreturn false\n}\n", typeName, strings.Join(valid, ", "))
+	fmt.Fprintf(b, "\n// MarshalJSON encodes the value, failing when it isn't valid.\nfunc (v %s) MarshalJSON() ([]byte, error) {\n"+
+		"if !v.Valid() {\nreturn nil, fmt.Errorf(\"invalid %s.%s value %%q\", string(v))\n}\nreturn json.Marshal(string(v))\n}\n",
+		typeName, collection, field.Name)
+}
+
+// goIdentifier returns the exported Go identifier of a name, e.g. PostsPublic of
+// posts_public, keeping its letters and digits.
+func goIdentifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
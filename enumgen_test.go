@@ -0,0 +1,54 @@
+package pocketbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSelectEnums(t *testing.T) {
+	var schemas []CollectionSchema
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name": "posts_public", "fields": [
+			{"name": "title", "type": "text"},
+			{"name": "status", "type": "select", "required": true, "maxSelect": 1, "values": ["draft", "in review", "draft!", "2024"]},
+			{"name": "tags", "type": "select", "maxSelect": 3, "values": ["go", "db"]}
+		]},
+		{"name": "authors", "fields": [{"name": "role", "type": "select", "values": []}]}
+	]`), &schemas))
+
+	var b bytes.Buffer
+	require.NoError(t, GenerateSelectEnums(&b, "models", schemas))
+	src := b.String()
+	assert.Contains(t, src, "// Code generated by pocketbase.GenerateSelectEnums. DO NOT EDIT.")
+	assert.Contains(t, src, "type PostsPublicStatus string")
+	assert.Contains(t, src, `PostsPublicStatusDraft    PostsPublicStatus = "draft"`)
+	assert.Contains(t, src, `PostsPublicStatusInReview PostsPublicStatus = "in review"`)
+	assert.Contains(t, src, `PostsPublicStatus3        PostsPublicStatus = "draft!"`, "the duplicate names are numbered")
+	assert.Contains(t, src, `PostsPublicStatus2024     PostsPublicStatus = "2024"`)
+	assert.Contains(t, src, "case PostsPublicStatusDraft, PostsPublicStatusInReview, PostsPublicStatus3, PostsPublicStatus2024:",
+		"the empty value of the required field isn't valid")
+	assert.Contains(t, src, `case PostsPublicTagsGo, PostsPublicTagsDb, "":`)
+	assert.Contains(t, src, `return nil, fmt.Errorf("invalid posts_public.status value %q", string(v))`)
+	assert.Less(t, bytes.Index(b.Bytes(), []byte("type AuthorsRole string")), bytes.Index(b.Bytes(), []byte("type PostsPublicStatus")),
+		"the collections are sorted")
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "enums.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("models", fset, []*ast.File{file}, nil)
+	require.NoError(t, err, "the source compiles:\n%s", src)
+
+	b.Reset()
+	require.NoError(t, GenerateSelectEnums(&b, "models", nil))
+	assert.Equal(t, "// Code generated by pocketbase.GenerateSelectEnums. DO NOT EDIT.\n\npackage models\n", b.String())
+}
@@ -0,0 +1,138 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type (
+	// APIError is an error response of PocketBase, e.g. to inspect its status:
+	//
+	//	var aerr *pocketbase.APIError
+	//	if errors.As(err, &aerr) && aerr.StatusCode == http.StatusNotFound {
+	//		...
+	//	}
+	//
+	// It wraps ErrInvalidResponse, and is wrapped by ValidationError for 400 responses.
+	APIError struct {
+		StatusCode int
+		Header     http.Header
+
+		err error
+	}
+
+	// FieldError is the validation failure of a single record field.
+	FieldError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError is returned by Create and Update when PocketBase rejects
+	// the record with a 400 response, e.g. to map the failures back to form inputs:
+	//
+	//	var verr *pocketbase.ValidationError
+	//	if errors.As(err, &verr) {
+	//		log.Print(verr.Fields["email"].Message)
+	//	}
+	//
+	// It wraps ErrInvalidResponse like the other error responses, or ErrInvalidRecord
+	// when the body failed its local validation, see ValidateStruct.
+	ValidationError struct {
+		// Message is the message of the response, e.g. "Failed to create record.".
+		Message string
+		// Fields are the failures by field name.
+		Fields map[string]FieldError
+
+		err error
+	}
+)
+
+func (e *ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// newValidationError wraps err into a ValidationError when resp is a 400
+// response with field errors, and returns err unchanged otherwise.
+func newValidationError(resp *resty.Response, err error) error {
+	if resp.StatusCode() != http.StatusBadRequest {
+		return err
+	}
+	var body struct {
+		Message string                `json:"message"`
+		Data    map[string]FieldError `json:"data"`
+	}
+	if json.Unmarshal(resp.Body(), &body) != nil || len(body.Data) == 0 {
+		return err
+	}
+	return &ValidationError{
+		Message: body.Message,
+		Fields:  body.Data,
+		err:     err,
+	}
+}
+
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// newAPIError wraps err, the error of an error response, into an APIError.
+func newAPIError(resp *resty.Response, err error) error {
+	return &APIError{
+		StatusCode: resp.StatusCode(),
+		Header:     resp.Header(),
+		err:        err,
+	}
+}
+
+// IsRetryable reports whether the call failing with err may succeed when retried later,
+// e.g. by a queue worker: on network errors, timeouts, an exhausted retry budget, an
+// unavailable server and 408, 429 and 5xx responses, except 501.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var aerr *APIError
+	if errors.As(err, &aerr) {
+		switch {
+		case aerr.StatusCode == http.StatusRequestTimeout, aerr.StatusCode == http.StatusTooManyRequests:
+			return true
+		case aerr.StatusCode == http.StatusNotImplemented:
+			return false
+		default:
+			return aerr.StatusCode >= 500
+		}
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, ErrRetryBudgetExhausted) ||
+		errors.Is(err, ErrServerUnavailable)
+}
+
+// IsRateLimited reports whether the call failed with a 429 response of the rate limiter.
+func IsRateLimited(err error) bool {
+	var aerr *APIError
+	return errors.As(err, &aerr) && aerr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsValidationError reports whether the record was rejected with field errors, by PocketBase
+// or by the local validation; retrying it as is fails again.
+func IsValidationError(err error) bool {
+	var verr *ValidationError
+	return errors.As(err, &verr)
+}
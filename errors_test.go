@@ -0,0 +1,114 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+
+	t.Run("create", func(t *testing.T) {
+		_, err := client.Create("users", map[string]any{
+			"email":           "invalid",
+			"password":        "short",
+			"passwordConfirm": "short",
+		})
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr))
+		assert.NotEmpty(t, verr.Message)
+		assert.Equal(t, "validation_is_email", verr.Fields["email"].Code)
+		assert.NotEmpty(t, verr.Fields["email"].Message)
+		assert.Contains(t, verr.Fields, "password")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		users, err := client.List("users", ParamsList{})
+		require.NoError(t, err)
+		require.NotEmpty(t, users.Items)
+
+		err = client.Update("users", users.Items[0]["id"].(string), map[string]any{"email": "invalid"})
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr))
+		assert.Equal(t, []string{"email"}, keys(verr.Fields))
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		_, err := client.Create("invalid_collection", map[string]any{})
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+		var verr *ValidationError
+		assert.False(t, errors.As(err, &verr))
+	})
+}
+
+func keys[V any](m map[string]V) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+func TestIsRetryable(t *testing.T) {
+	status := func(code int) error {
+		return fmt.Errorf("[list] can't list, err %w", &APIError{StatusCode: code, err: ErrInvalidResponse})
+	}
+	validation := &ValidationError{err: status(http.StatusBadRequest)}
+
+	for _, tc := range []struct {
+		err                                error
+		retryable, rateLimited, validation bool
+	}{
+		{err: nil},
+		{err: status(http.StatusNotFound)},
+		{err: status(http.StatusNotImplemented)},
+		{err: validation, validation: true},
+		{err: &ValidationError{err: ErrInvalidRecord}, validation: true},
+		{err: status(http.StatusTooManyRequests), retryable: true, rateLimited: true},
+		{err: status(http.StatusRequestTimeout), retryable: true},
+		{err: status(http.StatusServiceUnavailable), retryable: true},
+		{err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, retryable: true},
+		{err: fmt.Errorf("[one] can't send, err %w", context.DeadlineExceeded), retryable: true},
+		{err: fmt.Errorf("[one] can't send, err %w", context.Canceled)},
+		{err: ErrRetryBudgetExhausted, retryable: true},
+	} {
+		assert.Equal(t, tc.retryable, IsRetryable(tc.err), "%v", tc.err)
+		assert.Equal(t, tc.rateLimited, IsRateLimited(tc.err), "%v", tc.err)
+		assert.Equal(t, tc.validation, IsValidationError(tc.err), "%v", tc.err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+
+	_, err := client.One(migrations.PostsPublic, "missing")
+	var aerr *APIError
+	require.ErrorAs(t, err, &aerr)
+	assert.Equal(t, http.StatusNotFound, aerr.StatusCode)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+	assert.False(t, IsRetryable(err))
+
+	_, err = client.Create("users", map[string]any{"email": "invalid"})
+	require.ErrorAs(t, err, &aerr)
+	assert.Equal(t, http.StatusBadRequest, aerr.StatusCode)
+	assert.True(t, IsValidationError(err))
+
+	_, err = NewClient("http://127.0.0.1:1", WithRetry(0, 0, 0)).One(migrations.PostsPublic, "missing")
+	assert.True(t, IsRetryable(err))
+}
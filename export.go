@@ -0,0 +1,70 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ExportJSON writes the records of the collection matching params to w as a JSON array,
+// page by page, so large collections are exported without holding all their records in
+// memory:
+//
+//	f, err := os.Create("posts.json")
+//	...
+//	err = collection.ExportJSON(f, pocketbase.ParamsList{Sort: "created"})
+//
+// The records are encoded like the values of T, one per line. The export starts at
+// params.Page, by pages of params.Size records, 500 when 0. On error, w holds the
+// records written so far, without the closing bracket.
+func (c *Collection[T]) ExportJSON(w io.Writer, params ParamsList) error {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Size < 1 {
+		params.Size = 500
+	}
+	ctx, cancel := c.callContext(context.Background())
+	defer cancel()
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return fmt.Errorf("[export] can't write records, err %w", err)
+	}
+	written := 0
+	for {
+		page, err := c.list(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, record := range page.Items {
+			raw, err := c.codec.marshalJSON(record)
+			if err != nil {
+				return fmt.Errorf("[export] can't marshal record, err %w", err)
+			}
+			separator := ",\n"
+			if written == 0 {
+				separator = "\n"
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return fmt.Errorf("[export] can't write records, err %w", err)
+			}
+			if _, err := w.Write(raw); err != nil {
+				return fmt.Errorf("[export] can't write records, err %w", err)
+			}
+			written++
+		}
+		if params.Page >= page.TotalPages {
+			break
+		}
+		params.Page++
+	}
+
+	end := "]\n"
+	if written > 0 {
+		end = "\n]\n"
+	}
+	if _, err := io.WriteString(w, end); err != nil {
+		return fmt.Errorf("[export] can't write records, err %w", err)
+	}
+	return nil
+}
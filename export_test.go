@@ -0,0 +1,66 @@
+package pocketbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n--; w.n < 0 {
+		return 0, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func TestCollection_ExportJSON(t *testing.T) {
+	type post struct {
+		ID    string `json:"id"`
+		Title string `json:"title" pb:"field"`
+	}
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, r.URL.Query().Get("page")+"/"+r.URL.Query().Get("perPage"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("filter") == "none" {
+			_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 2, "totalItems": 0, "totalPages": 0, "items": []}`)
+			return
+		}
+		items := fmt.Sprintf(`{"id": "r%d", "field": "a"}, {"id": "r%d", "field": "b"}`, 2*page-1, 2*page)
+		if page == 3 {
+			items = `{"id": "r5", "field": "c"}`
+		}
+		_, _ = fmt.Fprintf(w, `{"page": %d, "perPage": 2, "totalItems": 5, "totalPages": 3, "items": [%s]}`, page, items)
+	}))
+	t.Cleanup(srv.Close)
+	collection := CollectionSet[post](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+
+	var out bytes.Buffer
+	require.NoError(t, collection.ExportJSON(&out, ParamsList{Size: 2}))
+	assert.Equal(t, []string{"1/2", "2/2", "3/2"}, pages)
+	var exported []post
+	require.NoError(t, json.Unmarshal(out.Bytes(), &exported))
+	require.Len(t, exported, 5)
+	assert.Equal(t, post{ID: "r5", Title: "c"}, exported[4])
+	assert.True(t, bytes.HasPrefix(out.Bytes(), []byte("[\n{\"id\":\"r1\",\"title\":\"a\"},\n{")))
+
+	out.Reset()
+	require.NoError(t, collection.ExportJSON(&out, ParamsList{Filters: "none"}))
+	assert.Equal(t, "[]\n", out.String())
+
+	err := collection.ExportJSON(&failingWriter{n: 3}, ParamsList{Size: 2})
+	assert.ErrorContains(t, err, "disk full")
+}
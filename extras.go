@@ -0,0 +1,97 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// Extras collects the fields of the records unknown to the struct type embedding it, so
+// generic tooling, e.g. a sync between two instances, round-trips the fields added to the
+// collection but not to the struct:
+//
+//	type Post struct {
+//		pocketbase.Extras
+//		ID    string `json:"id"`
+//		Title string `json:"title"`
+//	}
+//
+// The unknown fields are decoded into Extras as unmarshaled from JSON, and sent back by
+// Create and Update, except the ones the struct holds. The metadata of the records, e.g.
+// collectionId and expand, aren't collected. In strict decoding mode, the fields collected
+// into Extras aren't errors.
+type Extras struct {
+	Extras map[string]any `json:"-"`
+}
+
+var (
+	extrasType = reflect.TypeFor[Extras]()
+
+	// extrasFieldCache caches the index of the embedded Extras by struct type.
+	extrasFieldCache sync.Map
+)
+
+// extrasField returns the index of the Extras embedded in the struct type, nil without.
+func extrasField(t reflect.Type) []int {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	if cached, ok := extrasFieldCache.Load(t); ok {
+		return cached.([]int)
+	}
+
+	var index []int
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous && (f.Type == extrasType || f.Type == reflect.PointerTo(extrasType)) {
+			index = f.Index
+			break
+		}
+	}
+	extrasFieldCache.Store(t, index)
+	return index
+}
+
+// decodeExtras unmarshals the keys of the record into the Extras of the struct value.
+func (rc *recordCodec) decodeExtras(record map[string]json.RawMessage, keys []string, v reflect.Value, index []int) error {
+	var extras map[string]any
+	if len(keys) > 0 {
+		extras = make(map[string]any, len(keys))
+	}
+	for _, key := range keys {
+		var value any
+		if err := rc.unmarshalJSON(record[key], &value); err != nil {
+			return err
+		}
+		extras[key] = value
+	}
+	index = append(append([]int{}, index...), 0)
+	if extras == nil {
+		// a nil embedded *Extras is left nil
+		if field, ok := fieldByIndex(v, index); ok {
+			field.Set(reflect.Zero(field.Type()))
+		}
+		return nil
+	}
+	allocField(v, index).Set(reflect.ValueOf(extras))
+	return nil
+}
+
+// encodeExtras adds the Extras of the struct value to the encoded record, except the keys
+// it already has.
+func (rc *recordCodec) encodeExtras(record map[string]json.RawMessage, v reflect.Value, index []int) error {
+	field, ok := fieldByIndex(v, append(append([]int{}, index...), 0))
+	if !ok {
+		return nil
+	}
+	for key, value := range field.Interface().(map[string]any) {
+		if _, ok := record[key]; ok {
+			continue
+		}
+		raw, err := rc.marshalJSON(value)
+		if err != nil {
+			return err
+		}
+		record[key] = raw
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtras(t *testing.T) {
+	var sent map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			sent = nil
+			_ = json.Unmarshal(body, &sent)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id": "p1", "collectionId": "c1", "collectionName": "posts", "title": "hello",
+			"views": 3, "meta": {"a": [1, 2]}, "author": "u1",
+			"expand": {"author": {"id": "u1", "name": "ann"}}}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+
+	type user struct {
+		Extras
+		ID string `json:"id"`
+	}
+	type post struct {
+		Extras
+		ID     string `json:"id"`
+		Title  string `json:"title"`
+		Author user   `json:"-" pb:"relation=author"`
+	}
+	posts := CollectionSet[post](client, "posts")
+	one, err := posts.One("p1")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", one.Title)
+	assert.Equal(t, map[string]any{"views": 3.0, "meta": map[string]any{"a": []any{1.0, 2.0}}, "author": "u1"}, one.Extras.Extras)
+	assert.Equal(t, map[string]any{"name": "ann"}, one.Author.Extras.Extras)
+
+	one.Title = "changed"
+	one.Extras.Extras["title"] = "ignored"
+	require.NoError(t, posts.Update("p1", one))
+	assert.Equal(t, map[string]any{"id": "p1", "title": "changed", "views": 3.0, "meta": map[string]any{"a": []any{1.0, 2.0}}, "author": "u1"}, sent)
+
+	_, err = CollectionSet[post](client.Clone(WithStrictDecoding()), "posts").One("p1")
+	assert.NoError(t, err, "the fields collected into Extras aren't unknown")
+
+	type mapped struct {
+		*Extras
+		Headline string `pb:"title"`
+		Views    int    `json:"views"`
+	}
+	m, err := CollectionSet[mapped](client, "posts").One("p1")
+	require.NoError(t, err)
+	assert.Equal(t, mapped{
+		Extras:   &Extras{Extras: map[string]any{"id": "p1", "meta": map[string]any{"a": []any{1.0, 2.0}}, "author": "u1"}},
+		Headline: "hello",
+		Views:    3,
+	}, m)
+}
+
+func TestExtras_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	type post struct {
+		Extras
+		ID string `json:"id,omitempty"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+	created, err := posts.Create(post{Extras: Extras{Extras: map[string]any{"field": "extra"}}})
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+	assert.Equal(t, "extra", created.Record.Extras.Extras["field"])
+
+	one, err := posts.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "extra", one.Extras.Extras["field"])
+	assert.Contains(t, one.Extras.Extras, "created")
+}
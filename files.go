@@ -1,7 +1,7 @@
 package pocketbase
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 )
 
@@ -24,6 +24,7 @@ func (f Files) GetToken() (string, error) {
 	}
 
 	request := f.client.R().
+		SetContext(retrySafe(context.Background())).
 		SetHeader("Content-Type", "application/json")
 
 	resp, err := request.Post(f.url + "/api/files/token")
@@ -32,15 +33,15 @@ func (f Files) GetToken() (string, error) {
 	}
 
 	if resp.IsError() {
-		return "", fmt.Errorf("[files] pocketbase returned status at getting a new token: %d, msg: %s, err %w",
+		return "", newAPIError(resp, fmt.Errorf("[files] pocketbase returned status at getting a new token: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
 	response := ResponseGetToken{}
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := f.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return "", fmt.Errorf("[files] can't unmarshal response, err %w", err)
 	}
 	return response.Token, nil
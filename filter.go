@@ -0,0 +1,161 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateTimeLayout is the layout of the date fields of PocketBase, in UTC.
+const dateTimeLayout = "2006-01-02 15:04:05.000Z"
+
+// filterPlaceholder matches the {:name} placeholders of a filter expression.
+var filterPlaceholder = regexp.MustCompile(`\{:(\w+)\}`)
+
+// Filter renders the filter expression, replacing its {:name} placeholders with the escaped
+// literals of the params, so user input can't change the expression:
+//
+//	filter := pocketbase.Filter("title ~ {:title} && created > {:since}", map[string]any{
+//		"title": input,
+//		"since": time.Now().Add(-24 * time.Hour),
+//	})
+//
+// Strings are quoted, times are rendered as UTC datetimes, nil as null and other values
+// not being numbers or booleans are quoted as JSON. Placeholders without param are kept.
+// The trailing backslashes of the strings are trimmed, as a filter string can't end with one.
+func Filter(expr string, params map[string]any) string {
+	return filterPlaceholder.ReplaceAllStringFunc(expr, func(placeholder string) string {
+		value, ok := params[placeholder[2:len(placeholder)-1]]
+		if !ok {
+			return placeholder
+		}
+		return filterLiteral(value)
+	})
+}
+
+// filterLiteral returns the filter literal of a value.
+func filterLiteral(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quoteFilter(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return quoteFilter(v.UTC().Format(dateTimeLayout))
+	case fmt.Stringer:
+		return quoteFilter(v.String())
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return quoteFilter(fmt.Sprint(v))
+		}
+		return quoteFilter(string(raw))
+	}
+}
+
+// quoteFilter returns the single-quoted filter string literal of s. The filter strings only
+// unescape the quotes, so the backslashes are kept as is, but for the trailing ones, which
+// would escape the closing quote and are trimmed.
+func quoteFilter(s string) string {
+	return "'" + strings.ReplaceAll(strings.TrimRight(s, `\`), `'`, `\'`) + "'"
+}
+
+// Search returns the filter matching the records with the term in one of the fields, e.g.
+// for a search box, and an empty filter without term or field:
+//
+//	filter := pocketbase.Search("it's", "title", "body", "author.name")
+//	// title ~ 'it\'s' || body ~ 'it\'s' || author.name ~ 'it\'s'
+//
+// The term is matched literally, its wildcards escaped, except its trailing backslashes.
+func Search(term string, fields ...string) string {
+	term = searchTerm(term)
+	if term == "" || len(fields) == 0 {
+		return ""
+	}
+	return searchExpr(fields, searchLiteral(term))
+}
+
+// SearchTokens returns the filter matching the records with every word of the term in one
+// of the fields, e.g. "go sdk" matching the title "PocketBase SDK for Go":
+//
+//	filter := pocketbase.SearchTokens("go sdk", "title", "body")
+//	// (title ~ 'go' || body ~ 'go') && (title ~ 'sdk' || body ~ 'sdk')
+func SearchTokens(term string, fields ...string) string {
+	var words []string
+	for _, word := range strings.Fields(term) {
+		if word = searchTerm(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 || len(fields) == 0 {
+		return ""
+	}
+	if len(words) == 1 {
+		return Search(words[0], fields...)
+	}
+	conditions := make([]string, len(words))
+	for i, word := range words {
+		conditions[i] = "(" + searchExpr(fields, searchLiteral(word)) + ")"
+	}
+	return strings.Join(conditions, " && ")
+}
+
+// searchExpr returns the expression matching the literal in one of the fields.
+func searchExpr(fields []string, literal string) string {
+	conditions := make([]string, len(fields))
+	for i, field := range fields {
+		conditions[i] = field + " ~ " + literal
+	}
+	return strings.Join(conditions, " || ")
+}
+
+// searchTerm trims the spaces of a search term, and its trailing backslashes, which would
+// escape the closing quote of its literal.
+func searchTerm(term string) string {
+	return strings.TrimRight(strings.TrimSpace(term), `\`)
+}
+
+// searchLiteral returns the filter literal of a term matched literally by ~. PocketBase
+// escapes the LIKE wildcards of the operands without unescaped %, so only % is escaped.
+func searchLiteral(term string) string {
+	return quoteFilter(strings.ReplaceAll(term, "%", `\%`))
+}
+
+// Between returns the filter matching the records whose date field is in the range from
+// from, included, to to, excluded, so consecutive ranges don't overlap:
+//
+//	filter := pocketbase.Between("created", start, start.AddDate(0, 0, 7))
+//	// (created >= '2024-03-04 00:00:00.000Z' && created < '2024-03-11 00:00:00.000Z')
+//
+// The times are rendered as UTC datetimes. A zero time leaves its end of the range open,
+// and the filter is empty when both are zero.
+func Between(field string, from, to time.Time) string {
+	switch {
+	case from.IsZero() && to.IsZero():
+		return ""
+	case to.IsZero():
+		return Filter(field+" >= {:from}", map[string]any{"from": from})
+	case from.IsZero():
+		return Filter(field+" < {:to}", map[string]any{"to": to})
+	}
+	return Filter("("+field+" >= {:from} && "+field+" < {:to})", map[string]any{"from": from, "to": to})
+}
+
+// WithinLast returns the filter matching the records whose date field is within the last
+// duration, by the local clock, e.g. the records updated in the last day:
+//
+//	filter := pocketbase.WithinLast("updated", 24*time.Hour)
+func WithinLast(field string, d time.Duration) string {
+	return Between(field, time.Now().Add(-d), time.Time{})
+}
@@ -0,0 +1,160 @@
+package pocketbase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	since := time.Date(2024, 1, 2, 4, 4, 5, 0, time.FixedZone("", 3600))
+	filter := Filter("title ~ {:title} && created > {:since} && views > {:views} && draft = {:draft} && tags ?= {:tags} && x = {:x} && y = {:missing}", map[string]any{
+		"title": `it's a "test" \o/`,
+		"since": since,
+		"views": 1.5,
+		"draft": false,
+		"tags":  []string{"a"},
+		"x":     nil,
+	})
+	assert.Equal(t, `title ~ 'it\'s a "test" \o/' && created > '2024-01-02 03:04:05.000Z' && views > 1.5 && draft = false && tags ?= '["a"]' && x = null && y = {:missing}`, filter)
+}
+
+func TestFilter_Parse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`C:\dir`, `C:\dir`},
+		{`it's`, `it's`},
+		{`a\'b`, `a\'b`},
+		{`x\`, `x`},
+		{`x\\`, `x`},
+		{`'`, `'`},
+		{`\' && b = 'z`, `\' && b = 'z`},
+		{`x\' || 1 = 1 || a = '`, `x\' || 1 = 1 || a = '`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			filter := Filter("a = {:a} && b = {:b}", map[string]any{"a": tt.value, "b": "y"})
+			groups, err := fexpr.Parse(filter)
+			require.NoError(t, err, filter)
+			require.Len(t, groups, 2, "the value can't change the expression: %s", filter)
+			assert.Equal(t, tt.want, groups[0].Item.(fexpr.Expr).Right.Literal)
+			assert.Equal(t, "y", groups[1].Item.(fexpr.Expr).Right.Literal)
+		})
+	}
+}
+
+func TestFilter_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	posts := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	for _, field := range []string{`C:\dir\it's`, `C:\\dir\it's`} {
+		created, err := posts.Create(map[string]any{"field": field})
+		require.NoError(t, err)
+		defer func() {
+			_ = posts.Delete(created.ID)
+		}()
+	}
+
+	found, err := posts.Query().Where(Filter("field = {:field}", map[string]any{"field": `C:\dir\it's`})).All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, `C:\dir\it's`, found[0]["field"])
+}
+
+func TestSearch(t *testing.T) {
+	assert.Equal(t, `title ~ 'it\'s' || author.name ~ 'it\'s'`, Search(" it's ", "title", "author.name"))
+	assert.Equal(t, `title ~ '50\% off_ a\b'`, Search(`50% off_ a\b\\`, "title"), "the % wildcards are escaped")
+	assert.Empty(t, Search("  ", "title"))
+	assert.Empty(t, Search(` \ `, "title"))
+	assert.Empty(t, Search("go"))
+
+	assert.Equal(t, `(title ~ 'go' || body ~ 'go') && (title ~ 'sdk' || body ~ 'sdk')`, SearchTokens(" go  sdk", "title", "body"))
+	assert.Equal(t, `title ~ 'go'`, SearchTokens("go", "title"))
+	assert.Empty(t, SearchTokens(` \ `, "title"))
+}
+
+func TestSearch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+	for _, field := range []string{"searched 50% off", "searched 50x off", `searched it's a\b_c`, "searched it's axbxc"} {
+		created, err := posts.Create(post{Field: field})
+		require.NoError(t, err)
+		defer func() {
+			_ = posts.Delete(created.ID)
+		}()
+	}
+	search := func(filter string) []string {
+		found, err := posts.Query().Where(filter).OrderBy("field").All(context.Background())
+		require.NoError(t, err)
+		var fields []string
+		for _, p := range found {
+			fields = append(fields, p.Field)
+		}
+		return fields
+	}
+
+	assert.Equal(t, []string{"searched 50% off"}, search(Search("50% off", "id", "field")), "the wildcards are literal")
+	assert.Equal(t, []string{`searched it's a\b_c`}, search(Search(`it's a\b_c`, "field")))
+	assert.Equal(t, []string{"searched 50% off", "searched 50x off"}, search(SearchTokens("off searched\\", "field")))
+}
+
+func TestBetween(t *testing.T) {
+	from := time.Date(2024, 3, 4, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+	to := from.AddDate(0, 0, 7).Add(1500 * time.Microsecond)
+	assert.Equal(t, "(created >= '2024-03-04 00:00:00.000Z' && created < '2024-03-11 00:00:00.001Z')", Between("created", from, to))
+	assert.Equal(t, "created >= '2024-03-04 00:00:00.000Z'", Between("created", from, time.Time{}))
+	assert.Equal(t, "created < '2024-03-11 00:00:00.001Z'", Between("created", time.Time{}, to))
+	assert.Empty(t, Between("created", time.Time{}, time.Time{}))
+
+	since := time.Now().Add(-time.Hour)
+	filter := WithinLast("updated", time.Hour)
+	parsed, err := time.Parse("updated >= '"+dateTimeLayout+"'", filter)
+	require.NoError(t, err)
+	assert.WithinDuration(t, since, parsed, time.Second)
+}
+
+func TestBetween_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithTimeLayouts(DateTimeLayout))
+	type post struct {
+		ID      string    `json:"id,omitempty"`
+		Field   string    `json:"field"`
+		Created time.Time `json:"created,omitzero"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+	created, err := posts.Create(post{Field: "between"})
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+	record, err := posts.One(created.ID)
+	require.NoError(t, err)
+
+	count := func(filter string) int {
+		found, err := posts.Query().Where(Filter("id = {:id}", map[string]any{"id": created.ID}) + " && " + filter).All(context.Background())
+		require.NoError(t, err)
+		return len(found)
+	}
+	assert.Equal(t, 1, count(Between("created", record.Created, record.Created.Add(time.Millisecond))), "the start is included")
+	assert.Equal(t, 0, count(Between("created", record.Created.Add(-time.Hour), record.Created)), "the end is excluded")
+	assert.Equal(t, 1, count(WithinLast("created", time.Hour)))
+}
@@ -0,0 +1,116 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// defaultConcurrency is the number of items processed at once by ForEachConcurrent by default.
+const defaultConcurrency = 4
+
+type (
+	// ItemError is the failure of an item of a bulk operation.
+	ItemError struct {
+		// Index is the index of the item in the processed items.
+		Index int
+		Err   error
+	}
+
+	// BulkError is returned by ForEachConcurrent when items failed, with their failures
+	// sorted by index. It matches the errors of all its items with errors.Is and errors.As.
+	BulkError struct {
+		Errors []ItemError
+		// Total is the number of processed items.
+		Total int
+	}
+)
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("[foreach] %d of %d items failed, first: %v", len(e.Errors), e.Total, e.Errors[0])
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, item := range e.Errors {
+		errs[i] = item
+	}
+	return errs
+}
+
+// Failed returns the indexes of the failed items, e.g. to retry them.
+func (e *BulkError) Failed() []int {
+	indexes := make([]int, len(e.Errors))
+	for i, item := range e.Errors {
+		indexes[i] = item.Index
+	}
+	return indexes
+}
+
+// ForEachConcurrent calls fn for every item with at most limit calls at once, 4 when
+// limit is 0, so bulk jobs don't hand-roll semaphores around the client:
+//
+//	err := pocketbase.ForEachConcurrent(ctx, posts, 8, func(ctx context.Context, post Post) error {
+//		_, err := collection.Create(post)
+//		return err
+//	})
+//
+// A failing item doesn't stop the others. Once ctx is done, the items not started yet
+// fail with its error. The failures are returned as a *BulkError.
+func ForEachConcurrent[T any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) error) error {
+	if limit <= 0 {
+		limit = defaultConcurrency
+	}
+
+	var (
+		mu     sync.Mutex
+		failed []ItemError
+		wg     sync.WaitGroup
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		failed = append(failed, ItemError{Index: i, Err: err})
+		mu.Unlock()
+	}
+
+	sem := make(chan struct{}, limit)
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(i, ctx.Err())
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			fail(i, err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fn(ctx, item); err != nil {
+				fail(i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Index < failed[j].Index })
+	return &BulkError{Errors: failed, Total: len(items)}
+}
@@ -0,0 +1,64 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachConcurrent(t *testing.T) {
+	errOdd := errors.New("odd")
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	var running, peak, calls atomic.Int32
+	err := ForEachConcurrent(context.Background(), items, 3, func(_ context.Context, item int) error {
+		calls.Add(1)
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if item%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+
+	var bulk *BulkError
+	require.ErrorAs(t, err, &bulk)
+	assert.ErrorIs(t, err, errOdd)
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, bulk.Failed())
+	assert.Equal(t, 10, bulk.Total)
+	assert.EqualValues(t, 10, calls.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.EqualError(t, err, "[foreach] 5 of 10 items failed, first: item 1: odd")
+
+	assert.NoError(t, ForEachConcurrent(context.Background(), items, 0, func(context.Context, int) error { return nil }))
+	assert.NoError(t, ForEachConcurrent(context.Background(), []int(nil), 0, func(context.Context, int) error { return nil }))
+}
+
+func TestForEachConcurrent_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	err := ForEachConcurrent(ctx, make([]int, 10), 1, func(context.Context, int) error {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	var bulk *BulkError
+	require.ErrorAs(t, err, &bulk)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{2, 3, 4, 5, 6, 7, 8, 9}, bulk.Failed())
+	assert.EqualValues(t, 2, calls.Load())
+}
@@ -0,0 +1,478 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// graphqlField is a field of a GraphQL selection set.
+type graphqlField struct {
+	alias      string
+	name       string
+	args       map[string]any
+	selections []*graphqlField
+}
+
+// key returns the key of the field in the result.
+func (f *graphqlField) key() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+// GraphQL executes a read-only GraphQL query against the collections, for frontends that
+// prefer GraphQL over filter strings. Every top-level field is a collection, listed with
+// the filter, sort, page and perPage arguments, or viewed with the id argument. Nested
+// selections are relations, e.g. author, or back-relations, e.g. comments_via_post,
+// which are fetched in the same call with the computed expand and fields params.
+//
+//	data, err := client.GraphQL(ctx, `query($status: String) {
+//		posts(filter: $status, sort: "-created", perPage: 10) {
+//			id
+//			title
+//			author { name }
+//		}
+//	}`, map[string]any{"status": "status = 'published'"})
+//
+// The result maps the fields, or their aliases, to the list of records or the record.
+// Mutations, fragments and directives aren't supported.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any) (map[string]any, error) {
+	fields, err := parseGraphQL(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any, len(fields))
+	for _, f := range fields {
+		result, err := c.graphqlResolve(ctx, f)
+		if err != nil {
+			return data, err
+		}
+		data[f.key()] = result
+	}
+	return data, nil
+}
+
+// graphqlResolve fetches the records of a top-level field.
+func (c *Client) graphqlResolve(ctx context.Context, f *graphqlField) (any, error) {
+	if len(f.selections) == 0 {
+		return nil, fmt.Errorf("[graphql] collection %s has no selection", f.name)
+	}
+	fields, expand := graphqlParams(f.selections, "", "")
+	query := url.Values{"fields": {strings.Join(fields, ",")}}
+	if len(expand) > 0 {
+		query.Set("expand", strings.Join(expand, ","))
+	}
+
+	path := "/api/collections/" + url.PathEscape(f.name) + "/records"
+	if id, ok := f.args["id"]; ok {
+		if len(f.args) > 1 {
+			return nil, fmt.Errorf("[graphql] collection %s can't combine id with other arguments", f.name)
+		}
+		resp, err := c.Send(ctx, Request{Path: path + "/" + url.PathEscape(fmt.Sprint(id)), Query: query})
+		if err != nil {
+			return nil, fmt.Errorf("[graphql] can't view %s, err %w", f.name, err)
+		}
+		var record map[string]any
+		if err := resp.Decode(&record); err != nil {
+			return nil, err
+		}
+		return graphqlShape(record, f.selections), nil
+	}
+
+	for name, value := range f.args {
+		switch name {
+		case "filter", "sort", "page", "perPage":
+			query.Set(name, fmt.Sprint(value))
+		default:
+			return nil, fmt.Errorf("[graphql] unknown argument %s of collection %s", name, f.name)
+		}
+	}
+	resp, err := c.Send(ctx, Request{Path: path, Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("[graphql] can't list %s, err %w", f.name, err)
+	}
+	var list ResponseList[map[string]any]
+	if err := resp.Decode(&list); err != nil {
+		return nil, err
+	}
+	items := make([]any, 0, len(list.Items))
+	for _, record := range list.Items {
+		items = append(items, graphqlShape(record, f.selections))
+	}
+	return items, nil
+}
+
+// graphqlParams returns the fields and expand params of the selections of the records at
+// the fields prefix and expand path.
+func graphqlParams(selections []*graphqlField, prefix, path string) (fields, expand []string) {
+	for _, s := range selections {
+		if len(s.selections) == 0 {
+			fields = append(fields, prefix+s.name)
+			continue
+		}
+		relation := s.name
+		if path != "" {
+			relation = path + "." + s.name
+		}
+		expand = append(expand, relation)
+		nestedFields, nestedExpand := graphqlParams(s.selections, prefix+"expand."+s.name+".", relation)
+		fields = append(fields, nestedFields...)
+		expand = append(expand, nestedExpand...)
+	}
+	return fields, expand
+}
+
+// graphqlShape maps the record to the selections, moving the expanded relations to their fields.
+func graphqlShape(record map[string]any, selections []*graphqlField) map[string]any {
+	shaped := make(map[string]any, len(selections))
+	expand, _ := record["expand"].(map[string]any)
+	for _, s := range selections {
+		if len(s.selections) == 0 {
+			shaped[s.key()] = record[s.name]
+			continue
+		}
+		switch related := expand[s.name].(type) {
+		case map[string]any:
+			shaped[s.key()] = graphqlShape(related, s.selections)
+		case []any:
+			items := make([]any, 0, len(related))
+			for _, item := range related {
+				if m, ok := item.(map[string]any); ok {
+					items = append(items, graphqlShape(m, s.selections))
+				}
+			}
+			shaped[s.key()] = items
+		default:
+			shaped[s.key()] = nil
+		}
+	}
+	return shaped
+}
+
+// graphqlParser parses the subset of the GraphQL query language supported by Client.GraphQL.
+type graphqlParser struct {
+	src       string
+	pos       int
+	variables map[string]any
+}
+
+// parseGraphQL parses a query, with the variables substituted, into its top-level fields.
+func parseGraphQL(query string, variables map[string]any) ([]*graphqlField, error) {
+	p := &graphqlParser{src: query, variables: map[string]any{}}
+	for name, value := range variables {
+		p.variables[name] = value
+	}
+
+	p.skip()
+	if p.peek() != '{' {
+		operation := p.name()
+		if operation != "query" {
+			return nil, p.errorf("only queries are supported, got %q", operation)
+		}
+		p.skip()
+		if isGraphQLNameStart(p.peek()) {
+			p.name()
+			p.skip()
+		}
+		if p.peek() == '(' {
+			if err := p.variableDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	fields, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	p.skip()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q after the query", p.src[p.pos:])
+	}
+	return fields, nil
+}
+
+func (p *graphqlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("[graphql] can't parse query at %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// skip skips the ignored tokens: white space, commas and comments.
+func (p *graphqlParser) skip() {
+	for p.pos < len(p.src) {
+		switch ch := p.src[p.pos]; {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			p.pos++
+		case ch == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next byte, 0 at the end.
+func (p *graphqlParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+// expect consumes the punctuator after the ignored tokens.
+func (p *graphqlParser) expect(ch byte) error {
+	p.skip()
+	if p.peek() != ch {
+		return p.errorf("expected %q", ch)
+	}
+	p.pos++
+	return nil
+}
+
+func isGraphQLNameStart(ch byte) bool {
+	return ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// name consumes a name, empty when there is none.
+func (p *graphqlParser) name() string {
+	start := p.pos
+	if !isGraphQLNameStart(p.peek()) {
+		return ""
+	}
+	for p.pos < len(p.src) && (isGraphQLNameStart(p.src[p.pos]) || p.src[p.pos] >= '0' && p.src[p.pos] <= '9') {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// variableDefinitions consumes the variable definitions, applying their defaults.
+func (p *graphqlParser) variableDefinitions() error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		p.skip()
+		if p.peek() == ')' {
+			p.pos++
+			return nil
+		}
+		if err := p.expect('$'); err != nil {
+			return err
+		}
+		name := p.name()
+		if name == "" {
+			return p.errorf("expected a variable name")
+		}
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+		// the type is only checked for its syntax
+		p.skip()
+		for p.peek() == '[' || p.peek() == ']' || p.peek() == '!' || isGraphQLNameStart(p.peek()) {
+			if p.name() == "" {
+				p.pos++
+			}
+			p.skip()
+		}
+		if p.peek() == '=' {
+			p.pos++
+			value, err := p.value()
+			if err != nil {
+				return err
+			}
+			if _, ok := p.variables[name]; !ok {
+				p.variables[name] = value
+			}
+		}
+	}
+}
+
+// selectionSet consumes a selection set.
+func (p *graphqlParser) selectionSet() ([]*graphqlField, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	var fields []*graphqlField
+	for {
+		p.skip()
+		switch {
+		case p.peek() == '}':
+			p.pos++
+			if len(fields) == 0 {
+				return nil, p.errorf("empty selection set")
+			}
+			return fields, nil
+		case strings.HasPrefix(p.src[p.pos:], "..."):
+			return nil, p.errorf("fragments are not supported")
+		}
+
+		f, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+}
+
+// field consumes a field, with its alias, arguments and selections.
+func (p *graphqlParser) field() (*graphqlField, error) {
+	f := &graphqlField{name: p.name()}
+	if f.name == "" {
+		return nil, p.errorf("expected a field name")
+	}
+	p.skip()
+	if p.peek() == ':' {
+		p.pos++
+		p.skip()
+		f.alias, f.name = f.name, p.name()
+		if f.name == "" {
+			return nil, p.errorf("expected a field name")
+		}
+		p.skip()
+	}
+
+	if p.peek() == '(' {
+		p.pos++
+		f.args = map[string]any{}
+		for {
+			p.skip()
+			if p.peek() == ')' {
+				p.pos++
+				break
+			}
+			name := p.name()
+			if name == "" {
+				return nil, p.errorf("expected an argument name")
+			}
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				f.args[name] = value
+			}
+		}
+		p.skip()
+	}
+
+	switch p.peek() {
+	case '@':
+		return nil, p.errorf("directives are not supported")
+	case '{':
+		selections, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		f.selections = selections
+	}
+	return f, nil
+}
+
+// value consumes a value: a variable, string, number, boolean, null, enum or list.
+func (p *graphqlParser) value() (any, error) {
+	p.skip()
+	switch ch := p.peek(); {
+	case ch == '$':
+		p.pos++
+		name := p.name()
+		if name == "" {
+			return nil, p.errorf("expected a variable name")
+		}
+		return p.variables[name], nil
+	case ch == '"':
+		return p.string()
+	case ch == '-' || ch >= '0' && ch <= '9':
+		return p.number()
+	case ch == '[':
+		p.pos++
+		var list []any
+		for {
+			p.skip()
+			if p.peek() == ']' {
+				p.pos++
+				return list, nil
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+	case isGraphQLNameStart(ch):
+		switch name := p.name(); name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			return name, nil // enum value
+		}
+	default:
+		return nil, p.errorf("expected a value")
+	}
+}
+
+// string consumes a string, whose escapes are the ones of JSON.
+func (p *graphqlParser) string() (string, error) {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		return "", p.errorf("block strings are not supported")
+	}
+	start := p.pos
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal([]byte(p.src[start:p.pos]), &s); err != nil {
+				return "", p.errorf("invalid string %s", p.src[start:p.pos])
+			}
+			return s, nil
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// number consumes an int or float.
+func (p *graphqlParser) number() (any, error) {
+	start := p.pos
+	float := false
+	for p.pos < len(p.src) {
+		ch := p.src[p.pos]
+		switch {
+		case ch >= '0' && ch <= '9' || ch == '-' || ch == '+':
+		case ch == '.' || ch == 'e' || ch == 'E':
+			float = true
+		default:
+			goto done
+		}
+		p.pos++
+	}
+done:
+	raw := p.src[start:p.pos]
+	if float {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", raw)
+		}
+		return v, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, p.errorf("invalid number %s", raw)
+	}
+	return v, nil
+}
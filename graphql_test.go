@@ -0,0 +1,99 @@
+package pocketbase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGraphQL(t *testing.T) {
+	fields, err := parseGraphQL(`query Posts($filter: String = "draft = false", $size: Int!) {
+		# latest posts
+		latest: posts(filter: $filter, perPage: $size, sort: "-created") {
+			id, title
+			author { name avatar: picture }
+		}
+		post: posts(id: "abc") { id }
+	}`, map[string]any{"size": 5})
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+
+	latest := fields[0]
+	assert.Equal(t, "latest", latest.key())
+	assert.Equal(t, "posts", latest.name)
+	assert.Equal(t, map[string]any{"filter": "draft = false", "perPage": 5, "sort": "-created"}, latest.args)
+	require.Len(t, latest.selections, 3)
+	assert.Equal(t, "avatar", latest.selections[2].selections[1].key())
+	assert.Equal(t, "picture", latest.selections[2].selections[1].name)
+	assert.Equal(t, map[string]any{"id": "abc"}, fields[1].args)
+
+	fields, err = parseGraphQL(`{ posts(perPage: 1.5, sort: null, tags: [A, "b\n", true]) { id } }`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"perPage": 1.5, "tags": []any{"A", "b\n", true}}, fields[0].args)
+
+	for _, query := range []string{
+		`mutation { posts { id } }`,
+		`{ posts { ...PostFields } }`,
+		`{ posts @include(if: true) { id } }`,
+		`{ posts { } }`,
+		`{ posts(filter: "unterminated) { id } }`,
+		`{ posts { id } } extra`,
+	} {
+		_, err := parseGraphQL(query, nil)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestGraphQLParams(t *testing.T) {
+	fields, err := parseGraphQL(`{ posts { id author { name team { id } } comments_via_post { text } } }`, nil)
+	require.NoError(t, err)
+
+	f, expand := graphqlParams(fields[0].selections, "", "")
+	assert.Equal(t, []string{"id", "expand.author.name", "expand.author.expand.team.id", "expand.comments_via_post.text"}, f)
+	assert.Equal(t, []string{"author", "author.team", "comments_via_post"}, expand)
+}
+
+func TestGraphQLShape(t *testing.T) {
+	fields, err := parseGraphQL(`{ posts { key: id author { name } comments_via_post { text } editor { name } } }`, nil)
+	require.NoError(t, err)
+
+	shaped := graphqlShape(map[string]any{
+		"id": "p1",
+		"expand": map[string]any{
+			"author":            map[string]any{"name": "ann"},
+			"comments_via_post": []any{map[string]any{"text": "first"}},
+		},
+	}, fields[0].selections)
+	assert.Equal(t, map[string]any{
+		"key":               "p1",
+		"author":            map[string]any{"name": "ann"},
+		"comments_via_post": []any{map[string]any{"text": "first"}},
+		"editor":            nil,
+	}, shaped)
+}
+
+func TestClient_GraphQL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	created, err := client.Create(migrations.PostsPublic, map[string]any{"field": "graphql"})
+	require.NoError(t, err)
+	defer func() {
+		_ = client.Delete(migrations.PostsPublic, created.ID)
+	}()
+
+	data, err := client.GraphQL(context.Background(), `query($id: String) {
+		posts: posts_public(filter: "field = 'graphql'", perPage: 2) { id value: field }
+		post: posts_public(id: $id) { field }
+	}`, map[string]any{"id": created.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"id": created.ID, "value": "graphql"}}, data["posts"])
+	assert.Equal(t, map[string]any{"field": "graphql"}, data["post"])
+
+	_, err = client.GraphQL(context.Background(), `{ posts_public(limit: 1) { id } }`, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,362 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type (
+	// HARRecorder records the requests and responses of clients into an HTTP Archive (HAR),
+	// e.g. to share the traffic of a failing scenario with the PocketBase maintainers, or
+	// to open it in the network tab of a browser:
+	//
+	//	recorder := pocketbase.NewHARRecorder()
+	//	client := pocketbase.NewClient(url, pocketbase.WithHARRecorder(recorder))
+	//	// ...
+	//	err := recorder.WriteFile("pocketbase.har")
+	//
+	// Every response is recorded, including the responses of the retried attempts, and so
+	// are the requests failed without response. The credentials are redacted: the
+	// Authorization and cookie headers, and the JSON fields and query parameters matching
+	// the redaction of the client, see WithRedaction. The bodies of the file uploads aren't
+	// recorded, and nor are the bodies of the streamed responses, e.g. the downloads and the
+	// realtime events.
+	//
+	// A recorder can be shared by several clients, and is safe for concurrent use.
+	HARRecorder struct {
+		mu      sync.Mutex
+		entries []harEntry
+	}
+
+	harLog struct {
+		Log struct {
+			Version string     `json:"version"`
+			Creator harCreator `json:"creator"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	harEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+		Comment         string      `json:"comment,omitempty"`
+	}
+
+	harRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		PostData    *harPostData   `json:"postData,omitempty"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		Content     harContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	harPostData struct {
+		MimeType string         `json:"mimeType"`
+		Text     string         `json:"text,omitempty"`
+		Params   []harNameValue `json:"params,omitempty"`
+	}
+
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+	}
+
+	// harTimings are in milliseconds, -1 for the phases which don't apply.
+	harTimings struct {
+		DNS     float64 `json:"dns"`
+		Connect float64 `json:"connect"`
+		SSL     float64 `json:"ssl"`
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
+
+// NewHARRecorder creates an empty HAR recorder.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// WithHARRecorder records the requests and responses of the client into the recorder.
+func WithHARRecorder(recorder *HARRecorder) ClientOption {
+	return func(c *Client) {
+		c.client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+			recorder.recordResponse(resp, c.redaction)
+			return nil
+		})
+		c.client.OnError(func(r *resty.Request, err error) {
+			// the attempts with a response are already recorded
+			var respErr *resty.ResponseError
+			if !errors.As(err, &respErr) || respErr.Response.RawResponse == nil {
+				recorder.recordError(r, err, c.redaction)
+			}
+		})
+	}
+}
+
+// Len returns the number of recorded entries.
+func (h *HARRecorder) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.entries)
+}
+
+// Reset drops the recorded entries.
+func (h *HARRecorder) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = nil
+}
+
+// WriteTo writes the recorded entries to w as a HAR 1.2 file.
+func (h *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	var log harLog
+	log.Log.Version = "1.2"
+	log.Log.Creator = harCreator{Name: "github.com/Forty2Co/pocketbase", Version: moduleVersion()}
+	h.mu.Lock()
+	log.Log.Entries = append([]harEntry{}, h.entries...)
+	h.mu.Unlock()
+
+	raw, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("[har] can't marshal entries, err %w", err)
+	}
+	n, err := w.Write(append(raw, '\n'))
+	if err != nil {
+		return int64(n), fmt.Errorf("[har] can't write entries, err %w", err)
+	}
+	return int64(n), nil
+}
+
+// WriteFile writes the recorded entries to the file as a HAR 1.2 file.
+func (h *HARRecorder) WriteFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("[har] can't create file, err %w", err)
+	}
+	if _, err := h.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("[har] can't write entries, err %w", err)
+	}
+	return nil
+}
+
+func (h *HARRecorder) recordResponse(resp *resty.Response, redaction Redaction) {
+	// the recordings keep the bodies whole
+	redaction.MaxBodyLength = 0
+	entry := newHAREntry(resp.Request, redaction)
+	entry.Response = harResponse{
+		Status:      resp.StatusCode(),
+		StatusText:  http.StatusText(resp.StatusCode()),
+		HTTPVersion: resp.Proto(),
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(redactHeader(resp.Header())),
+		Content:     harContent{Size: len(resp.Body()), MimeType: resp.Header().Get("Content-Type")},
+		RedirectURL: resp.Header().Get("Location"),
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	// the streamed responses have no body
+	if body := resp.Body(); body != nil {
+		entry.Response.BodySize = len(body)
+		entry.Response.Content.Text = redaction.redact(body)
+	}
+
+	entry.Time = harMillis(resp.Time())
+	entry.Timings = harTimings{DNS: -1, Connect: -1, SSL: -1, Wait: entry.Time}
+	if trace := resp.Request.TraceInfo(); trace.TotalTime > 0 {
+		entry.Timings = harTimings{
+			DNS:     harPhase(trace.DNSLookup, trace.IsConnReused),
+			Connect: harPhase(trace.TCPConnTime, trace.IsConnReused),
+			SSL:     harPhase(trace.TLSHandshake, trace.IsConnReused || trace.TLSHandshake <= 0),
+			Wait:    harMillis(max(trace.ServerTime, 0)),
+			Receive: harMillis(max(trace.ResponseTime, 0)),
+		}
+	}
+	h.add(entry)
+}
+
+func (h *HARRecorder) recordError(r *resty.Request, err error, redaction Redaction) {
+	redaction.MaxBodyLength = 0
+	entry := newHAREntry(r, redaction)
+	entry.Response = harResponse{Cookies: []harNameValue{}, Headers: []harNameValue{}, HeadersSize: -1, BodySize: -1}
+	if !r.Time.IsZero() {
+		entry.Time = harMillis(time.Since(r.Time))
+	}
+	entry.Timings = harTimings{DNS: -1, Connect: -1, SSL: -1, Wait: entry.Time}
+	entry.Comment = err.Error()
+	h.add(entry)
+}
+
+func (h *HARRecorder) add(entry harEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+}
+
+// newHAREntry returns an entry with the redacted request.
+func newHAREntry(r *resty.Request, redaction Redaction) harEntry {
+	started := r.Time
+	if started.IsZero() {
+		started = time.Now()
+	}
+	entry := harEntry{StartedDateTime: started.Format(time.RFC3339Nano)}
+
+	rawURL, headers, proto := r.URL, http.Header{}, "HTTP/1.1"
+	if r.RawRequest != nil {
+		rawURL, headers, proto = r.RawRequest.URL.String(), r.RawRequest.Header, r.RawRequest.Proto
+	}
+	u, err := url.Parse(rawURL)
+	query := []harNameValue{}
+	if err == nil {
+		values := u.Query()
+		for name := range values {
+			if redaction.matches(name) {
+				values.Set(name, redacted)
+			}
+		}
+		u.RawQuery = values.Encode()
+		rawURL = u.String()
+		for name, vs := range values {
+			for _, v := range vs {
+				query = append(query, harNameValue{Name: name, Value: v})
+			}
+		}
+	}
+
+	entry.Request = harRequest{
+		Method:      r.Method,
+		URL:         rawURL,
+		HTTPVersion: proto,
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(redactHeader(headers)),
+		QueryString: query,
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if data := harRequestBody(r, headers.Get("Content-Type"), redaction); data != nil {
+		entry.Request.PostData = data
+		entry.Request.BodySize = len(data.Text)
+	}
+	return entry
+}
+
+// harRequestBody returns the redacted body of a request, nil without one.
+func harRequestBody(r *resty.Request, mimeType string, redaction Redaction) *harPostData {
+	if len(r.FormData) > 0 {
+		data := &harPostData{MimeType: mimeType}
+		for name, vs := range r.FormData {
+			for _, v := range vs {
+				if redaction.matches(name) {
+					v = redacted
+				}
+				data.Params = append(data.Params, harNameValue{Name: name, Value: v})
+			}
+		}
+		return data
+	}
+
+	var body []byte
+	switch b := r.Body.(type) {
+	case nil, io.Reader:
+		return nil
+	case []byte:
+		decoded, err := decodeRequestBody(b, r.Header.Get("Content-Encoding"))
+		if err != nil {
+			return nil
+		}
+		body = decoded
+	case string:
+		body = []byte(b)
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			return nil
+		}
+		if mimeType == "" {
+			mimeType = "application/json"
+		}
+	}
+	return &harPostData{MimeType: mimeType, Text: redaction.redact(body)}
+}
+
+// harHeaders returns the headers as name-value pairs.
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, vs := range header {
+		for _, v := range vs {
+			headers = append(headers, harNameValue{Name: name, Value: v})
+		}
+	}
+	return headers
+}
+
+// harPhase returns the duration of a connection phase, -1 when it doesn't apply.
+func harPhase(d time.Duration, skipped bool) float64 {
+	if skipped {
+		return -1
+	}
+	return harMillis(max(d, 0))
+}
+
+func harMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// moduleVersion returns the version of this module in the build, "devel" when unknown.
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/Forty2Co/pocketbase" {
+			return dep.Version
+		}
+	}
+	return "devel"
+}
@@ -0,0 +1,129 @@
+package pocketbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHARRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/collections/_superusers/auth-with-password":
+			_, _ = fmt.Fprint(w, `{"token": "secret-token", "record": {"id": "a1", "email": "admin@example.com"}}`)
+		case "/api/collections/posts/records":
+			_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 30, "totalItems": 0, "totalPages": 0, "items": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"status": 404, "message": "Not found."}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := NewHARRecorder()
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithAdminEmailPassword("admin@example.com", "hunter22"), WithHARRecorder(recorder))
+	_, err := client.List("posts", ParamsList{Filters: "title != ''"})
+	require.NoError(t, err)
+	_, err = client.Send(context.Background(), Request{Path: "/api/files/posts/p1/a.png", Query: url.Values{"token": {"file-token"}, "thumb": {"100x100"}}})
+	require.Error(t, err)
+	require.Equal(t, 3, recorder.Len())
+
+	var buf bytes.Buffer
+	_, err = recorder.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "hunter22")
+	assert.NotContains(t, buf.String(), "secret-token")
+	assert.NotContains(t, buf.String(), "file-token")
+
+	var har struct {
+		Log struct {
+			Version string
+			Entries []struct {
+				Request struct {
+					Method      string
+					URL         string
+					Headers     []harNameValue
+					QueryString []harNameValue
+					PostData    *harPostData
+				}
+				Response struct {
+					Status  int
+					Content harContent
+				}
+				Timings harTimings
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+	assert.Equal(t, "1.2", har.Log.Version)
+	entries := har.Log.Entries
+	require.Len(t, entries, 3)
+
+	auth := entries[0]
+	assert.Equal(t, http.MethodPost, auth.Request.Method)
+	require.NotNil(t, auth.Request.PostData)
+	assert.JSONEq(t, `{"identity": "admin@example.com", "password": "REDACTED"}`, auth.Request.PostData.Text)
+	assert.JSONEq(t, `{"token": "REDACTED", "record": {"id": "a1", "email": "admin@example.com"}}`, auth.Response.Content.Text)
+
+	list := entries[1]
+	assert.Equal(t, srv.URL+"/api/collections/posts/records?filter=title+%21%3D+%27%27", list.Request.URL)
+	assert.Contains(t, list.Request.Headers, harNameValue{Name: "Authorization", Value: "REDACTED"})
+	assert.Nil(t, list.Request.PostData)
+	assert.Equal(t, http.StatusOK, list.Response.Status)
+	assert.GreaterOrEqual(t, list.Timings.Wait, 0.0)
+
+	file := entries[2]
+	assert.Equal(t, srv.URL+"/api/files/posts/p1/a.png?thumb=100x100&token=REDACTED", file.Request.URL)
+	assert.ElementsMatch(t, []harNameValue{{"thumb", "100x100"}, {"token", "REDACTED"}}, file.Request.QueryString)
+	assert.Equal(t, http.StatusNotFound, file.Response.Status)
+
+	name := filepath.Join(t.TempDir(), "pocketbase.har")
+	require.NoError(t, recorder.WriteFile(name))
+	recorder.Reset()
+	assert.Zero(t, recorder.Len())
+}
+
+func TestWithHARRecorder_Errors(t *testing.T) {
+	srv, _ := newFailingServer(t)
+	recorder := NewHARRecorder()
+	client := NewClient(srv.URL, WithRetry(1, time.Millisecond, time.Millisecond), WithHARRecorder(recorder))
+
+	_, err := client.List("posts", ParamsList{})
+	require.Error(t, err)
+	require.Equal(t, 1, recorder.Len())
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	assert.Zero(t, recorder.entries[0].Response.Status)
+	assert.NotEmpty(t, recorder.entries[0].Comment)
+}
+
+func TestWithHARRecorder_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	recorder := NewHARRecorder()
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithHARRecorder(recorder), WithTimingHook(func(Operation, Timing, error) {}))
+	_, err := client.List(migrations.PostsPublic, ParamsList{Size: 1})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = recorder.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), migrations.AdminEmailPassword+`"`)
+	assert.Contains(t, buf.String(), `"status": 200`)
+	assert.Contains(t, buf.String(), `"dns": -1`)
+}
@@ -0,0 +1,110 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// defaultHealthCooldown is how long requests fail fast after a failed health probe.
+	defaultHealthCooldown = 5 * time.Second
+	// healthProbeTimeout bounds the health probes.
+	healthProbeTimeout = 2 * time.Second
+)
+
+// ErrServerUnavailable is returned by the requests of a client with WithHealthGate while
+// the health check of the server fails.
+var ErrServerUnavailable = errors.New("server unavailable")
+
+// healthGate is the transport of a client with WithHealthGate. It probes the health check
+// when a request fails with a connection error, and fails the requests during the cooldown
+// of a failed probe without sending them. It is shared by the clones of the client.
+type healthGate struct {
+	url      string
+	cooldown time.Duration
+	next     http.RoundTripper
+
+	mu        sync.Mutex
+	downUntil time.Time
+	probes    singleflight.Group
+}
+
+// WithHealthGate probes /api/health when a request fails with a connection error, before it
+// is retried. While the probe fails, the request and the requests sent during the cooldown
+// fail with ErrServerUnavailable instead of being retried, so a down instance doesn't cause
+// retry storms. A zero cooldown defaults to 5s.
+func WithHealthGate(cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		if cooldown <= 0 {
+			cooldown = defaultHealthCooldown
+		}
+		c.client.SetTransport(&healthGate{
+			url:      c.url,
+			cooldown: cooldown,
+			next:     c.client.GetClient().Transport,
+		})
+	}
+}
+
+// withNext returns a gate of the same server sending the requests with the transport.
+func (g *healthGate) withNext(next http.RoundTripper) *healthGate {
+	return &healthGate{url: g.url, cooldown: g.cooldown, next: next}
+}
+
+func (g *healthGate) RoundTrip(r *http.Request) (*http.Response, error) {
+	if g.down() {
+		return nil, fmt.Errorf("[health] failed health check, err %w", ErrServerUnavailable)
+	}
+	resp, err := g.transport().RoundTrip(r)
+	if err == nil || r.Context().Err() != nil {
+		return resp, err
+	}
+	if !g.probe() {
+		return nil, fmt.Errorf("[health] failed health check, err %w, err %w", ErrServerUnavailable, err)
+	}
+	return resp, err
+}
+
+// down reports whether the requests fail fast after a failed probe.
+func (g *healthGate) down() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return time.Now().Before(g.downUntil)
+}
+
+// probe calls the health check, once for the concurrent failures, and reports whether it passed.
+func (g *healthGate) probe() bool {
+	healthy, _, _ := g.probes.Do("health", func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), healthProbeTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url+"/api/health", nil)
+		if err != nil {
+			return false, nil
+		}
+		resp, err := g.transport().RoundTrip(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil || resp.StatusCode >= 500 {
+			g.mu.Lock()
+			g.downUntil = time.Now().Add(g.cooldown)
+			g.mu.Unlock()
+			return false, nil
+		}
+		return true, nil
+	})
+	return healthy.(bool)
+}
+
+func (g *healthGate) transport() http.RoundTripper {
+	if g.next == nil {
+		return http.DefaultTransport
+	}
+	return g.next
+}
@@ -0,0 +1,71 @@
+package pocketbase
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the requests sent by path.
+type countingTransport struct {
+	requests, probes atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Path == "/api/health" {
+		t.probes.Add(1)
+	} else {
+		t.requests.Add(1)
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWithHealthGate(t *testing.T) {
+	t.Run("down server", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+
+		transport := &countingTransport{}
+		client := NewClient(srv.URL, WithRetry(3, time.Millisecond, time.Millisecond),
+			WithHealthGate(time.Minute), WithTransport(transport))
+
+		_, err := client.List("posts", ParamsList{})
+		assert.ErrorIs(t, err, ErrServerUnavailable)
+		assert.True(t, IsRetryable(err))
+		assert.EqualValues(t, 1, transport.requests.Load())
+		assert.EqualValues(t, 1, transport.probes.Load())
+
+		// the requests fail fast during the cooldown, clones included
+		_, err = client.Clone().List("posts", ParamsList{})
+		assert.ErrorIs(t, err, ErrServerUnavailable)
+		assert.EqualValues(t, 1, transport.requests.Load())
+		assert.EqualValues(t, 1, transport.probes.Load())
+	})
+
+	t.Run("healthy server", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/health" {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"code": 200, "message": "API is healthy.", "data": {}}`))
+				return
+			}
+			panic(http.ErrAbortHandler)
+		}))
+		t.Cleanup(srv.Close)
+
+		transport := &countingTransport{}
+		client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond),
+			WithTransport(transport), WithHealthGate(0))
+
+		_, err := client.List("posts", ParamsList{})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrServerUnavailable)
+		assert.EqualValues(t, 3, transport.requests.Load())
+		assert.EqualValues(t, 3, transport.probes.Load())
+	})
+}
@@ -0,0 +1,178 @@
+package pocketbase
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// hookRegistry holds the lifecycle hooks of the collections of a client, shared with its
+// clones, by collection name and record type. The hooks are replaced on registration, so
+// the running operations keep the hooks they started with.
+type hookRegistry struct {
+	mu    sync.Mutex
+	hooks map[hookKey]any
+}
+
+// hookKey identifies the hooks of a collection.
+type hookKey struct {
+	collection string
+	record     reflect.Type
+}
+
+// collectionHooks are the lifecycle hooks of a collection, applied in registration order.
+type collectionHooks[T any] struct {
+	beforeCreate []func(record *T) error
+	beforeUpdate []func(id string, record *T) error
+	afterCreate  []func(record T)
+	afterUpdate  []func(id string)
+	afterDelete  []func(id string)
+	afterList    []func(records []T) error
+}
+
+// BeforeCreate registers a hook called with the record before it is created by Create,
+// e.g. to derive or validate fields in one place. Returning an error cancels the create.
+//
+//	posts.BeforeCreate(func(p *Post) error {
+//		p.Slug = slugify(p.Title)
+//		return nil
+//	})
+//
+// The hooks belong to the client and the collection name, so they apply to the copies of
+// the collection, the other collections of the same name and record type created by
+// CollectionSet, and the clones of the client.
+func (c *Collection[T]) BeforeCreate(hook func(record *T) error) *Collection[T] {
+	return c.registerHook(func(h *collectionHooks[T]) { h.beforeCreate = append(h.beforeCreate, hook) })
+}
+
+// BeforeUpdate registers a hook called with the record before it is updated by Update,
+// UpdateIfUnchanged and DiffUpdate, the after state for the latter. Returning an error
+// cancels the update.
+func (c *Collection[T]) BeforeUpdate(hook func(id string, record *T) error) *Collection[T] {
+	return c.registerHook(func(h *collectionHooks[T]) { h.beforeUpdate = append(h.beforeUpdate, hook) })
+}
+
+// AfterCreate registers a hook called with the record returned by a successful Create.
+func (c *Collection[T]) AfterCreate(hook func(record T)) *Collection[T] {
+	return c.registerHook(func(h *collectionHooks[T]) { h.afterCreate = append(h.afterCreate, hook) })
+}
+
+// AfterUpdate registers a hook called with the id of the record after a successful Update,
+// UpdateIfUnchanged or DiffUpdate.
+func (c *Collection[T]) AfterUpdate(hook func(id string)) *Collection[T] {
+	return c.registerHook(func(h *collectionHooks[T]) { h.afterUpdate = append(h.afterUpdate, hook) })
+}
+
+// AfterDelete registers a hook called with the id of the record after a successful Delete,
+// e.g. to invalidate a cache:
+//
+//	posts.AfterDelete(func(id string) { cache.Delete(id) })
+func (c *Collection[T]) AfterDelete(hook func(id string)) *Collection[T] {
+	return c.registerHook(func(h *collectionHooks[T]) { h.afterDelete = append(h.afterDelete, hook) })
+}
+
+// AfterList registers a hook post-processing the records of every page read by the lists
+// of the collection, i.e. List, FullList, FullListPartial, Keyset, Paginate, Query and
+// ExportJSON, in place. Returning an error fails the list.
+func (c *Collection[T]) AfterList(hook func(records []T) error) *Collection[T] {
+	return c.registerHook(func(h *collectionHooks[T]) { h.afterList = append(h.afterList, hook) })
+}
+
+// collectionHooks returns the hooks of the collection, nil without hooks.
+func (c *Collection[T]) collectionHooks() *collectionHooks[T] {
+	r := c.Client.hooks
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, _ := r.hooks[hookKey{c.Name, reflect.TypeFor[T]()}].(*collectionHooks[T])
+	return h
+}
+
+// registerHook stores a copy of the hooks of the collection updated by register.
+func (c *Collection[T]) registerHook(register func(h *collectionHooks[T])) *Collection[T] {
+	r := c.Client.hooks
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := hookKey{c.Name, reflect.TypeFor[T]()}
+	var h collectionHooks[T]
+	if current, ok := r.hooks[key].(*collectionHooks[T]); ok {
+		h = *current
+	}
+	register(&h)
+	if r.hooks == nil {
+		r.hooks = map[hookKey]any{}
+	}
+	r.hooks[key] = &h
+	return c
+}
+
+// runBeforeCreate runs the before create hooks on the record.
+func (h *collectionHooks[T]) runBeforeCreate(record *T) error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range h.beforeCreate {
+		if err := hook(record); err != nil {
+			return fmt.Errorf("[create] hook canceled the create, err %w", err)
+		}
+	}
+	return nil
+}
+
+// runBeforeUpdate runs the before update hooks on the record.
+func (h *collectionHooks[T]) runBeforeUpdate(id string, record *T) error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range h.beforeUpdate {
+		if err := hook(id, record); err != nil {
+			return fmt.Errorf("[update] hook canceled the update, err %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterCreate runs the after create hooks on the created record.
+func (h *collectionHooks[T]) runAfterCreate(record T) {
+	if h == nil {
+		return
+	}
+	for _, hook := range h.afterCreate {
+		hook(record)
+	}
+}
+
+// runAfterUpdate runs the after update hooks with the id of the updated record.
+func (h *collectionHooks[T]) runAfterUpdate(id string) {
+	if h == nil {
+		return
+	}
+	for _, hook := range h.afterUpdate {
+		hook(id)
+	}
+}
+
+// runAfterDelete runs the after delete hooks with the id of the deleted record.
+func (h *collectionHooks[T]) runAfterDelete(id string) {
+	if h == nil {
+		return
+	}
+	for _, hook := range h.afterDelete {
+		hook(id)
+	}
+}
+
+// runAfterList runs the after list hooks on the records of a page.
+func (h *collectionHooks[T]) runAfterList(records []T) error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range h.afterList {
+		if err := hook(records); err != nil {
+			return fmt.Errorf("[list] hook failed, err %w", err)
+		}
+	}
+	return nil
+}
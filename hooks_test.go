@@ -0,0 +1,156 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_Hooks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, r.Method+" "+string(body))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write(body)
+		case http.MethodPatch:
+			_, _ = fmt.Fprint(w, `{}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 30, "totalItems": 2, "totalPages": 1, "items": [{"id": "p1", "title": "a"}, {"id": "p2", "title": "b"}]}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Title string `json:"title"`
+		Slug  string `json:"slug,omitempty"`
+	}
+	var events []string
+	posts := CollectionSet[post](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts").
+		BeforeCreate(func(p *post) error {
+			if p.Title == "" {
+				return errors.New("missing title")
+			}
+			p.Slug = strings.ToLower(p.Title)
+			return nil
+		}).
+		BeforeUpdate(func(id string, p *post) error {
+			p.Slug = strings.ToLower(p.Title)
+			return nil
+		}).
+		AfterCreate(func(p post) { events = append(events, "created "+p.Slug) }).
+		AfterUpdate(func(id string) { events = append(events, "updated "+id) }).
+		AfterDelete(func(id string) { events = append(events, "deleted "+id) }).
+		AfterList(func(records []post) error {
+			for i := range records {
+				records[i].Title = strings.ToUpper(records[i].Title)
+			}
+			return nil
+		})
+
+	created, err := posts.Create(post{Title: "Hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", created.Record.Slug)
+	require.NoError(t, posts.Update("p1", post{Title: "World"}))
+	require.NoError(t, posts.DiffUpdate("p1", post{Title: "World"}, post{Title: "Again"}))
+	require.NoError(t, posts.Delete("p1"))
+
+	_, err = posts.Create(post{})
+	assert.ErrorContains(t, err, "missing title")
+
+	list, err := posts.List(ParamsList{})
+	require.NoError(t, err)
+	assert.Equal(t, []post{{ID: "p1", Title: "A"}, {ID: "p2", Title: "B"}}, list.Items)
+	all, err := posts.FullList(ParamsList{})
+	require.NoError(t, err)
+	assert.Equal(t, "A", all.Items[0].Title)
+	for record, err := range posts.Keyset(ParamsList{}, "") {
+		require.NoError(t, err)
+		assert.Equal(t, "A", record.Title)
+		break
+	}
+
+	assert.Equal(t, []string{"created hello", "updated p1", "updated p1", "deleted p1"}, events)
+	assert.Equal(t, `POST {"title":"Hello","slug":"hello"}`, bodies[0])
+	assert.Equal(t, `PATCH {"title":"World","slug":"world"}`, bodies[1])
+	var patch map[string]any
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(bodies[2], "PATCH ")), &patch))
+	assert.Equal(t, map[string]any{"slug": "again", "title": "Again"}, patch)
+	assert.Len(t, bodies, 7, "the canceled create isn't sent")
+
+	// a failing hook fails the list
+	posts.AfterList(func([]post) error { return errors.New("boom") })
+	_, err = posts.List(ParamsList{})
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestCollection_Hooks_Shared(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	type post struct {
+		ID string `json:"id"`
+	}
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	posts := CollectionSet[post](client, "posts")
+	copied := *posts
+	literal := Collection[post]{client, "posts", client.url + "/api/collections/posts"}
+	var deleted []string
+	posts.AfterDelete(func(id string) { deleted = append(deleted, id) })
+
+	require.NoError(t, copied.Delete("copy"))
+	require.NoError(t, literal.Delete("literal"))
+	require.NoError(t, CollectionSet[post](client, "posts").Delete("set"))
+	require.NoError(t, CollectionSet[post](client.Clone(), "posts").Delete("clone"))
+	// other collections and record types have their own hooks
+	require.NoError(t, CollectionSet[post](client, "comments").Delete("comment"))
+	require.NoError(t, CollectionSet[map[string]any](client, "posts").Delete("map"))
+	require.NoError(t, CollectionSet[post](NewClient(srv.URL), "posts").Delete("other client"))
+	assert.Equal(t, []string{"copy", "literal", "set", "clone"}, deleted)
+}
+
+func TestCollection_Hooks_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	deleted := map[string]bool{}
+	posts := CollectionSet[post](client, migrations.PostsPublic).
+		BeforeCreate(func(p *post) error {
+			p.Field = "hooked-" + p.Field
+			return nil
+		}).
+		AfterDelete(func(id string) { deleted[id] = true })
+
+	created, err := posts.Create(post{Field: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "hooked-test", created.Record.Field)
+	require.NoError(t, posts.Delete(created.ID))
+	assert.True(t, deleted[created.ID])
+}
@@ -0,0 +1,301 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// defaultCheckpointEvery writes the checkpoint after every imported record.
+	defaultCheckpointEvery = 1
+	// defaultMaxImportPause caps the pauses of the importer.
+	defaultMaxImportPause = time.Minute
+	// minImportBackoff is the first pause after a 429 response without reset time.
+	minImportBackoff = 100 * time.Millisecond
+)
+
+type (
+	// ImportOptions configures Import.
+	ImportOptions struct {
+		// Checkpoint is the file storing the number of records imported so far. An import
+		// with an existing checkpoint skips these records of the source, which must yield
+		// the same records in the same order, to resume an interrupted import. Empty disables it.
+		Checkpoint string
+		// CheckpointEvery is the number of imported records between two writes of the
+		// checkpoint, 1 when 0. A larger value writes less often, but a run resumed after a
+		// crash creates again up to CheckpointEvery-1 records already imported.
+		CheckpointEvery int
+		// MaxPause caps the pauses when the server limits the rate, 1 minute when 0.
+		MaxPause time.Duration
+		// OnError is called when a record fails with another error than a rate limit, e.g. a
+		// validation error, with its index in the source. The record is skipped when it returns
+		// nil, and the import stops with the returned error otherwise. A nil OnError stops it.
+		OnError func(index int, err error) error
+	}
+
+	// ImportResult is the outcome of an Import.
+	ImportResult struct {
+		// Resumed is the number of records skipped as imported by a previous run.
+		Resumed int
+		// Imported is the number of records imported by this run.
+		Imported int
+		// Skipped is the number of failed records skipped by OnError.
+		Skipped int
+		// RateLimited is the number of 429 responses, whose records were sent again.
+		RateLimited int
+	}
+
+	// importCheckpoint is the content of a checkpoint file.
+	importCheckpoint struct {
+		// Next is the index of the next record of the source to import.
+		Next int `json:"next"`
+	}
+
+	// importPacer paces the requests of an import by the rate limits of the server.
+	importPacer struct {
+		mu       sync.Mutex
+		delay    time.Duration
+		maxPause time.Duration
+		// header is the header of the last response.
+		header http.Header
+		now    func() time.Time
+	}
+)
+
+// Import creates the records of the source in the collection one by one, e.g. for imports of
+// millions of records running unattended:
+//
+//	result, err := pocketbase.Import(ctx, collection, slices.Values(posts), pocketbase.ImportOptions{
+//		Checkpoint: "posts.checkpoint",
+//	})
+//
+// The importer monitors the 429 responses and the X-RateLimit-Remaining, X-RateLimit-Reset
+// and Retry-After headers, e.g. of a proxy: it pauses until the reset of an exhausted limit,
+// spreads the remaining requests until the reset, and backs off exponentially on the 429
+// responses without reset time, speeding up again while the requests succeed. The rate-limited
+// records are sent again. The checkpoint is written after every record and when Import returns,
+// so a new run resumes after the last imported record. The import is at-least-once: a crash
+// between the creation of a record and the write of the checkpoint creates the record again,
+// so give the records their ids to make the duplicate fail instead, e.g. with OnError skipping
+// the ErrInvalidResponse of an existing id.
+func Import[T any](ctx context.Context, c *Collection[T], source iter.Seq[T], opts ImportOptions) (ImportResult, error) {
+	return importSource(ctx, c.Client, source, opts, func(client *Client, record T) error {
+		collection := &Collection[T]{Client: client, Name: c.Name, BaseCollectionPath: c.BaseCollectionPath}
+		_, err := collection.Create(record)
+		return err
+	})
+}
+
+// importSource imports the items of the source with create, which sends its requests with
+// the client given to it, pacing them by the rate limits and checkpointing the progress.
+func importSource[S any](ctx context.Context, c *Client, source iter.Seq[S], opts ImportOptions, create func(client *Client, item S) error) (ImportResult, error) {
+	var result ImportResult
+	if opts.CheckpointEvery <= 0 {
+		opts.CheckpointEvery = defaultCheckpointEvery
+	}
+	if opts.MaxPause <= 0 {
+		opts.MaxPause = defaultMaxImportPause
+	}
+
+	checkpoint, err := readCheckpoint(opts.Checkpoint)
+	if err != nil {
+		return result, err
+	}
+
+	// the importer reads the headers of the responses with a clone of the client
+	pacer := &importPacer{maxPause: opts.MaxPause, now: time.Now}
+	client := c.Clone()
+	client.client.OnAfterResponse(pacer.observe)
+
+	save := func() error {
+		return writeCheckpoint(opts.Checkpoint, checkpoint)
+	}
+
+	index := -1
+	for item := range source {
+		index++
+		if index < checkpoint.Next {
+			result.Resumed++
+			continue
+		}
+
+		for {
+			if err := pacer.wait(ctx); err != nil {
+				return result, errors.Join(err, save())
+			}
+			err := create(client, item)
+			if err == nil {
+				pacer.succeeded()
+				result.Imported++
+				break
+			}
+			var aerr *APIError
+			if errors.As(err, &aerr) && aerr.StatusCode == http.StatusTooManyRequests {
+				pacer.limited(aerr.Header)
+				result.RateLimited++
+				continue
+			}
+			if opts.OnError != nil {
+				err = opts.OnError(index, err)
+			}
+			if err != nil {
+				return result, errors.Join(fmt.Errorf("[import] can't import record %d, err %w", index, err), save())
+			}
+			result.Skipped++
+			break
+		}
+
+		checkpoint.Next = index + 1
+		if (result.Imported+result.Skipped)%opts.CheckpointEvery == 0 {
+			if err := save(); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, save()
+}
+
+// readCheckpoint reads the checkpoint file, empty when there is none.
+func readCheckpoint(path string) (importCheckpoint, error) {
+	var checkpoint importCheckpoint
+	if path == "" {
+		return checkpoint, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, fmt.Errorf("[import] can't read checkpoint, err %w", err)
+	}
+	if err := json.Unmarshal(raw, &checkpoint); err != nil {
+		return checkpoint, fmt.Errorf("[import] can't unmarshal checkpoint %s, err %w", path, err)
+	}
+	return checkpoint, nil
+}
+
+// writeCheckpoint replaces the checkpoint file atomically, so an interrupted write keeps the
+// previous checkpoint.
+func writeCheckpoint(path string, checkpoint importCheckpoint) error {
+	if path == "" {
+		return nil
+	}
+	raw, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("[import] can't write checkpoint, err %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("[import] can't write checkpoint, err %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("[import] can't write checkpoint, err %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("[import] can't write checkpoint, err %w", err)
+	}
+	return nil
+}
+
+// observe is a response middleware keeping the header of the last response.
+func (p *importPacer) observe(_ *resty.Client, resp *resty.Response) error {
+	p.mu.Lock()
+	p.header = resp.Header()
+	p.mu.Unlock()
+	return nil
+}
+
+// wait waits for the delay before the next request.
+func (p *importPacer) wait(ctx context.Context) error {
+	p.mu.Lock()
+	delay := p.delay
+	p.mu.Unlock()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// succeeded adapts the delay to the rate limit headers of the last response: the requests
+// are paused until the reset of an exhausted limit, and spread until the reset otherwise.
+// Without headers, the delay decreases after every success.
+func (p *importPacer) succeeded() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remaining, errRemaining := strconv.Atoi(p.header.Get("X-RateLimit-Remaining"))
+	reset, okReset := p.resetIn(p.header)
+	switch {
+	case errRemaining == nil && okReset && remaining <= 0:
+		p.delay = reset
+	case errRemaining == nil && okReset:
+		p.delay = reset / time.Duration(remaining+1)
+	default:
+		p.delay = p.delay * 3 / 4
+		if p.delay < time.Millisecond {
+			p.delay = 0
+		}
+	}
+	p.delay = min(p.delay, p.maxPause)
+}
+
+// limited pauses until the reset of the limit of a 429 response, or doubles the delay.
+func (p *importPacer) limited(header http.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if reset, ok := p.resetIn(header); ok {
+		p.delay = reset
+	} else {
+		p.delay = max(2*p.delay, minImportBackoff)
+	}
+	p.delay = min(p.delay, p.maxPause)
+}
+
+// resetIn returns the time until the reset of the rate limit, from the Retry-After header in
+// seconds or HTTP date, or the X-RateLimit-Reset header in seconds or Unix time.
+func (p *importPacer) resetIn(header http.Header) (time.Duration, bool) {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(p.now()), 0), true
+		}
+	}
+	if value := header.Get("X-RateLimit-Reset"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			// large values are Unix times, e.g. 1700000000, small ones durations
+			if seconds > 1e9 {
+				return max(time.Unix(seconds, 0).Sub(p.now()), 0), true
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
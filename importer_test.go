@@ -0,0 +1,130 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	type post struct {
+		Field string `json:"field"`
+	}
+	var requests atomic.Int32
+	var created []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body post
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch n := requests.Add(1); {
+		case n == 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(w, `{"status": 429, "message": "Too Many Requests.", "data": {}}`)
+		case n == 3:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(w, `{"status": 429, "message": "Too Many Requests.", "data": {}}`)
+		case body.Field == "invalid":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"status": 400, "message": "Failed to create record.", "data": {"field": {"code": "validation_invalid", "message": "Invalid."}}}`)
+		default:
+			created = append(created, body.Field)
+			_, _ = fmt.Fprintf(w, `{"id": "id%d", "field": %q}`, n, body.Field)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	collection := CollectionSet[post](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+	source := slices.Values([]post{{"a"}, {"b"}, {"c"}, {"invalid"}, {"d"}})
+	checkpoint := filepath.Join(t.TempDir(), "posts.checkpoint")
+	ctx := context.Background()
+
+	// the invalid record stops the first run
+	result, err := Import(ctx, collection, source, ImportOptions{Checkpoint: checkpoint, CheckpointEvery: 2})
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+	assert.ErrorContains(t, err, "can't import record 3")
+	assert.Equal(t, ImportResult{Imported: 3, RateLimited: 2}, result)
+	raw, err := os.ReadFile(checkpoint)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"next": 3}`, string(raw))
+
+	// the second run resumes after the imported records, skipping the invalid one
+	var failed []int
+	result, err = Import(ctx, collection, source, ImportOptions{
+		Checkpoint: checkpoint,
+		OnError: func(index int, err error) error {
+			failed = append(failed, index)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Resumed: 3, Imported: 1, Skipped: 1}, result)
+	assert.Equal(t, []int{3}, failed)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, created)
+	raw, err = os.ReadFile(checkpoint)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"next": 5}`, string(raw))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = Import(cancelled, collection, slices.Values([]post{{"e"}}), ImportOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestImport_CheckpointEveryRecord(t *testing.T) {
+	type post struct {
+		Field string `json:"field"`
+	}
+	checkpoint := filepath.Join(t.TempDir(), "posts.checkpoint")
+	var saved []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the checkpoint on disk when the next record is sent, as a crash would leave it
+		raw, _ := os.ReadFile(checkpoint)
+		saved = append(saved, string(raw))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id": "id"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	collection := CollectionSet[post](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+	source := slices.Values([]post{{"a"}, {"b"}, {"c"}})
+	result, err := Import(context.Background(), collection, source, ImportOptions{Checkpoint: checkpoint})
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: 3}, result)
+	assert.Equal(t, []string{"", `{"next":1}`, `{"next":2}`}, saved)
+}
+
+func TestImportPacer(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	p := &importPacer{maxPause: time.Minute, now: func() time.Time { return now }}
+
+	p.limited(http.Header{})
+	assert.Equal(t, minImportBackoff, p.delay)
+	p.limited(http.Header{})
+	assert.Equal(t, 2*minImportBackoff, p.delay)
+	p.succeeded()
+	assert.Equal(t, 150*time.Millisecond, p.delay)
+
+	p.limited(http.Header{"Retry-After": {now.Add(5 * time.Second).UTC().Format(http.TimeFormat)}})
+	assert.Equal(t, 5*time.Second, p.delay)
+	p.limited(http.Header{"X-Ratelimit-Reset": {"1700000120"}})
+	assert.Equal(t, time.Minute, p.delay, "capped by the max pause")
+
+	p.header = http.Header{"X-Ratelimit-Remaining": {"9"}, "X-Ratelimit-Reset": {"10"}}
+	p.succeeded()
+	assert.Equal(t, time.Second, p.delay)
+	p.header = http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"3"}}
+	p.succeeded()
+	assert.Equal(t, 3*time.Second, p.delay)
+}
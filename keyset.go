@@ -0,0 +1,132 @@
+package pocketbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// Keyset returns an iterator over all records matching params using keyset (cursor)
+// pagination: the records are sorted by field and id, and every page is filtered to
+// the records after the last one seen. Unlike offset pagination, records inserted
+// during the scan don't shift the pages, so every record is seen exactly once,
+// e.g. during long exports.
+//
+// The field defaults to "created"; params.Sort and params.Page are ignored. When
+// params.Fields is set, it must include the field and id. A text value of the field ending
+// with a backslash can't be filtered, and ends the iteration with an error.
+//
+//	for record, err := range collection.Keyset(pocketbase.ParamsList{Size: 200}, "") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Collection[T]) Keyset(params ParamsList, field string) iter.Seq2[T, error] {
+	if field == "" {
+		field = "created"
+	}
+	if params.Size < 1 {
+		params.Size = 500
+	}
+	params.Page = 1
+	params.Sort = field + ",id"
+	params = withRelations(params, relationType(reflect.TypeFor[T]()))
+	filter := params.Filters
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		var last map[string]json.RawMessage
+		for {
+			keyset, err := keysetFilter(filter, field, last)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			params.Filters = keyset
+
+			var r ResponseList[json.RawMessage]
+			if err := c.Client.list(context.Background(), c.Name, params, &r); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items := make([]T, len(r.Items))
+			for i, raw := range r.Items {
+				if err := c.codec.decode(raw, &items[i]); err != nil {
+					yield(zero, fmt.Errorf("[list] can't unmarshal record, err %w", err))
+					return
+				}
+				if err := json.Unmarshal(raw, &last); err != nil {
+					yield(zero, fmt.Errorf("[list] can't unmarshal record, err %w", err))
+					return
+				}
+			}
+			if err := c.collectionHooks().runAfterList(items); err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(r.Items) < params.Size {
+				return
+			}
+		}
+	}
+}
+
+// errTrailingBackslash is returned when a sort value ends with a backslash, which a filter
+// string can't express.
+var errTrailingBackslash = errors.New("a filter string can't end with a backslash")
+
+// keysetFilter returns the filter for the records after the last seen record.
+func keysetFilter(filter, field string, last map[string]json.RawMessage) (string, error) {
+	if last == nil {
+		return filter, nil
+	}
+	value, err := keysetLiteral(last[field])
+	if err != nil {
+		return "", fmt.Errorf("[list] can't filter the records after %s %s, err %w", field, last[field], err)
+	}
+	id, err := keysetLiteral(last["id"])
+	if err != nil {
+		return "", fmt.Errorf("[list] can't filter the records after id %s, err %w", last["id"], err)
+	}
+	keyset := fmt.Sprintf("(%s > %s || (%s = %s && id > %s))", field, value, field, value, id)
+	if filter == "" {
+		return keyset, nil
+	}
+	return "(" + filter + ") && " + keyset, nil
+}
+
+// keysetLiteral returns the filter literal of a JSON value of the last seen record, the
+// empty string when it is missing.
+func keysetLiteral(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "''", nil
+	}
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case string:
+		if strings.HasSuffix(v, `\`) {
+			return "", errTrailingBackslash
+		}
+	}
+	return filterLiteral(value), nil
+}
@@ -0,0 +1,114 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysetFilter(t *testing.T) {
+	last := map[string]json.RawMessage{
+		"id":      json.RawMessage(`"abc"`),
+		"created": json.RawMessage(`"2024-01-01 10:00:00.000Z"`),
+		"count":   json.RawMessage(`42`),
+		"title":   json.RawMessage(`"C:\\dir \"a\" \u0026 it's\n"`),
+		"path":    json.RawMessage(`"C:\\"`),
+	}
+	filter, err := keysetFilter("field='x'", "created", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "field='x'", filter)
+
+	filter, err = keysetFilter("", "created", last)
+	require.NoError(t, err)
+	assert.Equal(t, `(created > '2024-01-01 10:00:00.000Z' || (created = '2024-01-01 10:00:00.000Z' && id > 'abc'))`, filter)
+
+	filter, err = keysetFilter("field='x'", "count", last)
+	require.NoError(t, err)
+	assert.Equal(t, `(field='x') && (count > 42 || (count = 42 && id > 'abc'))`, filter)
+
+	filter, err = keysetFilter("", "title", last)
+	require.NoError(t, err)
+	assert.Equal(t, "(title > 'C:\\dir \"a\" & it\\'s\n' || (title = 'C:\\dir \"a\" & it\\'s\n' && id > 'abc'))", filter,
+		"the JSON escapes are decoded")
+	groups, err := fexpr.Parse(filter)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	or := groups[0].Item.([]fexpr.ExprGroup)
+	require.Len(t, or, 2)
+	assert.Equal(t, "C:\\dir \"a\" & it's\n", or[0].Item.(fexpr.Expr).Right.Literal)
+
+	_, err = keysetFilter("", "path", last)
+	assert.ErrorIs(t, err, errTrailingBackslash)
+}
+
+func TestCollection_Keyset(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+
+	field := "keyset_" + time.Now().Format(time.StampMilli)
+	for range 3 {
+		_, err := collection.Create(map[string]any{"field": field})
+		require.NoError(t, err)
+	}
+
+	seen := map[any]int{}
+	for record, err := range collection.Keyset(ParamsList{Size: 2, Filters: "field='" + field + "'"}, "") {
+		require.NoError(t, err)
+		if len(seen) == 0 {
+			// inserted mid-scan, must not shift the pages
+			_, err := collection.Create(map[string]any{"field": field})
+			require.NoError(t, err)
+		}
+		seen[record["id"]]++
+	}
+	assert.Len(t, seen, 4)
+	for id, n := range seen {
+		assert.Equal(t, 1, n, id)
+	}
+
+	t.Run("break", func(t *testing.T) {
+		n := 0
+		for _, err := range collection.Keyset(ParamsList{Size: 2, Filters: "field='" + field + "'"}, "id") {
+			require.NoError(t, err)
+			n++
+			break
+		}
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("text field", func(t *testing.T) {
+		marker := "keysettext" + time.Now().Format("150405000")
+		values := []string{`a\b`, `a\\b`, "a&b", "a<b", "a>b", `a"b`, "a'b", "a\nb", "a\tb", "a"}
+		for _, value := range values {
+			created, err := collection.Create(map[string]any{"field": marker + value})
+			require.NoError(t, err)
+			defer func() {
+				_ = collection.Delete(created.ID)
+			}()
+		}
+
+		seen := map[any]int{}
+		for record, err := range collection.Keyset(ParamsList{Size: 1, Filters: Filter("field ~ {:marker}", map[string]any{"marker": marker})}, "field") {
+			require.NoError(t, err)
+			seen[record["field"]]++
+		}
+		assert.Len(t, seen, len(values))
+		for field, n := range seen {
+			assert.Equal(t, 1, n, field)
+		}
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		for _, err := range collection.Keyset(ParamsList{}, "unknown") {
+			assert.ErrorIs(t, err, ErrInvalidResponse)
+		}
+	})
+}
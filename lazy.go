@@ -0,0 +1,157 @@
+package pocketbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Relation is a relation field holding the ids of the related records, fetched on demand
+// instead of expanded with every record:
+//
+//	type Post struct {
+//		ID     string                    `json:"id"`
+//		Author pocketbase.Relation[User] `json:"author"`
+//	}
+//
+//	authors, err := post.Author.Fetch(ctx, users)
+//
+// It decodes the ids of the single and multiple relation fields, and encodes a single id
+// as a string and the others as a list. The fetched records are kept by the relation, and
+// the relations of a list of records are fetched at once by PrefetchRelations.
+type Relation[T any] struct {
+	IDs []string
+
+	records []T
+	loaded  bool
+}
+
+// RelationTo returns a relation to the records of the ids.
+func RelationTo[T any](ids ...string) Relation[T] {
+	return Relation[T]{IDs: ids}
+}
+
+// ID returns the id of a single relation, the first id of a multiple one, and an empty
+// string without id.
+func (r Relation[T]) ID() string {
+	if len(r.IDs) == 0 {
+		return ""
+	}
+	return r.IDs[0]
+}
+
+// Loaded returns the fetched records, and whether they were fetched.
+func (r Relation[T]) Loaded() ([]T, bool) {
+	return r.records, r.loaded
+}
+
+// Fetch returns the related records from the collection, in the order of the ids and
+// without the ones not found, e.g. deleted; the records already fetched are returned
+// without request. T must hold the id of the records.
+func (r *Relation[T]) Fetch(ctx context.Context, collection *Collection[T]) ([]T, error) {
+	if r.loaded {
+		return r.records, nil
+	}
+	related, err := fetchRelated(ctx, collection, r.IDs)
+	if err != nil {
+		return nil, err
+	}
+	r.load(related)
+	return r.records, nil
+}
+
+// PrefetchRelations fetches the related records of a relation of the records at once, in
+// chunks of ids, instead of a request per record:
+//
+//	err := pocketbase.PrefetchRelations(ctx, users, posts, func(p *Post) *pocketbase.Relation[User] { return &p.Author })
+//	authors, _ := posts[0].Author.Loaded()
+func PrefetchRelations[R, T any](ctx context.Context, collection *Collection[T], records []R, relation func(*R) *Relation[T]) error {
+	var ids []string
+	for i := range records {
+		ids = append(ids, relation(&records[i]).IDs...)
+	}
+	related, err := fetchRelated(ctx, collection, ids)
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		relation(&records[i]).load(related)
+	}
+	return nil
+}
+
+// load keeps the related records of the ids.
+func (r *Relation[T]) load(related map[string]T) {
+	r.records = make([]T, 0, len(r.IDs))
+	for _, id := range r.IDs {
+		if record, ok := related[id]; ok {
+			r.records = append(r.records, record)
+		}
+	}
+	r.loaded = true
+}
+
+// MarshalJSON encodes a single id as a string, and the others as a list.
+func (r Relation[T]) MarshalJSON() ([]byte, error) {
+	if len(r.IDs) == 1 {
+		return json.Marshal(r.IDs[0])
+	}
+	return json.Marshal(append([]string{}, r.IDs...))
+}
+
+// UnmarshalJSON decodes the id of a single relation field, or the ids of a multiple one.
+func (r *Relation[T]) UnmarshalJSON(data []byte) error {
+	*r = Relation[T]{}
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		return json.Unmarshal(data, &r.IDs)
+	}
+	var id string
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	if id != "" {
+		r.IDs = []string{id}
+	}
+	return nil
+}
+
+// fetchRelated returns the records of the ids from the collection, by id.
+func fetchRelated[T any](ctx context.Context, collection *Collection[T], ids []string) (map[string]T, error) {
+	items, err := ListIn(ctx, collection, "id", ids, ParamsList{})
+	if err != nil {
+		return nil, err
+	}
+	related := make(map[string]T, len(items))
+	for _, item := range items {
+		id, err := recordID(collection.codec, item)
+		if err != nil {
+			return nil, err
+		}
+		related[id] = item
+	}
+	return related, nil
+}
+
+// recordID returns the id of a decoded record, as encoded by the codec.
+func recordID(codec *recordCodec, record any) (string, error) {
+	body, err := codec.encode(record)
+	if err != nil {
+		return "", fmt.Errorf("[relation] can't encode record, err %w", err)
+	}
+	raw, err := codec.marshalJSON(body)
+	if err != nil {
+		return "", fmt.Errorf("[relation] can't encode record, err %w", err)
+	}
+	var key struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &key); err != nil || key.ID == "" {
+		return "", fmt.Errorf("[relation] the records of %T don't hold their id, err %w", record, ErrInvalidResponse)
+	}
+	return key.ID, nil
+}
@@ -0,0 +1,104 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelation(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var items []string
+		for _, m := range regexp.MustCompile(`id = '(\w+)'`).FindAllStringSubmatch(r.URL.Query().Get("filter"), -1) {
+			if m[1] != "gone" {
+				items = append(items, fmt.Sprintf(`{"id": %q, "name": "user %s"}`, m[1], m[1]))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 30, "totalItems": %d, "totalPages": 1, "items": [%s]}`, len(items), strings.Join(items, ","))
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+
+	type user struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	type post struct {
+		ID       string         `json:"id"`
+		Author   Relation[user] `json:"author"`
+		Editors  Relation[user] `json:"editors"`
+		Reviewer Relation[user] `json:"reviewer"`
+	}
+	var posts []post
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id": "p1", "author": "u1", "editors": ["u2", "gone", "u1"], "reviewer": ""},
+		{"id": "p2", "author": "u2", "editors": [], "reviewer": null}
+	]`), &posts))
+	assert.Equal(t, RelationTo[user]("u1"), posts[0].Author)
+	assert.Equal(t, "u2", posts[0].Editors.ID())
+	assert.Empty(t, posts[0].Reviewer.ID())
+	raw, err := json.Marshal(posts[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "p1", "author": "u1", "editors": ["u2", "gone", "u1"], "reviewer": []}`, string(raw))
+
+	users := CollectionSet[user](client, "users")
+	editors, err := posts[0].Editors.Fetch(context.Background(), users)
+	require.NoError(t, err)
+	assert.Equal(t, []user{{ID: "u2", Name: "user u2"}, {ID: "u1", Name: "user u1"}}, editors,
+		"the records are in the order of the ids, without the missing ones")
+	_, err = posts[0].Editors.Fetch(context.Background(), users)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load(), "the fetched records are kept")
+
+	requests.Store(0)
+	require.NoError(t, PrefetchRelations(context.Background(), users, posts, func(p *post) *Relation[user] { return &p.Author }))
+	assert.Equal(t, int32(1), requests.Load(), "the relations are fetched at once")
+	authors, ok := posts[1].Author.Loaded()
+	assert.True(t, ok)
+	assert.Equal(t, []user{{ID: "u2", Name: "user u2"}}, authors)
+
+	type anonymous struct {
+		Name string `json:"name"`
+	}
+	_, err = (&Relation[anonymous]{IDs: []string{"u1"}}).Fetch(context.Background(), CollectionSet[anonymous](client, "users"))
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestRelation_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Field string `json:"field"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+
+	var ids []string
+	for _, field := range []string{"lazy 1", "lazy 2"} {
+		created, err := posts.Create(post{Field: field})
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+		defer func() {
+			_ = posts.Delete(created.ID)
+		}()
+	}
+	relation := RelationTo[post](ids[1], ids[0])
+	related, err := relation.Fetch(context.Background(), posts)
+	require.NoError(t, err)
+	assert.Equal(t, []post{{ID: ids[1], Field: "lazy 2"}, {ID: ids[0], Field: "lazy 1"}}, related)
+}
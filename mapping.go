@@ -0,0 +1,245 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// mappedField is a struct field mapped to a PocketBase field.
+type mappedField struct {
+	index []int
+	// key is the name of the PocketBase field.
+	key       string
+	omitEmpty bool
+	omitZero  bool
+}
+
+// recordCodec decodes and encodes the records of struct types with their relation fields
+// and field mapping.
+type recordCodec struct {
+	// naming maps the Go names of the fields without json or pb name, nil to keep them.
+	naming NamingStrategy
+	// mappings caches the mapped fields by struct type, nil for the types decoded and
+	// encoded by their JSON tags.
+	mappings sync.Map
+	// strict fails the decoding of the records with fields unknown to their struct type.
+	strict bool
+	// known caches the lowercased keys held by the struct types, in strict mode and for
+	// the types embedding Extras.
+	known sync.Map
+	// timeLayouts decode the time fields and timeFormat encodes them, with WithTimeLayouts.
+	timeLayouts []string
+	timeFormat  string
+	// times caches the time fields by struct type, with WithTimeLayouts.
+	times sync.Map
+	// marshal and unmarshal are the JSON functions of WithJSONCodec, nil for encoding/json.
+	marshal   func(v any) ([]byte, error)
+	unmarshal func(data []byte, v any) error
+}
+
+var (
+	// defaultCodec is the codec of the clients without naming strategy.
+	defaultCodec = &recordCodec{}
+
+	jsonMarshaler   = reflect.TypeFor[json.Marshaler]()
+	jsonUnmarshaler = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// parsePBTag returns the field name of a pb tag, e.g. "title" of `pb:"title"`, and its
+// key=value options, e.g. relation=author.
+func parsePBTag(tag string) (name string, options map[string]string) {
+	options = map[string]string{}
+	for i, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			if i == 0 {
+				name = option
+			}
+			continue
+		}
+		options[key] = value
+	}
+	return name, options
+}
+
+// fieldMapping returns the mapped fields of the struct type when one of its fields has a
+// pb field name, e.g.
+//
+//	Title string `json:"headline" pb:"title"`
+//
+// or the codec has a naming strategy, for the records to be encoded and decoded by their
+// PocketBase field names instead of their JSON tags. The fields without pb name fall back
+// to their JSON names, and then to their Go names mapped by the naming strategy.
+func (rc *recordCodec) fieldMapping(t reflect.Type) []mappedField {
+	// the types with their own JSON encoding are left alone
+	if t.Kind() != reflect.Struct || t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return nil
+	}
+	if cached, ok := rc.mappings.Load(t); ok {
+		return cached.([]mappedField)
+	}
+
+	fields, named := rc.appendMappedFields(nil, t, nil)
+	if !named && rc.naming == nil {
+		fields = nil
+	}
+	rc.mappings.Store(t, fields)
+	return fields
+}
+
+// appendMappedFields appends the fields of the struct type, flattening the untagged embedded
+// structs like encoding/json, and reports whether one of them has a pb field name.
+func (rc *recordCodec) appendMappedFields(fields []mappedField, t reflect.Type, index []int) ([]mappedField, bool) {
+	named := false
+	for i := range t.NumField() {
+		f := t.Field(i)
+		jsonName, jsonOptions, _ := strings.Cut(f.Tag.Get("json"), ",")
+		pbName, options := parsePBTag(f.Tag.Get("pb"))
+		fieldIndex := append(append([]int{}, index...), i)
+
+		if f.Anonymous && jsonName == "" && pbName == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				var embeddedNamed bool
+				fields, embeddedNamed = rc.appendMappedFields(fields, embedded, fieldIndex)
+				named = named || embeddedNamed
+				continue
+			}
+		}
+		// relation fields are decoded from the expanded records, and never sent
+		if !f.IsExported() || options["relation"] != "" {
+			continue
+		}
+
+		field := mappedField{
+			index:     fieldIndex,
+			key:       f.Name,
+			omitEmpty: strings.Contains(","+jsonOptions+",", ",omitempty,"),
+			omitZero:  strings.Contains(","+jsonOptions+",", ",omitzero,"),
+		}
+		switch {
+		case pbName == "-":
+			continue
+		case pbName != "":
+			field.key = pbName
+			named = true
+		case jsonName == "-":
+			continue
+		case jsonName != "":
+			field.key = jsonName
+		case rc.naming != nil:
+			field.key = rc.naming(f.Name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, named
+}
+
+// decodeMapped unmarshals the record into the mapped fields of the struct value, matching
+// the names like encoding/json, exactly first and then case-insensitively.
+func (rc *recordCodec) decodeMapped(record map[string]json.RawMessage, v reflect.Value, fields []mappedField) error {
+	for _, f := range fields {
+		raw, ok := record[f.key]
+		if !ok {
+			for key, value := range record {
+				if strings.EqualFold(key, f.key) {
+					raw, ok = value, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := rc.unmarshalJSON(raw, allocField(v, f.index).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocField returns the field of the struct value, allocating the nil embedded pointers.
+func allocField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// encode returns the body to send for a record, keyed by the PocketBase field names
+// when its struct type has a field mapping, with its Extras when it embeds them and its time
+// fields in the output layout of WithTimeLayouts, and the body itself otherwise.
+func (rc *recordCodec) encode(body any) (any, error) {
+	rv := reflect.ValueOf(body)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return body, nil
+	}
+	fields := rc.fieldMapping(rv.Type())
+	extras := extrasField(rv.Type())
+	var times []mappedField
+	if rc.timeFormat != "" {
+		times = rc.timeFields(rv.Type())
+	}
+	if fields == nil && extras == nil && times == nil {
+		return body, nil
+	}
+
+	record := make(map[string]json.RawMessage, len(fields))
+	if fields == nil {
+		raw, err := rc.marshalJSON(body)
+		if err != nil {
+			return nil, err
+		}
+		if err := rc.unmarshalJSON(raw, &record); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range fields {
+		v, ok := fieldByIndex(rv, f.index)
+		if !ok || f.omitEmpty && isEmptyValue(v) || f.omitZero && isZeroValue(v) {
+			continue
+		}
+		raw, err := rc.marshalJSON(v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		record[f.key] = raw
+	}
+	if extras != nil {
+		if err := rc.encodeExtras(record, rv, extras); err != nil {
+			return nil, err
+		}
+	}
+	if err := rc.encodeTimes(record, rv, times); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// isEmptyValue reports whether the value is empty for omitempty, like encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
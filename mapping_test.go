@@ -0,0 +1,115 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mappedBase struct {
+	ID string `json:"id"`
+}
+
+type mappedPost struct {
+	mappedBase
+	Headline string       `json:"headline" pb:"title"`
+	Secret   string       `json:"-" pb:"secret"`
+	Views    int          `json:"views,omitempty"`
+	Internal string       `pb:"-"`
+	Author   relationUser `json:"author" pb:"relation=author"`
+	Draft    bool
+}
+
+func TestFieldMapping(t *testing.T) {
+	assert.Nil(t, defaultCodec.fieldMapping(reflect.TypeFor[relationPost]()))
+
+	fields := defaultCodec.fieldMapping(reflect.TypeFor[mappedPost]())
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.key)
+	}
+	assert.Equal(t, []string{"id", "title", "secret", "views", "Draft"}, keys)
+}
+
+func TestRecordCodec_Encode(t *testing.T) {
+	body, err := defaultCodec.encode(&mappedPost{Headline: "hello", Secret: "s", Internal: "i", Draft: true})
+	require.NoError(t, err)
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "", "title": "hello", "secret": "s", "Draft": true}`, string(raw))
+
+	body, err = defaultCodec.encode(map[string]any{"title": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"title": "hello"}, body)
+}
+
+func TestRecordCodec_DecodeMapping(t *testing.T) {
+	var post mappedPost
+	err := defaultCodec.decode([]byte(`{
+		"id": "p1",
+		"title": "hello",
+		"headline": "ignored",
+		"secret": "s",
+		"views": 3,
+		"draft": true,
+		"author": "u1",
+		"expand": {"author": {"id": "u1", "name": "ann"}}
+	}`), &post)
+	require.NoError(t, err)
+	assert.Equal(t, mappedPost{
+		mappedBase: mappedBase{ID: "p1"},
+		Headline:   "hello",
+		Secret:     "s",
+		Views:      3,
+		Author:     relationUser{ID: "u1", Name: "ann"},
+		Draft:      true,
+	}, post)
+}
+
+func TestCollection_Mapping(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id,omitempty"`
+		Value string `json:"value" pb:"field"`
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	collection := CollectionSet[post](client, migrations.PostsPublic)
+
+	created, err := collection.Create(post{Value: "mapped"})
+	require.NoError(t, err)
+	defer func() {
+		_ = collection.Delete(created.ID)
+	}()
+	assert.Equal(t, "mapped", created.Record.Value)
+
+	require.NoError(t, collection.Update(created.ID, post{Value: "remapped"}))
+	one, err := collection.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post{ID: created.ID, Value: "remapped"}, one)
+}
+
+func TestRecordCodec_Naming(t *testing.T) {
+	type post struct {
+		ID        string `json:"id,omitempty"`
+		CreatedBy string
+		UserID    string
+		Headline  string `pb:"title"`
+	}
+	codec := &recordCodec{naming: SnakeCase}
+
+	body, err := codec.encode(post{CreatedBy: "u1", UserID: "u2", Headline: "hello"})
+	require.NoError(t, err)
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"created_by": "u1", "user_id": "u2", "title": "hello"}`, string(raw))
+
+	var decoded post
+	require.NoError(t, codec.decode(raw, &decoded))
+	assert.Equal(t, post{CreatedBy: "u1", UserID: "u2", Headline: "hello"}, decoded)
+}
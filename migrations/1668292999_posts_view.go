@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		if _, err := app.FindCollectionByNameOrId(PostsView); err == nil {
+			return nil
+		}
+
+		log.Println("creating view collection: ", PostsView)
+
+		collection := core.NewViewCollection(PostsView)
+		collection.ViewQuery = "SELECT id, field FROM " + PostsPublic
+		collection.ListRule = types.Pointer("")
+		collection.ViewRule = types.Pointer("")
+
+		return app.Save(collection)
+	}, func(app core.App) error {
+		collection, err := app.FindCollectionByNameOrId(PostsView)
+		if err != nil {
+			return nil
+		}
+		return app.Delete(collection)
+	})
+}
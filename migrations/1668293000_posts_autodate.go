@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		for _, c := range []string{PostsAdmin, PostsUser, PostsPublic} {
+			collection, err := app.FindCollectionByNameOrId(c)
+			if err != nil {
+				return err
+			}
+			if collection.Fields.GetByName("updated") != nil {
+				continue
+			}
+
+			log.Println("adding autodate fields to: ", c)
+
+			collection.Fields.Add(
+				&core.AutodateField{Name: "created", OnCreate: true},
+				&core.AutodateField{Name: "updated", OnCreate: true, OnUpdate: true},
+			)
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func(app core.App) error {
+		for _, c := range []string{PostsAdmin, PostsUser, PostsPublic} {
+			collection, err := app.FindCollectionByNameOrId(c)
+			if err != nil {
+				return nil
+			}
+			collection.Fields.RemoveByName("created")
+			collection.Fields.RemoveByName("updated")
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
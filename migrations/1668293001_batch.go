@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		settings := app.Settings()
+		if settings.Batch.Enabled {
+			return nil
+		}
+
+		log.Println("enabling batch requests")
+
+		settings.Batch.Enabled = true
+		settings.Batch.MaxRequests = 50
+		settings.Batch.Timeout = 3
+		return app.Save(settings)
+	}, func(app core.App) error {
+		settings := app.Settings()
+		settings.Batch.Enabled = false
+		return app.Save(settings)
+	})
+}
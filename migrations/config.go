@@ -5,6 +5,7 @@ const (
 	PostsAdmin         = "posts_admin"
 	PostsUser          = "posts_user"
 	PostsPublic        = "posts_public"
+	PostsView          = "posts_view"
 	AdminEmailPassword = "admin@admin.com"
 	UserEmailPassword  = "user@user.com"
 )
@@ -0,0 +1,245 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// ErrNoChanges is returned by Diff when the live collections match their declarations.
+var ErrNoChanges = errors.New("no schema changes")
+
+var registered []*Builder
+
+// Register adds the builders to the schema compared to the live collections by Diff.
+// It's meant to be called from init, next to the registration of their migrations:
+//
+//	func init() {
+//		schema.Register(posts)
+//		m.Register(posts.Up, posts.Down)
+//	}
+func Register(builders ...*Builder) {
+	registered = append(registered, builders...)
+}
+
+// Diff returns the source of a Go migration of package pkg applying the changes made to the
+// live collections since their registered declarations, e.g. in the dashboard: the added,
+// changed and removed fields, the indexes and the rules. Its down migration reverts them.
+//
+// Collections which aren't registered are ignored, and extended ones are compared on the
+// declared parts only.
+func Diff(app core.App, pkg string) (string, error) {
+	var names []string
+	builders := map[string][]*Builder{}
+	for _, b := range registered {
+		if _, ok := builders[b.name]; !ok {
+			names = append(names, b.name)
+		}
+		builders[b.name] = append(builders[b.name], b)
+	}
+
+	var up, down []string
+	for _, name := range names {
+		live, err := app.FindCollectionByNameOrId(name)
+		if err != nil {
+			continue // not migrated yet
+		}
+		declared, err := declaration(app, live, builders[name])
+		if err != nil {
+			return "", err
+		}
+
+		upParts, err := diffCollection(declared, live)
+		if err != nil {
+			return "", err
+		}
+		downParts, err := diffCollection(live, declared)
+		if err != nil {
+			return "", err
+		}
+		if len(upParts) == 0 {
+			continue
+		}
+		up = append(up, collectionBlock(name, upParts))
+		down = append([]string{collectionBlock(name, downParts)}, down...)
+	}
+	if len(up) == 0 {
+		return "", ErrNoChanges
+	}
+
+	upSrc, downSrc := strings.Join(up, "\n"), strings.Join(down, "\n")
+	imports := ""
+	if strings.Contains(upSrc+downSrc, "json.Unmarshal(") {
+		imports = "\"encoding/json\"\n\n"
+	}
+	src := fmt.Sprintf(diffTemplate, pkg, imports, upSrc, downSrc)
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return "", fmt.Errorf("[schema] can't format migration, err %w", err)
+	}
+	return string(formatted), nil
+}
+
+const diffTemplate = `package %s
+
+import (
+	%s"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+%s
+		return nil
+	}, func(app core.App) error {
+%s
+		return nil
+	})
+}
+`
+
+// declaration returns the collection as declared by the builders, starting from the live
+// collection when it's only extended.
+func declaration(app core.App, live *core.Collection, builders []*Builder) (*core.Collection, error) {
+	var collection *core.Collection
+	if builders[0].extend {
+		data, err := json.Marshal(live)
+		if err != nil {
+			return nil, err
+		}
+		collection = &core.Collection{}
+		if err := json.Unmarshal(data, collection); err != nil {
+			return nil, err
+		}
+	} else {
+		collection = core.NewCollection(builders[0].typ, live.Name, live.Id)
+	}
+
+	for _, b := range builders {
+		if err := b.Apply(collection, func(name string) (string, error) {
+			if name == live.Name {
+				return live.Id, nil
+			}
+			target, err := app.FindCollectionByNameOrId(name)
+			if err != nil {
+				return "", err
+			}
+			return target.Id, nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+// diffCollection returns the statements updating the from collection to the to collection.
+func diffCollection(from, to *core.Collection) ([]string, error) {
+	var parts []string
+
+	for _, f := range from.Fields {
+		if to.Fields.GetByName(f.GetName()) == nil {
+			parts = append(parts, fmt.Sprintf("collection.Fields.RemoveByName(%q)\n", f.GetName()))
+		}
+	}
+	for _, f := range to.Fields {
+		raw, err := marshalField(f)
+		if err != nil {
+			return nil, err
+		}
+		if old := from.Fields.GetByName(f.GetName()); old != nil {
+			oldRaw, err := marshalField(old)
+			if err != nil {
+				return nil, err
+			}
+			if bytes.Equal(raw, oldRaw) {
+				continue
+			}
+		}
+		parts = append(parts, errIf(fmt.Sprintf("collection.Fields.AddMarshaledJSON([]byte(%s))", quote(raw))))
+	}
+
+	fromData, toData := collectionData(from), collectionData(to)
+	changed := map[string]any{}
+	for key, value := range toData {
+		if !reflect.DeepEqual(fromData[key], value) {
+			changed[key] = value
+		}
+	}
+	if len(changed) > 0 {
+		raw, err := json.Marshal(changed)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, errIf(fmt.Sprintf("json.Unmarshal([]byte(%s), collection)", quote(raw))))
+	}
+	return parts, nil
+}
+
+// marshalField marshals the field with its type and without its id, so it's added or
+// replaced by name.
+func marshalField(f core.Field) ([]byte, error) {
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	delete(data, "id")
+	data["type"] = f.Type()
+	return json.Marshal(data)
+}
+
+// collectionData returns the compared collection data besides the fields.
+func collectionData(c *core.Collection) map[string]any {
+	indexes := slices.Clone([]string(c.Indexes))
+	if indexes == nil {
+		indexes = []string{}
+	}
+	return map[string]any{
+		"indexes":    indexes,
+		"listRule":   ruleValue(c.ListRule),
+		"viewRule":   ruleValue(c.ViewRule),
+		"createRule": ruleValue(c.CreateRule),
+		"updateRule": ruleValue(c.UpdateRule),
+		"deleteRule": ruleValue(c.DeleteRule),
+	}
+}
+
+func ruleValue(rule *string) any {
+	if rule == nil {
+		return nil
+	}
+	return *rule
+}
+
+func collectionBlock(name string, parts []string) string {
+	return fmt.Sprintf(`{
+	collection, err := app.FindCollectionByNameOrId(%q)
+	if err != nil {
+		return err
+	}
+	%s
+	if err := app.Save(collection); err != nil {
+		return err
+	}
+}
+`, name, strings.Join(parts, "\n"))
+}
+
+func errIf(statement string) string {
+	return "if err := " + statement + "; err != nil {\n\treturn err\n}\n"
+}
+
+// quote returns a Go raw string literal of the data.
+func quote(data []byte) string {
+	return "`" + strings.ReplaceAll(string(data), "`", "` + \"`\" + `") + "`"
+}
@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCollection(t *testing.T) {
+	declared := core.NewBaseCollection("posts")
+	declared.Fields.Add(&core.TextField{Name: "title"}, &core.TextField{Name: "legacy"})
+
+	live := core.NewBaseCollection("posts")
+	live.Fields.Add(&core.TextField{Name: "title", Required: true}, &core.BoolField{Name: "draft"})
+	live.AddIndex("idx_posts_title", true, "title", "")
+	live.ListRule = Rule("")
+
+	parts, err := diffCollection(declared, live)
+	require.NoError(t, err)
+	up := strings.Join(parts, "\n")
+	assert.Contains(t, up, `collection.Fields.RemoveByName("legacy")`)
+	assert.Contains(t, up, `"name":"title"`)
+	assert.Contains(t, up, `"required":true`)
+	assert.Contains(t, up, `"name":"draft"`)
+	assert.Contains(t, up, "idx_posts_title")
+	assert.Contains(t, up, `"listRule":""`)
+	assert.NotContains(t, up, "viewRule")
+	assert.NotContains(t, up, `"id"`)
+	assert.Contains(t, up, `"type":"bool"`)
+
+	parts, err = diffCollection(live, declared)
+	require.NoError(t, err)
+	down := strings.Join(parts, "\n")
+	assert.Contains(t, down, `collection.Fields.RemoveByName("draft")`)
+	assert.Contains(t, down, `"name":"legacy"`)
+	assert.Contains(t, down, `"indexes":[]`)
+	assert.Contains(t, down, `"listRule":null`)
+
+	parts, err = diffCollection(live, live)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// historyPrefix prefixes the _params ids of the collection states saved by Up.
+const historyPrefix = "schema_history_"
+
+// pushHistory saves the state of the collection before Up, null when Up created it.
+// The states are stacked, as the migrations are reverted in reverse order.
+func pushHistory(app core.App, name string, state json.RawMessage) error {
+	raw, err := loadHistory(app, name)
+	if err != nil {
+		return err
+	}
+	history, err := pushState(raw, state)
+	if err != nil {
+		return err
+	}
+	return saveHistory(app, name, history)
+}
+
+// popHistory removes and returns the state saved by the last Up of the collection,
+// nil when there is none.
+func popHistory(app core.App, name string) (json.RawMessage, error) {
+	raw, err := loadHistory(app, name)
+	if err != nil || raw == "" {
+		return nil, err
+	}
+	state, history, err := popState(raw)
+	if err != nil {
+		return nil, err
+	}
+	return state, saveHistory(app, name, history)
+}
+
+func loadHistory(app core.App, name string) (string, error) {
+	var raw string
+	err := app.DB().Select("value").From("_params").
+		Where(dbx.HashExp{"id": historyPrefix + name}).
+		Row(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("[schema] can't load history of %s, err %w", name, err)
+	}
+	return raw, nil
+}
+
+func saveHistory(app core.App, name, history string) error {
+	_, err := app.DB().NewQuery(`
+		INSERT INTO {{_params}} ([[id]], [[value]]) VALUES ({:id}, {:value})
+		ON CONFLICT ([[id]]) DO UPDATE SET
+			[[value]] = excluded.[[value]],
+			[[updated]] = strftime('%Y-%m-%d %H:%M:%fZ')
+	`).Bind(dbx.Params{"id": historyPrefix + name, "value": history}).Execute()
+	if err != nil {
+		return fmt.Errorf("[schema] can't save history of %s, err %w", name, err)
+	}
+	return nil
+}
+
+// pushState appends the state to the raw JSON array of states.
+func pushState(raw string, state json.RawMessage) (string, error) {
+	var states []json.RawMessage
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &states); err != nil {
+			return "", fmt.Errorf("[schema] can't unmarshal history, err %w", err)
+		}
+	}
+	data, err := json.Marshal(append(states, state))
+	return string(data), err
+}
+
+// popState removes the last state of the raw JSON array of states, returning it and the
+// remaining states. The state is nil when there are none.
+func popState(raw string) (json.RawMessage, string, error) {
+	var states []json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &states); err != nil {
+		return nil, "", fmt.Errorf("[schema] can't unmarshal history, err %w", err)
+	}
+	if len(states) == 0 {
+		return nil, raw, nil
+	}
+	data, err := json.Marshal(states[:len(states)-1])
+	return states[len(states)-1], string(data), err
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryStates(t *testing.T) {
+	raw, err := pushState("", json.RawMessage("null"))
+	require.NoError(t, err)
+	raw, err = pushState(raw, json.RawMessage(`{"name":"posts"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[null,{"name":"posts"}]`, raw)
+
+	state, raw, err := popState(raw)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"posts"}`, string(state))
+
+	state, raw, err = popState(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(state))
+	assert.Equal(t, "[]", raw)
+
+	state, _, err = popState(raw)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	_, _, err = popState("{")
+	assert.Error(t, err)
+}
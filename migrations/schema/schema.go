@@ -0,0 +1,358 @@
+// Package schema declares collections as code, generating idempotent migrations:
+//
+//	var posts = schema.Collection("posts").
+//		Text("title").Required().
+//		Relation("author", "users").
+//		Timestamps().
+//		Public()
+//
+//	func init() {
+//		schema.Register(posts)
+//		m.Register(posts.Up, posts.Down)
+//	}
+//
+// Up creates the collection, or updates it to match the declaration, so it can be run
+// again, e.g. after editing the declaration. It saves the previous state of the
+// collection, which Down restores, deleting the collection when Up created it.
+// Existing collections, like users, are extended with Extend.
+// Diff compares the registered declarations to the live collections.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type (
+	// Builder declares a collection. Its methods return the builder, so they can be chained.
+	Builder struct {
+		name      string
+		typ       string
+		extend    bool
+		fields    []core.Field
+		relations map[string]string
+		indexes   []index
+		rules     map[string]*string
+	}
+
+	index struct {
+		name    string
+		unique  bool
+		columns string
+	}
+)
+
+// Collection declares a base collection.
+func Collection(name string) *Builder {
+	return newBuilder(name, core.CollectionTypeBase)
+}
+
+// AuthCollection declares an auth collection.
+func AuthCollection(name string) *Builder {
+	return newBuilder(name, core.CollectionTypeAuth)
+}
+
+// Extend declares fields, indexes and rules added to an existing collection, e.g. users.
+func Extend(name string) *Builder {
+	b := newBuilder(name, "")
+	b.extend = true
+	return b
+}
+
+func newBuilder(name, typ string) *Builder {
+	return &Builder{
+		name:      name,
+		typ:       typ,
+		relations: map[string]string{},
+		rules:     map[string]*string{},
+	}
+}
+
+// Name returns the name of the collection.
+func (b *Builder) Name() string {
+	return b.name
+}
+
+// Field adds a field not covered by the other methods.
+func (b *Builder) Field(field core.Field) *Builder {
+	b.fields = append(b.fields, field)
+	return b
+}
+
+// Text adds a text field.
+func (b *Builder) Text(name string) *Builder {
+	return b.Field(&core.TextField{Name: name})
+}
+
+// Editor adds a rich text field.
+func (b *Builder) Editor(name string) *Builder {
+	return b.Field(&core.EditorField{Name: name})
+}
+
+// Number adds a number field.
+func (b *Builder) Number(name string) *Builder {
+	return b.Field(&core.NumberField{Name: name})
+}
+
+// Bool adds a bool field.
+func (b *Builder) Bool(name string) *Builder {
+	return b.Field(&core.BoolField{Name: name})
+}
+
+// Email adds an email field.
+func (b *Builder) Email(name string) *Builder {
+	return b.Field(&core.EmailField{Name: name})
+}
+
+// URL adds an URL field.
+func (b *Builder) URL(name string) *Builder {
+	return b.Field(&core.URLField{Name: name})
+}
+
+// Date adds a date field.
+func (b *Builder) Date(name string) *Builder {
+	return b.Field(&core.DateField{Name: name})
+}
+
+// JSON adds a JSON field.
+func (b *Builder) JSON(name string) *Builder {
+	return b.Field(&core.JSONField{Name: name})
+}
+
+// Select adds a single select field with the given values.
+func (b *Builder) Select(name string, values ...string) *Builder {
+	return b.Field(&core.SelectField{Name: name, Values: values, MaxSelect: 1})
+}
+
+// File adds a single file field.
+func (b *Builder) File(name string) *Builder {
+	return b.Field(&core.FileField{Name: name, MaxSelect: 1})
+}
+
+// Relation adds a single relation field to the given collection.
+func (b *Builder) Relation(name, collection string) *Builder {
+	b.relations[name] = collection
+	return b.Field(&core.RelationField{Name: name, MaxSelect: 1})
+}
+
+// Timestamps adds the "created" and "updated" autodate fields.
+func (b *Builder) Timestamps() *Builder {
+	b.Field(&core.AutodateField{Name: "created", OnCreate: true})
+	return b.Field(&core.AutodateField{Name: "updated", OnCreate: true, OnUpdate: true})
+}
+
+// Required makes the field added last required.
+func (b *Builder) Required() *Builder {
+	switch f := b.last().(type) {
+	case *core.TextField:
+		f.Required = true
+	case *core.EditorField:
+		f.Required = true
+	case *core.NumberField:
+		f.Required = true
+	case *core.BoolField:
+		f.Required = true
+	case *core.EmailField:
+		f.Required = true
+	case *core.URLField:
+		f.Required = true
+	case *core.DateField:
+		f.Required = true
+	case *core.JSONField:
+		f.Required = true
+	case *core.SelectField:
+		f.Required = true
+	case *core.FileField:
+		f.Required = true
+	case *core.RelationField:
+		f.Required = true
+	}
+	return b
+}
+
+// Unique adds an unique index on the field added last.
+func (b *Builder) Unique() *Builder {
+	if f := b.last(); f != nil {
+		b.Index("idx_"+b.name+"_"+f.GetName(), true, f.GetName())
+	}
+	return b
+}
+
+// Index adds an index on the comma separated columns.
+func (b *Builder) Index(name string, unique bool, columns string) *Builder {
+	b.indexes = append(b.indexes, index{name: name, unique: unique, columns: columns})
+	return b
+}
+
+// ListRule sets the list rule, nil allowing only superusers.
+func (b *Builder) ListRule(rule *string) *Builder { return b.rule("list", rule) }
+
+// ViewRule sets the view rule, nil allowing only superusers.
+func (b *Builder) ViewRule(rule *string) *Builder { return b.rule("view", rule) }
+
+// CreateRule sets the create rule, nil allowing only superusers.
+func (b *Builder) CreateRule(rule *string) *Builder { return b.rule("create", rule) }
+
+// UpdateRule sets the update rule, nil allowing only superusers.
+func (b *Builder) UpdateRule(rule *string) *Builder { return b.rule("update", rule) }
+
+// DeleteRule sets the delete rule, nil allowing only superusers.
+func (b *Builder) DeleteRule(rule *string) *Builder { return b.rule("delete", rule) }
+
+// Public allows everyone to list and view the records.
+func (b *Builder) Public() *Builder {
+	return b.ListRule(Rule("")).ViewRule(Rule(""))
+}
+
+// Rule returns a pointer to the rule, for the rule setters.
+func Rule(rule string) *string {
+	return &rule
+}
+
+func (b *Builder) rule(name string, rule *string) *Builder {
+	b.rules[name] = rule
+	return b
+}
+
+func (b *Builder) selfRelated() bool {
+	for _, target := range b.relations {
+		if target == b.name {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Builder) last() core.Field {
+	if len(b.fields) == 0 {
+		return nil
+	}
+	return b.fields[len(b.fields)-1]
+}
+
+// Up creates or updates the collection to match the declaration, saving its previous state for Down.
+func (b *Builder) Up(app core.App) error {
+	previous := json.RawMessage("null")
+	collection, err := app.FindCollectionByNameOrId(b.name)
+	switch {
+	case err == nil:
+		if previous, err = json.Marshal(collection); err != nil {
+			return err
+		}
+	case b.extend:
+		return fmt.Errorf("[schema] can't extend collection %s, err %w", b.name, err)
+	case b.selfRelated():
+		// relations to the collection itself are only valid once it exists
+		if err := app.Save(core.NewCollection(b.typ, b.name)); err != nil {
+			return fmt.Errorf("[schema] can't save collection %s, err %w", b.name, err)
+		}
+		if collection, err = app.FindCollectionByNameOrId(b.name); err != nil {
+			return err
+		}
+	default:
+		collection = core.NewCollection(b.typ, b.name)
+	}
+
+	if err := b.Apply(collection, func(name string) (string, error) {
+		if name == b.name {
+			return collection.Id, nil
+		}
+		target, err := app.FindCollectionByNameOrId(name)
+		if err != nil {
+			return "", err
+		}
+		return target.Id, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := app.Save(collection); err != nil {
+		return fmt.Errorf("[schema] can't save collection %s, err %w", b.name, err)
+	}
+	return pushHistory(app, b.name, previous)
+}
+
+// Down restores the collection to its state before Up, deleting it when Up created it.
+// Without a saved state, e.g. when Up ran before the states were saved, it deletes the
+// collection, or removes the declared fields and indexes of an extended one.
+func (b *Builder) Down(app core.App) error {
+	previous, err := popHistory(app, b.name)
+	if err != nil {
+		return err
+	}
+	collection, err := app.FindCollectionByNameOrId(b.name)
+	if err != nil {
+		return nil // already deleted
+	}
+
+	if previous != nil && string(previous) != "null" {
+		if err := json.Unmarshal(previous, collection); err != nil {
+			return fmt.Errorf("[schema] can't restore collection %s, err %w", b.name, err)
+		}
+		if err := app.Save(collection); err != nil {
+			return fmt.Errorf("[schema] can't save collection %s, err %w", b.name, err)
+		}
+		return nil
+	}
+
+	if !b.extend {
+		if err := app.Delete(collection); err != nil {
+			return fmt.Errorf("[schema] can't delete collection %s, err %w", b.name, err)
+		}
+		return nil
+	}
+
+	for _, f := range b.fields {
+		collection.Fields.RemoveByName(f.GetName())
+	}
+	for _, i := range b.indexes {
+		collection.RemoveIndex(i.name)
+	}
+	if err := app.Save(collection); err != nil {
+		return fmt.Errorf("[schema] can't save collection %s, err %w", b.name, err)
+	}
+	return nil
+}
+
+// Apply updates the collection to match the declaration, without saving it. The relations
+// are resolved to collection ids by resolve.
+func (b *Builder) Apply(collection *core.Collection, resolve func(name string) (string, error)) error {
+	var errs []error
+	for _, f := range b.fields {
+		if r, ok := f.(*core.RelationField); ok {
+			id, err := resolve(b.relations[r.Name])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("[schema] can't resolve relation %s.%s to %s, err %w", b.name, r.Name, b.relations[r.Name], err))
+				continue
+			}
+			r.CollectionId = id
+		}
+		collection.Fields.Add(f)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for _, i := range b.indexes {
+		collection.AddIndex(i.name, i.unique, i.columns, "")
+	}
+
+	for name, rule := range b.rules {
+		switch name {
+		case "list":
+			collection.ListRule = rule
+		case "view":
+			collection.ViewRule = rule
+		case "create":
+			collection.CreateRule = rule
+		case "update":
+			collection.UpdateRule = rule
+		case "delete":
+			collection.DeleteRule = rule
+		}
+	}
+	return nil
+}
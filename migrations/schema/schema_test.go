@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_Apply(t *testing.T) {
+	posts := Collection("posts").
+		Text("title").Required().
+		Text("slug").Unique().
+		Relation("author", "users").
+		Select("status", "draft", "published").
+		Timestamps().
+		Public().
+		CreateRule(Rule("@request.auth.id != ''"))
+
+	resolve := func(name string) (string, error) {
+		if name == "users" {
+			return "_pb_users_auth_", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	collection := core.NewBaseCollection("posts")
+	require.NoError(t, posts.Apply(collection, resolve))
+
+	title, ok := collection.Fields.GetByName("title").(*core.TextField)
+	require.True(t, ok)
+	assert.True(t, title.Required)
+	author, ok := collection.Fields.GetByName("author").(*core.RelationField)
+	require.True(t, ok)
+	assert.Equal(t, "_pb_users_auth_", author.CollectionId)
+	assert.NotNil(t, collection.Fields.GetByName("updated"))
+	assert.Len(t, collection.Indexes, 1)
+	assert.Contains(t, collection.Indexes[0], "idx_posts_slug")
+	require.NotNil(t, collection.ListRule)
+	assert.Empty(t, *collection.ListRule)
+	assert.Nil(t, collection.DeleteRule)
+
+	// applying again is idempotent
+	fields := len(collection.Fields)
+	require.NoError(t, posts.Apply(collection, resolve))
+	assert.Len(t, collection.Fields, fields)
+	assert.Len(t, collection.Indexes, 1)
+
+	// unresolved relations fail
+	err := Collection("comments").Relation("post", "missing").Apply(core.NewBaseCollection("comments"), resolve)
+	assert.ErrorContains(t, err, "comments.post")
+}
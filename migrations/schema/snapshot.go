@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// Snapshot returns the configuration of all collections as indented JSON, in the format
+// of the dashboard export, without the timestamps so only the changes show in reviews.
+func Snapshot(app core.App) ([]byte, error) {
+	collections, err := app.FindAllCollections()
+	if err != nil {
+		return nil, fmt.Errorf("[schema] can't fetch collections, err %w", err)
+	}
+	return marshalSnapshot(collections)
+}
+
+// SnapshotMigration returns the source of a Go migration of package pkg importing the
+// snapshot, to provision fresh instances with the exact collections.
+func SnapshotMigration(app core.App, pkg string) (string, error) {
+	data, err := Snapshot(app)
+	if err != nil {
+		return "", err
+	}
+	src := fmt.Sprintf(snapshotTemplate, pkg, quote(bytes.TrimSpace(data)))
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return "", fmt.Errorf("[schema] can't format migration, err %w", err)
+	}
+	return string(formatted), nil
+}
+
+const snapshotTemplate = `package %s
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		return app.ImportCollectionsByMarshaledJSON([]byte(%s), false)
+	}, nil)
+}
+`
+
+func marshalSnapshot(collections []*core.Collection) ([]byte, error) {
+	var items []map[string]any
+	for _, c := range collections {
+		raw, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		var item map[string]any
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, err
+		}
+		delete(item, "created")
+		delete(item, "updated")
+		items = append(items, item)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(items); err != nil {
+		return nil, fmt.Errorf("[schema] can't marshal snapshot, err %w", err)
+	}
+	return buf.Bytes(), nil
+}
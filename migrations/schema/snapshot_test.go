@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalSnapshot(t *testing.T) {
+	posts := core.NewBaseCollection("posts")
+	posts.Fields.Add(&core.TextField{Name: "title"})
+	posts.ListRule = Rule("status = 'published' && author != ''")
+
+	data, err := marshalSnapshot([]*core.Collection{posts, core.NewAuthCollection("users")})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "\n  {\n    \"", "indented")
+	assert.Contains(t, string(data), "&&", "not escaped")
+
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(data, &items))
+	require.Len(t, items, 2)
+	assert.Equal(t, "posts", items[0]["name"])
+	assert.Equal(t, "auth", items[1]["type"])
+	assert.NotContains(t, items[0], "created")
+	assert.NotContains(t, items[0], "updated")
+}
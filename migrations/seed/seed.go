@@ -0,0 +1,92 @@
+// Package seed provides data migrations which are safe to run again, so reference data,
+// e.g. roles, plans or feature flags, can live in migrations without duplicating rows:
+//
+//	var roles = []map[string]any{
+//		{"name": "admin", "level": 100},
+//		{"name": "member", "level": 10},
+//	}
+//
+//	func init() {
+//		m.Register(func(app core.App) error {
+//			return seed.UpsertRecords(app, "roles", "name", roles)
+//		}, func(app core.App) error {
+//			return seed.DeleteRecords(app, "roles", "name", roles)
+//		})
+//	}
+package seed
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// UpsertRecords creates the rows as records of the collection, or updates the records with
+// the same keyField value, so running it again doesn't duplicate them. Every row must have
+// a keyField value.
+func UpsertRecords(app core.App, collection, keyField string, rows []map[string]any) error {
+	if err := checkKeys(collection, keyField, rows); err != nil {
+		return err
+	}
+	c, err := app.FindCollectionByNameOrId(collection)
+	if err != nil {
+		return fmt.Errorf("[seed] can't find collection %s, err %w", collection, err)
+	}
+
+	for _, row := range rows {
+		record, err := app.FindFirstRecordByData(c, keyField, row[keyField])
+		switch {
+		case err == nil:
+		case errors.Is(err, sql.ErrNoRows):
+			record = core.NewRecord(c)
+		default:
+			return fmt.Errorf("[seed] can't find %s %s=%v, err %w", collection, keyField, row[keyField], err)
+		}
+
+		record.Load(row)
+		if err := app.Save(record); err != nil {
+			return fmt.Errorf("[seed] can't save %s %s=%v, err %w", collection, keyField, row[keyField], err)
+		}
+	}
+	return nil
+}
+
+// DeleteRecords deletes the records of the collection with the keyField values of the rows,
+// e.g. in the down migration of UpsertRecords. Missing records are skipped.
+func DeleteRecords(app core.App, collection, keyField string, rows []map[string]any) error {
+	if err := checkKeys(collection, keyField, rows); err != nil {
+		return err
+	}
+	c, err := app.FindCollectionByNameOrId(collection)
+	if err != nil {
+		return nil // already deleted
+	}
+
+	for _, row := range rows {
+		record, err := app.FindFirstRecordByData(c, keyField, row[keyField])
+		switch {
+		case err == nil:
+		case errors.Is(err, sql.ErrNoRows):
+			continue
+		default:
+			return fmt.Errorf("[seed] can't find %s %s=%v, err %w", collection, keyField, row[keyField], err)
+		}
+
+		if err := app.Delete(record); err != nil {
+			return fmt.Errorf("[seed] can't delete %s %s=%v, err %w", collection, keyField, row[keyField], err)
+		}
+	}
+	return nil
+}
+
+// checkKeys checks that every row has a keyField value.
+func checkKeys(collection, keyField string, rows []map[string]any) error {
+	for i, row := range rows {
+		if key, ok := row[keyField]; !ok || key == nil {
+			return fmt.Errorf("[seed] row %d of %s has no %s", i, collection, keyField)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckKeys(t *testing.T) {
+	assert.NoError(t, checkKeys("roles", "name", []map[string]any{{"name": "admin"}, {"name": "member"}}))
+	assert.NoError(t, checkKeys("roles", "name", nil))
+	assert.ErrorContains(t, checkKeys("roles", "name", []map[string]any{{"name": "admin"}, {"level": 10}}), "row 1 of roles has no name")
+	assert.Error(t, checkKeys("roles", "name", []map[string]any{{"name": nil}}))
+}
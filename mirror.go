@@ -0,0 +1,363 @@
+package pocketbase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// fileTokenRefresh is the age of the file tokens renewed by Mirror, shorter than their
+// default lifetime of 2 minutes.
+const fileTokenRefresh = time.Minute
+
+type (
+	// MirrorTarget stores the files mirrored by Client.Mirror under keys of the form
+	// collection/record/filename, e.g. DirTarget and S3Target.
+	MirrorTarget interface {
+		// Checksum returns the hex encoded SHA-256 checksum of the file stored under the key,
+		// empty when there is none.
+		Checksum(ctx context.Context, key string) (string, error)
+		// Put stores the file under the key, with its hex encoded SHA-256 checksum.
+		Put(ctx context.Context, key string, body io.ReadSeeker, checksum string) error
+	}
+
+	// MirrorOptions configures Client.Mirror.
+	MirrorOptions struct {
+		// Collections are the mirrored collections, all the collections with file fields
+		// except the views when empty.
+		Collections []string
+		// Concurrency is the number of files mirrored at once, 4 when 0.
+		Concurrency int
+		// Verify downloads the files already stored by the target again and replaces the
+		// ones whose checksum differs, e.g. after a corruption. Otherwise they are skipped,
+		// as the files of PocketBase never change under the same name.
+		Verify bool
+	}
+
+	// MirrorResult is the outcome of a Client.Mirror.
+	MirrorResult struct {
+		// Files is the number of files referenced by the records.
+		Files int
+		// Copied is the number of files stored by the target.
+		Copied int
+		// Unchanged is the number of files already stored by the target.
+		Unchanged int
+		// Bytes is the size of the copied files.
+		Bytes int64
+	}
+
+	// DirTarget mirrors the files to a local directory, in subdirectories by collection
+	// and record.
+	DirTarget struct {
+		Dir string
+	}
+
+	// mirrorFile is a file referenced by a record.
+	mirrorFile struct {
+		collection string
+		record     string
+		name       string
+		protected  bool
+	}
+
+	// fileToken caches the file token of the protected files.
+	fileToken struct {
+		files   Files
+		mu      sync.Mutex
+		token   string
+		expires time.Time
+	}
+)
+
+// key returns the key of the file in the targets.
+func (f mirrorFile) key() string {
+	return f.collection + "/" + f.record + "/" + f.name
+}
+
+// Mirror copies the files referenced by the file fields of the records to the target, e.g.
+// for backups of the files only or to prime a CDN, which requires a superuser:
+//
+//	result, err := client.Mirror(ctx, pocketbase.DirTarget{Dir: "/var/backups/files"}, pocketbase.MirrorOptions{})
+//
+// The mirror is incremental: the files already stored by the target are skipped, or
+// compared by checksum with Verify. The protected files are downloaded with a file token.
+// A failing file doesn't stop the others; the failures are returned as a *BulkError, with
+// the indexes of the files in the order of the collections and records.
+func (c *Client) Mirror(ctx context.Context, target MirrorTarget, opts MirrorOptions) (MirrorResult, error) {
+	var result MirrorResult
+	files, err := c.mirrorFiles(ctx, opts.Collections)
+	if err != nil {
+		return result, err
+	}
+	result.Files = len(files)
+
+	token := &fileToken{files: c.Files()}
+	var copied, unchanged atomic.Int64
+	var size atomic.Int64
+	err = ForEachConcurrent(ctx, files, opts.Concurrency, func(ctx context.Context, file mirrorFile) error {
+		n, err := c.mirrorFile(ctx, target, token, file, opts.Verify)
+		if err != nil {
+			return fmt.Errorf("[mirror] can't mirror %s, err %w", file.key(), err)
+		}
+		if n < 0 {
+			unchanged.Add(1)
+			return nil
+		}
+		copied.Add(1)
+		size.Add(n)
+		return nil
+	})
+	result.Copied = int(copied.Load())
+	result.Unchanged = int(unchanged.Load())
+	result.Bytes = size.Load()
+	return result, err
+}
+
+// mirrorFiles lists the files referenced by the records of the collections.
+func (c *Client) mirrorFiles(ctx context.Context, collections []string) ([]mirrorFile, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	var definitions []CollectionSchema
+	if len(collections) == 0 {
+		all, err := c.collectionSchemas(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, definition := range all {
+			if definition.Type != "view" {
+				definitions = append(definitions, definition)
+			}
+		}
+	}
+	for _, collection := range collections {
+		definition, err := c.collectionSchema(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	var files []mirrorFile
+	for _, definition := range definitions {
+		var fields []SchemaField
+		names := []string{"id"}
+		for _, field := range definition.Fields {
+			if field.Type == "file" {
+				fields = append(fields, field)
+				names = append(names, field.Name)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		params := ParamsList{Page: 1, Size: 500, Sort: "id", Fields: strings.Join(names, ",")}
+		for {
+			var page ResponseList[map[string]any]
+			if err := c.list(ctx, definition.Name, params, &page); err != nil {
+				return nil, err
+			}
+			for _, record := range page.Items {
+				id, _ := record["id"].(string)
+				for _, field := range fields {
+					for _, name := range fileNames(record[field.Name]) {
+						files = append(files, mirrorFile{
+							collection: definition.Name,
+							record:     id,
+							name:       name,
+							protected:  field.Protected(),
+						})
+					}
+				}
+			}
+			if params.Page >= page.TotalPages {
+				break
+			}
+			params.Page++
+		}
+	}
+	return files, nil
+}
+
+// fileNames returns the names of the files of a file field, with a single or multiple values.
+func fileNames(value any) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, name := range v {
+			if s, ok := name.(string); ok && s != "" {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// mirrorFile copies a file to the target, returning its size, or -1 when it is unchanged.
+func (c *Client) mirrorFile(ctx context.Context, target MirrorTarget, token *fileToken, file mirrorFile, verify bool) (int64, error) {
+	stored, err := target.Checksum(ctx, file.key())
+	if err != nil {
+		return 0, err
+	}
+	if stored != "" && !verify {
+		return -1, nil
+	}
+
+	tmp, err := os.CreateTemp("", "pocketbase-mirror-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	checksum, size, err := c.downloadFile(ctx, token, file, tmp)
+	if err != nil {
+		return 0, err
+	}
+	if checksum == stored {
+		return -1, nil
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if err := target.Put(ctx, file.key(), tmp, checksum); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// downloadFile writes the file to w, returning its checksum and size.
+func (c *Client) downloadFile(ctx context.Context, token *fileToken, file mirrorFile, w io.Writer) (string, int64, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	request := c.client.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetPathParams(map[string]string{
+			"collection": file.collection,
+			"record":     file.record,
+			"name":       file.name,
+		})
+	if file.protected {
+		t, err := token.get()
+		if err != nil {
+			return "", 0, err
+		}
+		request.SetQueryParam("token", t)
+	}
+
+	resp, err := request.Get(c.url + "/api/files/{collection}/{record}/{name}")
+	if err != nil {
+		return "", 0, fmt.Errorf("can't send file request to pocketbase, err %w", err)
+	}
+	body := resp.RawBody()
+	defer func() {
+		_ = body.Close()
+	}()
+	if resp.IsError() {
+		msg, _ := io.ReadAll(io.LimitReader(body, 1<<10))
+		return "", 0, newAPIError(resp, fmt.Errorf("pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			requestRedaction(resp.Request).redact(msg),
+			ErrInvalidResponse,
+		))
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, hash), body)
+	if err != nil {
+		return "", 0, fmt.Errorf("can't download file, err %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// get returns a file token, renewed before it expires.
+func (t *fileToken) get() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && time.Now().Before(t.expires) {
+		return t.token, nil
+	}
+	token, err := t.files.GetToken()
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expires = token, time.Now().Add(fileTokenRefresh)
+	return token, nil
+}
+
+// Checksum computes the checksum of the file, empty when it doesn't exist.
+func (d DirTarget) Checksum(_ context.Context, key string) (string, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Put replaces the file atomically, so an interrupted mirror doesn't leave partial files.
+func (d DirTarget) Put(_ context.Context, key string, body io.ReadSeeker, _ string) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := io.Copy(tmp, body); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path returns the path of the file, rejecting the keys escaping the directory.
+func (d DirTarget) path(key string) (string, error) {
+	rel := filepath.FromSlash(key)
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(d.Dir, rel), nil
+}
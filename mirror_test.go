@@ -0,0 +1,116 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Mirror(t *testing.T) {
+	posts := `{"name": "posts", "type": "base", "fields": [
+		{"name": "id", "type": "text"},
+		{"name": "cover", "type": "file", "maxSelect": 1},
+		{"name": "attachments", "type": "file", "maxSelect": 5, "protected": true}
+	]}`
+	var downloads, tokens atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/collections":
+			_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 500, "totalItems": 3, "totalPages": 1, "items": [
+				%s,
+				{"name": "tags", "type": "base", "fields": [{"name": "id", "type": "text"}]},
+				{"name": "covers", "type": "view", "fields": [{"name": "cover", "type": "file"}]}
+			]}`, posts)
+		case "/api/collections/posts":
+			_, _ = fmt.Fprint(w, posts)
+		case "/api/collections/posts/records":
+			assert.Equal(t, "id,cover,attachments", r.URL.Query().Get("fields"))
+			_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 500, "totalItems": 2, "totalPages": 1, "items": [
+				{"id": "p1", "cover": "a.png", "attachments": ["b.pdf", "c.txt"]},
+				{"id": "p2", "cover": "", "attachments": []}
+			]}`)
+		case "/api/files/token":
+			tokens.Add(1)
+			_, _ = fmt.Fprint(w, `{"token": "file-token"}`)
+		case "/api/files/posts/p1/a.png":
+			downloads.Add(1)
+			_, _ = fmt.Fprint(w, "png")
+		case "/api/files/posts/p1/b.pdf", "/api/files/posts/p1/c.txt":
+			downloads.Add(1)
+			if r.URL.Query().Get("token") != "file-token" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprint(w, "protected "+filepath.Base(r.URL.Path))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	dir := t.TempDir()
+	target := DirTarget{Dir: dir}
+
+	result, err := client.Mirror(context.Background(), target, MirrorOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, MirrorResult{Files: 3, Copied: 3, Bytes: 3 + 15 + 15}, result)
+	assert.EqualValues(t, 1, tokens.Load())
+	content, err := os.ReadFile(filepath.Join(dir, "posts", "p1", "b.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, "protected b.pdf", string(content))
+
+	// the stored files are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "posts", "p1", "a.png"), []byte("corrupted"), 0o600))
+	downloads.Store(0)
+	result, err = client.Mirror(context.Background(), target, MirrorOptions{Collections: []string{"posts"}})
+	require.NoError(t, err)
+	assert.Equal(t, MirrorResult{Files: 3, Unchanged: 3}, result)
+	assert.EqualValues(t, 0, downloads.Load())
+
+	// the corrupted files are replaced when verified
+	result, err = client.Mirror(context.Background(), target, MirrorOptions{Verify: true})
+	require.NoError(t, err)
+	assert.Equal(t, MirrorResult{Files: 3, Copied: 1, Unchanged: 2, Bytes: 3}, result)
+	content, err = os.ReadFile(filepath.Join(dir, "posts", "p1", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(content))
+	assert.EqualValues(t, 3, downloads.Load())
+
+	// the failing files don't stop the others
+	require.NoError(t, os.RemoveAll(dir))
+	failing := &failingTarget{DirTarget: target, key: "posts/p1/c.txt"}
+	result, err = client.Mirror(context.Background(), failing, MirrorOptions{Concurrency: 1})
+	var berr *BulkError
+	require.ErrorAs(t, err, &berr)
+	assert.Equal(t, []int{2}, berr.Failed())
+	assert.ErrorContains(t, err, "[mirror] can't mirror posts/p1/c.txt")
+	assert.Equal(t, MirrorResult{Files: 3, Copied: 2, Bytes: 18}, result)
+}
+
+func TestDirTarget_InvalidKey(t *testing.T) {
+	target := DirTarget{Dir: t.TempDir()}
+	_, err := target.Checksum(context.Background(), "../outside")
+	assert.ErrorContains(t, err, "invalid key")
+}
+
+type failingTarget struct {
+	DirTarget
+	key string
+}
+
+func (f *failingTarget) Checksum(ctx context.Context, key string) (string, error) {
+	if key == f.key {
+		return "", errors.New("unavailable")
+	}
+	return f.DirTarget.Checksum(ctx, key)
+}
@@ -0,0 +1,81 @@
+package pocketbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// Mutation is an outgoing record body passed to the mutators.
+	Mutation struct {
+		// Collection is the collection of the record.
+		Collection string
+		// ID is the id of the updated record, empty on create.
+		ID string
+		// Fields are the fields of the body, changed in place by the mutators.
+		Fields map[string]any
+		// Auth is the authenticated record of the client, nil if unknown.
+		Auth map[string]any
+	}
+
+	// Mutator changes the body of a created or updated record before it is sent,
+	// e.g. to set audit fields. Returning an error cancels the call.
+	Mutator func(m Mutation) error
+)
+
+// WithCreateMutator registers a mutator applied to the bodies of the created records,
+// in registration order, e.g. to set audit fields in one place:
+//
+//	pocketbase.WithCreateMutator(func(m pocketbase.Mutation) error {
+//		m.Fields["created_by"] = m.Auth["id"]
+//		m.Fields["app_version"] = version
+//		return nil
+//	})
+func WithCreateMutator(m Mutator) ClientOption {
+	return func(c *Client) {
+		c.createMutators = append(c.createMutators, m)
+	}
+}
+
+// WithUpdateMutator registers a mutator applied to the bodies of the updated records,
+// in registration order, like WithCreateMutator.
+func WithUpdateMutator(m Mutator) ClientOption {
+	return func(c *Client) {
+		c.updateMutators = append(c.updateMutators, m)
+	}
+}
+
+// mutate returns the encoded body changed by the mutators, or the body itself without mutators.
+func (c *Client) mutate(mutators []Mutator, collection, id string, body any) (any, error) {
+	if len(mutators) == 0 {
+		return body, nil
+	}
+
+	raw, err := c.codec.marshalJSON(body)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber() // keeps the precision of the large numbers
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("body isn't an object, err %w", err)
+	}
+	if fields == nil {
+		fields = map[string]any{}
+	}
+
+	m := Mutation{
+		Collection: collection,
+		ID:         id,
+		Fields:     fields,
+		Auth:       c.authorizer.Model(),
+	}
+	for _, mutator := range mutators {
+		if err := mutator(m); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,70 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Mutate(t *testing.T) {
+	client := NewClient(defaultURL, WithCreateMutator(func(m Mutation) error {
+		m.Fields["created_by"] = "u1"
+		return nil
+	}))
+	type post struct {
+		Title string `json:"title"`
+		Views int64  `json:"views"`
+	}
+
+	body, err := client.mutate(client.createMutators, "posts", "", post{Title: "hello", Views: 1 << 60})
+	require.NoError(t, err)
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title": "hello", "views": 1152921504606846976, "created_by": "u1"}`, string(raw))
+
+	body, err = client.mutate(client.updateMutators, "posts", "p1", post{Title: "unchanged"})
+	require.NoError(t, err)
+	assert.Equal(t, post{Title: "unchanged"}, body)
+
+	_, err = client.mutate(client.createMutators, "posts", "", []string{"not", "an", "object"})
+	assert.Error(t, err)
+}
+
+func TestClient_Mutators(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	errReadOnly := errors.New("read only")
+	var mutations []Mutation
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithCreateMutator(func(m Mutation) error {
+			mutations = append(mutations, m)
+			m.Fields["field"] = "stamped by " + m.Auth["email"].(string)
+			return nil
+		}),
+		WithUpdateMutator(func(m Mutation) error {
+			mutations = append(mutations, m)
+			return errReadOnly
+		}),
+	)
+
+	created, err := client.Create(migrations.PostsPublic, map[string]any{"field": "value"})
+	require.NoError(t, err)
+	defer func() {
+		_ = client.Delete(migrations.PostsPublic, created.ID)
+	}()
+	assert.Equal(t, "stamped by "+migrations.AdminEmailPassword, created.Record["field"])
+
+	err = client.Update(migrations.PostsPublic, created.ID, map[string]any{"field": "changed"})
+	assert.ErrorIs(t, err, errReadOnly)
+
+	require.Len(t, mutations, 2)
+	assert.Equal(t, migrations.PostsPublic, mutations[0].Collection)
+	assert.Empty(t, mutations[0].ID)
+	assert.Equal(t, created.ID, mutations[1].ID)
+}
@@ -0,0 +1,45 @@
+package pocketbase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy maps the Go name of a struct field without json or pb name to the name
+// of its PocketBase field, e.g. SnakeCase.
+type NamingStrategy func(goName string) string
+
+// WithNamingStrategy maps the struct fields without json or pb name to the PocketBase fields
+// named by the strategy when encoding and decoding records, so structs don't need a tag
+// on every field:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithNamingStrategy(pocketbase.SnakeCase))
+//
+// For a single collection, use a clone of the client:
+//
+//	posts := pocketbase.CollectionSet[Post](client.Clone(pocketbase.WithNamingStrategy(pocketbase.SnakeCase)), "posts")
+func WithNamingStrategy(naming NamingStrategy) ClientOption {
+	return func(c *Client) {
+		codec := c.codec.clone()
+		codec.naming = naming
+		c.codec = codec
+	}
+}
+
+// SnakeCase maps a Go name to snake_case, keeping the initialisms together,
+// e.g. CreatedBy to created_by and UserID to user_id.
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
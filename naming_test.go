@@ -0,0 +1,50 @@
+package pocketbase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"ID":        "id",
+		"Title":     "title",
+		"CreatedBy": "created_by",
+		"UserID":    "user_id",
+		"HTMLBody":  "html_body",
+		"Field2":    "field2",
+		"Top10List": "top10_list",
+		"already":   "already",
+	} {
+		assert.Equal(t, expected, SnakeCase(name), name)
+	}
+}
+
+func TestClient_NamingStrategy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID      string `json:"id,omitempty"`
+		Content string
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	collection := CollectionSet[post](client.Clone(WithNamingStrategy(func(name string) string {
+		return map[string]string{"Content": "field"}[name]
+	})), migrations.PostsPublic)
+
+	created, err := collection.Create(post{Content: "named"})
+	require.NoError(t, err)
+	defer func() {
+		_ = collection.Delete(created.ID)
+	}()
+	assert.Equal(t, "named", created.Record.Content)
+
+	posts, err := collection.Query().Where(Filter("id = {:id}", map[string]any{"id": created.ID})).All(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []post{{ID: created.ID, Content: "named"}}, posts)
+}
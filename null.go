@@ -0,0 +1,93 @@
+package pocketbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Null is a field of a record which tells apart leaving the field untouched, clearing it and
+// setting it, e.g. for partial updates without maps:
+//
+//	type PostUpdate struct {
+//		Title pocketbase.Null[string] `json:"title,omitzero"`
+//		Views pocketbase.Null[int]    `json:"views,omitzero"`
+//	}
+//
+//	err := posts.Update(id, PostUpdate{Title: pocketbase.NullOf("new title"), Views: pocketbase.NullClear[int]()})
+//
+// The zero Null is unset, and omitted by omitzero; the cleared fields are sent as null,
+// which PocketBase resets to the zero value of the field. When decoded, the fields of the
+// record are set, and null only when the record holds null.
+type Null[T any] struct {
+	// Value is the value of the field, when Valid.
+	Value T
+	// Valid reports whether the field is set to Value rather than null.
+	Valid bool
+	// Set reports whether the field is set, to Value or null.
+	Set bool
+}
+
+// NullOf returns the field set to the value.
+func NullOf[T any](v T) Null[T] {
+	return Null[T]{Value: v, Valid: true, Set: true}
+}
+
+// NullClear returns the field set to null.
+func NullClear[T any]() Null[T] {
+	return Null[T]{Set: true}
+}
+
+// IsZero reports whether the field is unset, for omitzero.
+func (n Null[T]) IsZero() bool {
+	return !n.Set
+}
+
+// Get returns the value of the field and whether it is valid.
+func (n Null[T]) Get() (T, bool) {
+	return n.Value, n.Valid
+}
+
+// MarshalJSON encodes the value of the field, or null when it isn't valid.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON sets the field to the decoded value, or to null.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	n.Value, n.Valid, n.Set = zero, false, true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// zeroer is implemented by the types with an IsZero method, e.g. Null and time.Time.
+type zeroer interface {
+	IsZero() bool
+}
+
+// isZeroValue reports whether the value is zero for omitzero, like encoding/json: by its
+// IsZero method when it has one.
+func isZeroValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return true
+	}
+	if z, ok := v.Interface().(zeroer); ok {
+		return z.IsZero()
+	}
+	if v.CanAddr() {
+		if z, ok := v.Addr().Interface().(zeroer); ok {
+			return z.IsZero()
+		}
+	}
+	return v.IsZero()
+}
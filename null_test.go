@@ -0,0 +1,82 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNull(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			sent = append(sent, string(body))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id": "p1", "title": "hello", "views": null}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+
+	type update struct {
+		Title Null[string] `json:"title,omitzero"`
+		Views Null[int]    `json:"views,omitzero"`
+		Draft Null[bool]   `json:"draft,omitzero"`
+	}
+	type mapped struct {
+		Title Null[string] `json:"headline,omitzero" pb:"title"`
+		Views Null[int]    `json:"views,omitzero"`
+		Draft Null[bool]   `json:"draft,omitzero"`
+	}
+	require.NoError(t, CollectionSet[update](client, "posts").Update("p1", update{Title: NullOf(""), Views: NullClear[int]()}))
+	require.NoError(t, CollectionSet[mapped](client, "posts").Update("p1", mapped{Title: NullOf("new"), Draft: NullOf(false)}))
+	assert.Equal(t, []string{`{"title":"","views":null}`, `{"draft":false,"title":"new"}`}, sent,
+		"the unset fields are omitted, the cleared ones sent as null")
+
+	one, err := CollectionSet[mapped](client, "posts").One("p1")
+	require.NoError(t, err)
+	assert.Equal(t, mapped{Title: NullOf("hello"), Views: NullClear[int]()}, one)
+	title, ok := one.Title.Get()
+	assert.Equal(t, "hello", title)
+	assert.True(t, ok)
+
+	var n Null[int]
+	require.Error(t, json.Unmarshal([]byte(`"x"`), &n))
+}
+
+func TestNull_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	type post struct {
+		ID    string       `json:"id,omitzero"`
+		Field Null[string] `json:"field,omitzero"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+
+	created, err := posts.Create(post{Field: NullOf("set")})
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+	assert.Equal(t, NullOf("set"), created.Record.Field)
+
+	require.NoError(t, posts.Update(created.ID, post{}))
+	one, err := posts.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, NullOf("set"), one.Field, "the unset field is untouched")
+
+	require.NoError(t, posts.Update(created.ID, post{Field: NullClear[string]()}))
+	one, err = posts.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, NullOf(""), one.Field, "the cleared field is reset")
+}
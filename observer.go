@@ -0,0 +1,111 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type (
+	// Operation identifies an SDK call reported to observers.
+	Operation struct {
+		// Collection is the collection the call operates on, empty for calls outside collections.
+		Collection string
+		// Name is the kind of the call: "list", "view", "create", "update" and "delete"
+		// for records, the action for other collection calls, e.g. "auth-with-password",
+		// and the API for calls outside collections, e.g. "backups" or "realtime".
+		Name string
+		// Method is the HTTP method of the call.
+		Method string
+		// Path is the URL path of the call.
+		Path string
+	}
+
+	// Observer is called after every SDK call with its duration, including retries,
+	// and error, e.g. to feed metrics systems. Error responses are reported as errors
+	// wrapping ErrInvalidResponse.
+	Observer func(op Operation, d time.Duration, err error)
+)
+
+// WithObserver adds an observer called after every SDK call.
+func WithObserver(observer Observer) ClientOption {
+	return func(c *Client) {
+		c.observers = append(c.observers, observer)
+	}
+}
+
+func (c *Client) observeSuccess(_ *resty.Client, resp *resty.Response) {
+	var err error
+	if resp.IsError() {
+		err = fmt.Errorf("pocketbase returned status: %d, err %w", resp.StatusCode(), ErrInvalidResponse)
+	}
+	if len(c.responseHooks) > 0 {
+		op, meta := newOperation(resp.Request.Method, resp.Request.URL), NewResponseMeta(resp)
+		for _, hook := range c.responseHooks {
+			hook(op, meta)
+		}
+	}
+	c.observe(resp.Request, err)
+}
+
+func (c *Client) observeError(r *resty.Request, err error) {
+	c.observe(r, err)
+}
+
+func (c *Client) observe(r *resty.Request, err error) {
+	if len(c.observers) == 0 && len(c.timingHooks) == 0 {
+		return
+	}
+	d := requestElapsed(r)
+	op := newOperation(r.Method, r.URL)
+	for _, observer := range c.observers {
+		observer(op, d, err)
+	}
+	if len(c.timingHooks) > 0 {
+		timing := newTiming(r, d)
+		for _, hook := range c.timingHooks {
+			hook(op, timing, err)
+		}
+	}
+}
+
+// newOperation derives the operation from the method and URL of a request.
+func newOperation(method, rawURL string) Operation {
+	op := Operation{Method: method, Path: rawURL}
+	if u, err := url.Parse(rawURL); err == nil {
+		op.Path = u.Path
+	}
+
+	segments := strings.Split(strings.Trim(op.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "api" {
+		op.Name = strings.ToLower(method)
+		return op
+	}
+	if segments[1] != "collections" || len(segments) < 4 {
+		op.Name = segments[1]
+		return op
+	}
+
+	op.Collection, _ = url.PathUnescape(segments[2])
+	if segments[3] != "records" {
+		op.Name = segments[3]
+		return op
+	}
+	switch {
+	case len(segments) == 4 && method == http.MethodPost:
+		op.Name = "create"
+	case len(segments) == 4:
+		op.Name = "list"
+	case method == http.MethodPatch:
+		op.Name = "update"
+	case method == http.MethodDelete:
+		op.Name = "delete"
+	default:
+		op.Name = "view"
+	}
+	return op
+}
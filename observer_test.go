@@ -0,0 +1,71 @@
+package pocketbase
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOperation(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   Operation
+	}{
+		{http.MethodGet, defaultURL + "/api/collections/posts/records?page=1", Operation{"posts", "list", http.MethodGet, "/api/collections/posts/records"}},
+		{http.MethodPost, defaultURL + "/api/collections/posts/records", Operation{"posts", "create", http.MethodPost, "/api/collections/posts/records"}},
+		{http.MethodGet, defaultURL + "/api/collections/posts/records/abc", Operation{"posts", "view", http.MethodGet, "/api/collections/posts/records/abc"}},
+		{http.MethodPatch, defaultURL + "/api/collections/posts/records/abc", Operation{"posts", "update", http.MethodPatch, "/api/collections/posts/records/abc"}},
+		{http.MethodDelete, defaultURL + "/api/collections/posts/records/abc", Operation{"posts", "delete", http.MethodDelete, "/api/collections/posts/records/abc"}},
+		{http.MethodPost, defaultURL + "/api/collections/users/auth-with-password", Operation{"users", "auth-with-password", http.MethodPost, "/api/collections/users/auth-with-password"}},
+		{http.MethodGet, defaultURL + "/api/backups", Operation{"", "backups", http.MethodGet, "/api/backups"}},
+		{http.MethodGet, defaultURL + "/custom", Operation{"", "get", http.MethodGet, "/custom"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, newOperation(tt.method, tt.url))
+		})
+	}
+}
+
+func TestWithObserver(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type call struct {
+		op  Operation
+		d   time.Duration
+		err error
+	}
+	var (
+		mu    sync.Mutex
+		calls []call
+	)
+	client := NewClient(defaultURL, WithObserver(func(op Operation, d time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call{op, d, err})
+	}))
+
+	resp, err := client.Create(migrations.PostsPublic, map[string]any{"field": "observed"})
+	require.NoError(t, err)
+	_, err = client.One(migrations.PostsPublic, resp.ID+"x")
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, calls, 2)
+
+	assert.Equal(t, migrations.PostsPublic, calls[0].op.Collection)
+	assert.Equal(t, "create", calls[0].op.Name)
+	assert.Positive(t, calls[0].d)
+	assert.NoError(t, calls[0].err)
+
+	assert.Equal(t, "view", calls[1].op.Name)
+	assert.ErrorIs(t, calls[1].err, ErrInvalidResponse)
+}
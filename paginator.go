@@ -0,0 +1,89 @@
+package pocketbase
+
+import "errors"
+
+// ErrNoPage is returned when paginating beyond the first or the last page.
+var ErrNoPage = errors.New("no such page")
+
+type (
+	// PageInfo describes the current page of a Paginator.
+	PageInfo struct {
+		Page       int
+		PerPage    int
+		TotalItems int
+		TotalPages int
+	}
+
+	// Paginator walks the pages of a collection list, keeping track of the page bookkeeping.
+	Paginator[T any] struct {
+		collection *Collection[T]
+		params     ParamsList
+		info       PageInfo
+		fetched    bool
+	}
+)
+
+// Paginate returns a paginator for the records matching params, starting at params.Page.
+//
+//	p := collection.Paginate(pocketbase.ParamsList{Size: 50})
+//	for p.HasMore() {
+//		items, err := p.Next()
+//		...
+//	}
+func (c *Collection[T]) Paginate(params ParamsList) *Paginator[T] {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	return &Paginator[T]{
+		collection: c,
+		params:     params,
+	}
+}
+
+// Next fetches the next page, or the start page on the first call.
+func (p *Paginator[T]) Next() ([]T, error) {
+	if !p.HasMore() {
+		return nil, ErrNoPage
+	}
+	page := p.params.Page
+	if p.fetched {
+		page = p.info.Page + 1
+	}
+	return p.fetch(page)
+}
+
+// Prev fetches the page before the current one.
+func (p *Paginator[T]) Prev() ([]T, error) {
+	if !p.fetched || p.info.Page <= 1 {
+		return nil, ErrNoPage
+	}
+	return p.fetch(p.info.Page - 1)
+}
+
+// HasMore reports whether Next has a page to fetch.
+func (p *Paginator[T]) HasMore() bool {
+	return !p.fetched || p.info.Page < p.info.TotalPages
+}
+
+// PageInfo returns the info of the page fetched last.
+func (p *Paginator[T]) PageInfo() PageInfo {
+	return p.info
+}
+
+func (p *Paginator[T]) fetch(page int) ([]T, error) {
+	params := p.params
+	params.Page = page
+	r, err := p.collection.List(params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.fetched = true
+	p.info = PageInfo{
+		Page:       r.Page,
+		PerPage:    r.PerPage,
+		TotalItems: r.TotalItems,
+		TotalPages: r.TotalPages,
+	}
+	return r.Items, nil
+}
@@ -0,0 +1,48 @@
+package pocketbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_Paginate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+
+	field := "paginate_" + time.Now().Format(time.StampMilli)
+	for range 3 {
+		_, err := collection.Create(map[string]any{"field": field})
+		require.NoError(t, err)
+	}
+
+	p := collection.Paginate(ParamsList{Size: 2, Filters: "field='" + field + "'", Sort: "id"})
+	assert.True(t, p.HasMore())
+	_, err := p.Prev()
+	assert.ErrorIs(t, err, ErrNoPage)
+
+	first, err := p.Next()
+	require.NoError(t, err)
+	assert.Len(t, first, 2)
+	assert.Equal(t, PageInfo{Page: 1, PerPage: 2, TotalItems: 3, TotalPages: 2}, p.PageInfo())
+	assert.True(t, p.HasMore())
+
+	second, err := p.Next()
+	require.NoError(t, err)
+	assert.Len(t, second, 1)
+	assert.Equal(t, 2, p.PageInfo().Page)
+	assert.False(t, p.HasMore())
+	_, err = p.Next()
+	assert.ErrorIs(t, err, ErrNoPage)
+
+	prev, err := p.Prev()
+	require.NoError(t, err)
+	assert.Equal(t, first, prev)
+	assert.Equal(t, 1, p.PageInfo().Page)
+}
@@ -1,5 +1,11 @@
 package pocketbase
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
 // ParamsList represents query parameters for PocketBase API requests including pagination, filtering, and sorting.
 type ParamsList struct {
 	Page    int
@@ -10,4 +16,31 @@ type ParamsList struct {
 	Fields  string
 
 	hackResponseRef any //hack for collection list
+	// skipTotal skips the count of the records, for the lists not reading it.
+	skipTotal bool
+}
+
+// Continuation is the position to resume an interrupted FullListPartial from.
+type Continuation struct {
+	// Params are the list params with Page set to the first page not fetched yet.
+	Params ParamsList `json:"params"`
+}
+
+// Token encodes the continuation as an opaque string, e.g. to store it in a checkpoint.
+func (c Continuation) Token() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// ParseContinuation decodes a continuation from its token.
+func ParseContinuation(token string) (Continuation, error) {
+	var c Continuation
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("[list] can't decode continuation token, err %w", err)
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, fmt.Errorf("[list] can't unmarshal continuation token, err %w", err)
+	}
+	return c, nil
 }
@@ -0,0 +1,147 @@
+//go:build parquet
+
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// ExportParquet writes the records of the collection matching params to w as a Parquet file,
+// page by page, e.g. to land the data into lakehouse storage. It requires the parquet build
+// tag, keeping the Parquet dependencies out of the other builds:
+//
+//	go build -tags parquet ./...
+//
+// The schema of the file is inferred from the definition of the collection, which requires
+// a superuser, with an optional column per field: text, number, bool and JSON fields map to
+// strings, doubles, booleans and JSON strings, date fields to timestamps in milliseconds,
+// fields with multiple values to lists, and geo points to groups of longitude and latitude.
+// The hidden fields, e.g. passwords, are left out. The records are read like ExportJSON,
+// by pages of params.Size records, 500 when 0. The file is compressed with Snappy, and is
+// incomplete when an error is returned.
+func (c *Collection[T]) ExportParquet(w io.Writer, params ParamsList) error {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Size < 1 {
+		params.Size = 500
+	}
+	ctx, cancel := c.callContext(context.Background())
+	defer cancel()
+
+	definition, err := c.collectionSchema(ctx, c.Name)
+	if err != nil {
+		return err
+	}
+	group := parquet.Group{}
+	var fields []SchemaField
+	for _, field := range definition.Fields {
+		if field.Hidden || field.Type == "password" {
+			continue
+		}
+		group[field.Name] = parquet.Optional(parquetNode(field))
+		fields = append(fields, field)
+	}
+
+	writer := parquet.NewWriter(w, parquet.NewSchema(definition.Name, group), parquet.Compression(&parquet.Snappy))
+	for {
+		var page ResponseList[map[string]any]
+		if err := c.Client.list(ctx, c.Name, params, &page); err != nil {
+			return err
+		}
+		for _, record := range page.Items {
+			row := make(map[string]any, len(fields))
+			for _, field := range fields {
+				value, err := parquetValue(field, record[field.Name])
+				if err != nil {
+					return err
+				}
+				row[field.Name] = value
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("[export] can't write records, err %w", err)
+			}
+		}
+		if params.Page >= page.TotalPages {
+			break
+		}
+		params.Page++
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("[export] can't write records, err %w", err)
+	}
+	return nil
+}
+
+// parquetNode returns the Parquet type of a field.
+func parquetNode(field SchemaField) parquet.Node {
+	var node parquet.Node
+	switch field.Type {
+	case "number":
+		node = parquet.Leaf(parquet.DoubleType)
+	case "bool":
+		node = parquet.Leaf(parquet.BooleanType)
+	case "date", "autodate":
+		node = parquet.Timestamp(parquet.Millisecond)
+	case "json":
+		node = parquet.JSON()
+	case "geoPoint":
+		node = parquet.Group{
+			"lon": parquet.Leaf(parquet.DoubleType),
+			"lat": parquet.Leaf(parquet.DoubleType),
+		}
+	default:
+		node = parquet.String()
+	}
+	if field.Multiple() {
+		return parquet.List(node)
+	}
+	return node
+}
+
+// parquetValue converts the value of a field in a record to its Parquet type, nil for the
+// missing values and empty dates.
+func parquetValue(field SchemaField, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if field.Multiple() {
+		values, _ := value.([]any)
+		list := make([]string, 0, len(values))
+		for _, v := range values {
+			list = append(list, fmt.Sprint(v))
+		}
+		return list, nil
+	}
+
+	switch field.Type {
+	case "date", "autodate":
+		s, _ := value.(string)
+		if s == "" {
+			return nil, nil
+		}
+		t, ok := parseDateTime(s)
+		if !ok {
+			return nil, fmt.Errorf("[export] invalid date %q of field %s", s, field.Name)
+		}
+		return t.UTC(), nil
+	case "json":
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("[export] can't marshal field %s, err %w", field.Name, err)
+		}
+		return string(raw), nil
+	case "number", "bool", "geoPoint":
+		return value, nil
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
@@ -0,0 +1,90 @@
+//go:build parquet
+
+package pocketbase
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_ExportParquet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/collections/posts":
+			_, _ = fmt.Fprint(w, `{"name": "posts", "type": "base", "fields": [
+				{"name": "id", "type": "text", "required": true},
+				{"name": "title", "type": "text"},
+				{"name": "views", "type": "number"},
+				{"name": "draft", "type": "bool"},
+				{"name": "published", "type": "date"},
+				{"name": "tags", "type": "select", "maxSelect": 3},
+				{"name": "author", "type": "relation", "maxSelect": 1},
+				{"name": "meta", "type": "json"},
+				{"name": "place", "type": "geoPoint"},
+				{"name": "secret", "type": "text", "hidden": true}
+			]}`)
+		case "/api/collections/posts/records":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			items := `{"id": "r1", "title": "a", "views": 3, "draft": false, "published": "2024-03-01 10:00:00.123Z",
+				"tags": ["go", "db"], "author": "u1", "meta": {"k": 1}, "place": {"lon": 1.5, "lat": 2.5}}`
+			if page == 2 {
+				items = `{"id": "r2", "title": "", "views": 0, "draft": true, "published": "", "tags": [], "author": "", "meta": null}`
+			}
+			_, _ = fmt.Fprintf(w, `{"page": %d, "perPage": 1, "totalItems": 2, "totalPages": 2, "items": [%s]}`, page, items)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	collection := CollectionSet[map[string]any](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+
+	var out bytes.Buffer
+	require.NoError(t, collection.ExportParquet(&out, ParamsList{Size: 1}))
+
+	file, err := parquet.OpenFile(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, file.NumRows())
+	columns := map[string]bool{}
+	for _, field := range file.Schema().Fields() {
+		columns[field.Name()] = field.Optional()
+	}
+	assert.Equal(t, map[string]bool{
+		"id": true, "title": true, "views": true, "draft": true, "published": true,
+		"tags": true, "author": true, "meta": true, "place": true,
+	}, columns)
+
+	reader := parquet.NewReader(bytes.NewReader(out.Bytes()))
+	var rows []map[string]any
+	for {
+		row := map[string]any{}
+		if err := reader.Read(&row); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 2)
+	published := time.Date(2024, 3, 1, 10, 0, 0, 123e6, time.UTC).UnixMilli()
+	assert.Equal(t, map[string]any{
+		"id": "r1", "title": "a", "views": 3.0, "draft": false, "published": published,
+		"tags": []any{"go", "db"}, "author": "u1", "meta": map[string]any{"k": 1.0},
+		"place": map[string]any{"lon": 1.5, "lat": 2.5},
+	}, rows[0])
+	assert.Equal(t, "r2", rows[1]["id"])
+	assert.Equal(t, true, rows[1]["draft"])
+	assert.Nil(t, rows[1]["published"])
+	assert.Nil(t, rows[1]["meta"])
+	assert.Nil(t, rows[1]["place"])
+}
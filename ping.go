@@ -0,0 +1,105 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PingResult describes the server reached by Client.Ping.
+type PingResult struct {
+	// Version is the server version when the health check reports one, e.g. a server adding
+	// it to the data of /api/health. It is empty for the stock PocketBase.
+	Version string
+	// Latency is the round trip of the health check.
+	Latency time.Duration
+	// Authenticated reports whether the client is authenticated, i.e. has an auth token
+	// accepted by the server.
+	Authenticated bool
+	// Data is the data of the health check, e.g. canBackup for superusers.
+	Data map[string]any
+}
+
+// Ping checks that the server is reachable and the credentials of the client are valid,
+// by authorizing the client, refreshing its token, so that static, revoked or expired
+// tokens fail too, and calling the health check. It is intended for the startup
+// of services, for a misconfigured URL or credentials to fail fast with a clear error:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+//	defer cancel()
+//	result, err := client.Ping(ctx)
+//	if err != nil {
+//		log.Fatalf("pocketbase is not available: %v", err)
+//	}
+//	log.Printf("pocketbase %s reached in %s", result.Version, result.Latency)
+func (c *Client) Ping(ctx context.Context) (PingResult, error) {
+	var result PingResult
+	if _, err := normalizeURL(c.url); err != nil {
+		return result, fmt.Errorf("[ping] can't reach pocketbase, err %w", err)
+	}
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	if err := c.Authorize(); err != nil {
+		return result, fmt.Errorf("[ping] can't authorize, err %w", err)
+	}
+	if token := c.authorizer.Token(); token != "" {
+		if err := c.verifyToken(ctx, token); err != nil {
+			return result, fmt.Errorf("[ping] can't authorize, err %w", err)
+		}
+		result.Authenticated = true
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		Get(c.url + "/api/health")
+	if err != nil {
+		return result, fmt.Errorf("[ping] can't send health request to pocketbase, err %w", err)
+	}
+	result.Latency = resp.Time()
+
+	if resp.IsError() {
+		return result, newAPIError(resp, fmt.Errorf("[ping] pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	var health struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := c.codec.unmarshalJSON(resp.Body(), &health); err != nil {
+		return result, fmt.Errorf("[ping] can't unmarshal health response, err %w", err)
+	}
+	result.Data = health.Data
+	result.Version, _ = health.Data["version"].(string)
+	return result, nil
+}
+
+// verifyToken checks that the server accepts the token by refreshing it with the auth
+// collection of its claims, without keeping the refreshed token.
+func (c *Client) verifyToken(ctx context.Context, token string) error {
+	claims, err := ParseToken(token)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.R().
+		SetContext(retrySafe(ctx)).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", token).
+		SetPathParam("collection", claims.CollectionID).
+		Post(c.url + "/api/collections/{collection}/auth-refresh")
+	if err != nil {
+		return fmt.Errorf("[auth-refresh] can't send request to pocketbase %w", err)
+	}
+	if resp.IsError() {
+		return newAPIError(resp, fmt.Errorf("[auth-refresh] pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+)
+
+func TestClient_Ping(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ctx := context.Background()
+
+	result, err := NewClient(defaultURL).Ping(ctx)
+	require.NoError(t, err)
+	assert.False(t, result.Authenticated)
+	assert.Positive(t, result.Latency)
+
+	admin := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	result, err = admin.Ping(ctx)
+	require.NoError(t, err)
+	assert.True(t, result.Authenticated)
+	assert.Contains(t, result.Data, "canBackup")
+
+	_, err = NewClient(defaultURL, WithRetry(0, 0, 0), WithAdminEmailPassword(migrations.AdminEmailPassword, "wrong password")).Ping(ctx)
+	assert.ErrorContains(t, err, "[ping] can't authorize")
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+
+	user := NewClient(defaultURL, WithUserEmailPassword(migrations.UserEmailPassword, migrations.UserEmailPassword))
+	require.NoError(t, user.Authorize())
+	token := user.AuthStore().Token()
+	result, err = NewClient(defaultURL, WithAuthorizer(&secretAuthorizer{token: token})).Ping(ctx)
+	require.NoError(t, err)
+	assert.True(t, result.Authenticated)
+
+	_, err = NewClient(defaultURL, WithRetry(0, 0, 0), WithAuthorizer(&secretAuthorizer{token: token[:len(token)-2] + "xx"})).Ping(ctx)
+	assert.ErrorIs(t, err, ErrInvalidResponse, "the static tokens are checked by the server")
+	_, err = NewClient(defaultURL, WithAuthorizer(&secretAuthorizer{token: "not a token"})).Ping(ctx)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestClient_PingServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/health":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"code": 200, "message": "API is healthy.", "data": {"version": "v0.30.4"}}`)
+		case "/other/api/health":
+			_, _ = fmt.Fprint(w, `<html>not pocketbase</html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+
+	result, err := NewClient(srv.URL).Ping(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.30.4", result.Version)
+
+	_, err = NewClient(srv.URL + "/missing").Ping(ctx)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	_, err = NewClient(srv.URL + "/other").Ping(ctx)
+	assert.ErrorContains(t, err, "can't unmarshal health response")
+	_, err = NewClient("localhost:8090").Ping(ctx)
+	assert.ErrorIs(t, err, ErrInvalidURL)
+
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = NewClient("http://127.0.0.1:1").Ping(ctx)
+	assert.Error(t, err)
+}
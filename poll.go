@@ -0,0 +1,247 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollCursor is the position of a polled topic: the latest seen "updated" value
+// and the versions of the records seen with it, as the next poll includes them again.
+type pollCursor struct {
+	since string
+	seen  map[string]string
+}
+
+// pollRecord is the part of a record needed to track changes.
+type pollRecord struct {
+	ID      string `json:"id"`
+	Created string `json:"created"`
+	Updated string `json:"updated"`
+}
+
+// subscribePoll emulates a realtime subscription by periodically listing the records
+// updated since the last poll and emitting them as create and update events.
+func (c *Collection[T]) subscribePoll(opts SubscribeOptions, targets []string) (*Stream[T], error) {
+	if err := c.Authorize(); err != nil {
+		return nil, err
+	}
+
+	stream := newStream[T](c.Client, targets, opts.Headers)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	stream.unsubscribe = func() {
+		cancel()
+		<-done
+	}
+
+	cursors := make(map[string]*pollCursor, len(targets))
+	for _, topic := range targets {
+		cursor, err := c.pollStart(ctx, opts, topic)
+		if err != nil {
+			cancel()
+			c.realtime.subscriptionFailed([]string{topic}, err)
+			return nil, err
+		}
+		cursors[topic] = cursor
+	}
+	c.realtime.add(stream)
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(opts.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for _, topic := range stream.Topics() {
+				events, err := c.poll(ctx, opts, topic, cursors[topic])
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					if c.sseDebug {
+						log.Printf("Poll %s failed: %v", topic, err)
+					}
+					c.realtime.subscriptionFailed([]string{topic}, err)
+					continue
+				}
+				for _, e := range events {
+					select {
+					case stream.channel.C <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+// pollStart returns the cursor of the latest records of the topic.
+func (c *Collection[T]) pollStart(ctx context.Context, opts SubscribeOptions, topic string) (*pollCursor, error) {
+	var list ResponseList[pollRecord]
+	if err := c.pollList(ctx, opts, topic, url.Values{
+		"perPage":   {"200"},
+		"sort":      {"-updated"},
+		"fields":    {"id,created,updated"},
+		"skipTotal": {"1"},
+	}, &list); err != nil {
+		return nil, err
+	}
+
+	cursor := &pollCursor{seen: map[string]string{}}
+	for _, record := range list.Items {
+		if cursor.since == "" {
+			cursor.since = record.Updated
+		}
+		if record.Updated != cursor.since {
+			break
+		}
+		cursor.seen[record.ID] = record.Updated
+	}
+	return cursor, nil
+}
+
+// poll lists the records of the topic updated since the cursor and advances it once they
+// are all listed. The listing restarts at the first page each time the cursor moves, since
+// the offsets of the next pages shift with the filter and the records updated meanwhile;
+// it only moves on to the next page when the records of a page are all seen.
+func (c *Collection[T]) poll(ctx context.Context, opts SubscribeOptions, topic string, cursor *pollCursor) ([]RealtimeEvent[T], error) {
+	var events []RealtimeEvent[T]
+	next := pollCursor{since: cursor.since, seen: maps.Clone(cursor.seen)}
+	for page := 1; ; {
+		var list ResponseList[json.RawMessage]
+		if err := c.pollList(ctx, opts, topic, url.Values{
+			"page":    {strconv.Itoa(page)},
+			"perPage": {"200"},
+			"sort":    {"updated,id"},
+			"filter":  {Filter("updated >= {:since}", map[string]any{"since": next.since})},
+		}, &list); err != nil {
+			return nil, err
+		}
+		since := next.since
+
+		for _, raw := range list.Items {
+			var record pollRecord
+			if err := json.Unmarshal(raw, &record); err != nil {
+				return nil, fmt.Errorf("[realtime] can't unmarshal polled record, err %w", err)
+			}
+			if next.seen[record.ID] == record.Updated {
+				continue
+			}
+			if record.Updated > next.since {
+				next.since = record.Updated
+				next.seen = map[string]string{}
+			}
+			next.seen[record.ID] = record.Updated
+
+			action := ActionUpdate
+			if record.Created == record.Updated {
+				action = ActionCreate
+			}
+			data, err := json.Marshal(struct {
+				Action Action          `json:"action"`
+				Record json.RawMessage `json:"record"`
+			}{action, raw})
+			if err != nil {
+				return nil, err
+			}
+
+			var e RealtimeEvent[T]
+			e.Error = e.decode(data, c.codec)
+			e.Topic = topic
+			e.Raw = data
+			events = append(events, e)
+		}
+
+		if page >= list.TotalPages {
+			*cursor = next
+			return events, nil
+		}
+		if next.since != since {
+			page = 1
+		} else {
+			page++
+		}
+	}
+}
+
+// pollList lists the records of a "collection", "collection/*" or "collection/id" topic,
+// with the query and headers of its options, see TopicWithOptions. The "filter" of the
+// options narrows the records, and the "fields" of the options keep the fields tracking
+// the changes, i.e. id, created and updated.
+func (c *Collection[T]) pollList(ctx context.Context, opts SubscribeOptions, topic string, query url.Values, result any) error {
+	topic, rawOptions, _ := strings.Cut(topic, "?")
+	var topicOpts TopicOptions
+	if rawOptions != "" {
+		values, err := url.ParseQuery(rawOptions)
+		if err != nil {
+			return fmt.Errorf("[realtime] can't parse the options of %s, err %w", topic, err)
+		}
+		if raw := values.Get("options"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &topicOpts); err != nil {
+				return fmt.Errorf("[realtime] can't parse the options of %s, err %w", topic, err)
+			}
+		}
+	}
+
+	var filters []string
+	collection, id, ok := strings.Cut(topic, "/")
+	if ok && id != "*" {
+		filters = append(filters, Filter("id = {:id}", map[string]any{"id": id}))
+	}
+	for _, f := range []string{query.Get("filter"), topicOpts.Query["filter"]} {
+		if f != "" {
+			filters = append(filters, "("+f+")")
+		}
+	}
+	if len(filters) > 0 {
+		query.Set("filter", strings.Join(filters, " && "))
+	}
+	for k, v := range topicOpts.Query {
+		switch {
+		case k == "filter":
+		case k == "fields":
+			if !query.Has(k) {
+				query.Set(k, v+",id,created,updated")
+			}
+		case !query.Has(k):
+			query.Set(k, v)
+		}
+	}
+	for k, v := range opts.Query {
+		if _, ok := query[k]; !ok {
+			query[k] = v
+		}
+	}
+	headers := opts.Headers
+	if len(topicOpts.Headers) > 0 {
+		headers = maps.Clone(opts.Headers)
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		maps.Copy(headers, topicOpts.Headers)
+	}
+
+	resp, err := c.Send(ctx, Request{
+		Path:    "/api/collections/" + url.PathEscape(collection) + "/records",
+		Query:   query,
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("[realtime] can't poll %s, err %w", topic, err)
+	}
+	return resp.Decode(result)
+}
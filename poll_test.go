@@ -0,0 +1,260 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_SubscribePoll(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	existing, err := collection.Create(map[string]any{"field": "existing"})
+	require.NoError(t, err)
+
+	stream, err := collection.SubscribeWith(SubscribeOptions{PollInterval: 100 * time.Millisecond})
+	require.NoError(t, err)
+	defer stream.Unsubscribe()
+	<-stream.Ready()
+	ch := stream.Events()
+	assert.Equal(t, []string{migrations.PostsPublic}, client.Realtime().Subscriptions())
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := collection.Create(map[string]any{"field": "polled"})
+		require.NoError(t, err)
+		e := <-ch
+		require.NoError(t, e.Error)
+		assert.Equal(t, ActionCreate, e.Action)
+		assert.Equal(t, resp.ID, e.Record["id"])
+		assert.Equal(t, "polled", e.Record["field"])
+		assert.Equal(t, migrations.PostsPublic, e.Topic)
+		assert.Contains(t, string(e.Raw), resp.ID)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		require.NoError(t, collection.Update(existing.ID, map[string]any{"field": "existing_updated"}))
+		e := <-ch
+		assert.Equal(t, ActionUpdate, e.Action)
+		assert.Equal(t, existing.ID, e.Record["id"])
+		assert.Equal(t, "existing_updated", e.Record["field"])
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		select {
+		case e := <-ch:
+			t.Errorf("unexpected event: %+v", e)
+		case <-time.After(300 * time.Millisecond):
+		}
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		stream.Unsubscribe()
+		if _, ok := <-ch; ok {
+			t.Error("unsubscribe is not working.")
+		}
+		assert.Empty(t, client.Realtime().Subscriptions())
+	})
+}
+
+func TestCollection_SubscribePollRecord(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	watched, err := collection.Create(map[string]any{"field": "watched"})
+	require.NoError(t, err)
+	other, err := collection.Create(map[string]any{"field": "other"})
+	require.NoError(t, err)
+
+	stream, err := collection.SubscribeWith(SubscribeOptions{PollInterval: 100 * time.Millisecond},
+		migrations.PostsPublic+"/"+watched.ID)
+	require.NoError(t, err)
+	defer stream.Unsubscribe()
+	ch := stream.Events()
+
+	require.NoError(t, collection.Update(other.ID, map[string]any{"field": "other_updated"}))
+	require.NoError(t, collection.Update(watched.ID, map[string]any{"field": "watched_updated"}))
+	e := <-ch
+	assert.Equal(t, ActionUpdate, e.Action)
+	assert.Equal(t, watched.ID, e.Record["id"])
+	assert.Equal(t, migrations.PostsPublic+"/"+watched.ID, e.Topic)
+}
+
+func TestCollection_SubscribePollWildcard(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	stream, err := collection.SubscribeWith(SubscribeOptions{
+		PollInterval: 100 * time.Millisecond,
+		TopicOptions: &TopicOptions{Query: map[string]string{"fields": "id,field"}},
+	}, migrations.PostsPublic+"/*")
+	require.NoError(t, err)
+	defer stream.Unsubscribe()
+	ch := stream.Events()
+
+	resp, err := collection.Create(map[string]any{"field": "wildcard"})
+	require.NoError(t, err)
+	e := <-ch
+	require.NoError(t, e.Error)
+	assert.Equal(t, ActionCreate, e.Action)
+	assert.Equal(t, resp.ID, e.Record["id"])
+	assert.Equal(t, "wildcard", e.Record["field"])
+	assert.NotContains(t, e.Record, "collectionName", "the fields of the topic shape the records")
+}
+
+func TestCollection_Poll_Pages(t *testing.T) {
+	type record struct{ id, updated string }
+	var (
+		mu       sync.Mutex
+		records  []record
+		requests int
+		failFrom int
+	)
+	add := func(n int) {
+		mu.Lock()
+		defer mu.Unlock()
+		for range n {
+			i := len(records)
+			records = append(records, record{fmt.Sprintf("r%03d", i), fmt.Sprintf("2024-01-01 00:%02d:%02d.000Z", i/60, i%60)})
+		}
+	}
+	since := regexp.MustCompile(`^\(updated >= '(.*)'\)$`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		q := r.URL.Query()
+		page, _ := strconv.Atoi(q.Get("page"))
+		if failFrom > 0 && requests >= failFrom {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var matched []string
+		for _, rec := range records { // sorted by updated and id
+			if rec.updated >= since.FindStringSubmatch(q.Get("filter"))[1] {
+				matched = append(matched, fmt.Sprintf(`{"id": %q, "created": "2024-01-01 00:00:00.000Z", "updated": %q}`, rec.id, rec.updated))
+			}
+		}
+		if requests == 1 {
+			// the first record is updated while the pages are listed
+			updated := records[0]
+			updated.updated = "2024-01-01 01:00:00.000Z"
+			records = append(records[1:], updated)
+		}
+		start, end := min((page-1)*200, len(matched)), min(page*200, len(matched))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"page": %d, "perPage": 200, "totalItems": %d, "totalPages": %d, "items": [%s]}`,
+			page, len(matched), (len(matched)+199)/200, strings.Join(matched[start:end], ","))
+	}))
+	t.Cleanup(srv.Close)
+	collection := CollectionSet[map[string]any](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+	ids := func(events []RealtimeEvent[map[string]any]) map[any]bool {
+		set := map[any]bool{}
+		for _, e := range events {
+			set[e.Record["id"]] = true
+		}
+		return set
+	}
+
+	add(450)
+	cursor := &pollCursor{since: "2024-01-01 00:00:00.000Z", seen: map[string]string{}}
+	events, err := collection.poll(context.Background(), SubscribeOptions{}, "posts", cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 450, len(ids(events)), "no record is skipped across the pages")
+	assert.Equal(t, 451, len(events), "the records are repeated only when updated")
+	assert.Equal(t, "2024-01-01 01:00:00.000Z", cursor.since)
+
+	events, err = collection.poll(context.Background(), SubscribeOptions{}, "posts", cursor)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	mu.Lock()
+	failFrom = requests + 2
+	mu.Unlock()
+	cursor = &pollCursor{since: "2024-01-01 00:00:00.000Z", seen: map[string]string{}}
+	_, err = collection.poll(context.Background(), SubscribeOptions{}, "posts", cursor)
+	require.Error(t, err)
+	assert.Equal(t, pollCursor{since: "2024-01-01 00:00:00.000Z", seen: map[string]string{}}, *cursor,
+		"the cursor doesn't advance on errors")
+}
+
+func TestCollection_PollTopics(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 200, "totalItems": 1, "totalPages": 1, "items": [
+			{"id": "p1", "created": "2024-01-01 00:00:00.000Z", "updated": "2024-01-01 00:00:01.000Z", "expand": {"author": {"id": "a1"}}}
+		]}`)
+	}))
+	t.Cleanup(srv.Close)
+	collection := CollectionSet[map[string]any](NewClient(srv.URL, WithRetry(0, 0, 0)), "posts")
+	opts := SubscribeOptions{Headers: map[string]string{"X-Proxy": "proxy"}}
+	last := func() *http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[len(requests)-1]
+	}
+
+	t.Run("expanded topic", func(t *testing.T) {
+		topic := TopicWithOptions("posts/*", TopicOptions{
+			Query:   map[string]string{"expand": "author", "fields": "*,expand.author.id", "filter": "a = 1 || b = 2"},
+			Headers: map[string]string{"X-Tenant": "t1"},
+		})
+		cursor := &pollCursor{since: "2024-01-01 00:00:00.000Z", seen: map[string]string{}}
+		events, err := collection.poll(context.Background(), opts, topic, cursor)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, map[string]any{"author": map[string]any{"id": "a1"}}, events[0].Record["expand"])
+		assert.Equal(t, topic, events[0].Topic)
+
+		r := last()
+		q := r.URL.Query()
+		assert.Equal(t, "(updated >= '2024-01-01 00:00:00.000Z') && (a = 1 || b = 2)", q.Get("filter"))
+		assert.Equal(t, "author", q.Get("expand"))
+		assert.Equal(t, "*,expand.author.id,id,created,updated", q.Get("fields"))
+		assert.Equal(t, "updated,id", q.Get("sort"))
+		assert.Equal(t, "t1", r.Header.Get("X-Tenant"))
+		assert.Equal(t, "proxy", r.Header.Get("X-Proxy"))
+
+		// the cursor only needs the tracking fields
+		_, err = collection.pollStart(context.Background(), opts, topic)
+		require.NoError(t, err)
+		assert.Equal(t, "id,created,updated", last().URL.Query().Get("fields"))
+		assert.Equal(t, "(a = 1 || b = 2)", last().URL.Query().Get("filter"))
+	})
+
+	t.Run("record topic", func(t *testing.T) {
+		cursor := &pollCursor{since: "2024-01-01 00:00:00.000Z", seen: map[string]string{}}
+		_, err := collection.poll(context.Background(), opts, "posts/p1", cursor)
+		require.NoError(t, err)
+		assert.Equal(t, "id = 'p1' && (updated >= '2024-01-01 00:00:00.000Z')", last().URL.Query().Get("filter"))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := collection.pollStart(context.Background(), opts, "posts?options=%7B")
+		assert.ErrorContains(t, err, "[realtime] can't parse the options of posts")
+	})
+}
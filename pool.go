@@ -0,0 +1,163 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// TenantConfig describes how to reach the PocketBase instance of a single tenant.
+	TenantConfig struct {
+		URL     string
+		Options []ClientOption
+	}
+
+	// TenantResolver returns the configuration of a tenant.
+	// It is called when a tenant is used for the first time or again after eviction.
+	TenantResolver func(tenant string) (TenantConfig, error)
+
+	// ClientPool manages one Client per tenant for services talking to many PocketBase
+	// instances or auth collections.
+	//
+	// Clients are created lazily, share a single HTTP transport and keep their own
+	// authorization (token store), so tenants never see each other's sessions.
+	ClientPool struct {
+		resolve   TenantResolver
+		transport http.RoundTripper
+		maxSize   int
+		idleTTL   time.Duration
+
+		mu      sync.Mutex
+		clients map[string]*poolEntry
+	}
+
+	// ClientPoolOption is a function type for configuring ClientPool instances.
+	ClientPoolOption func(*ClientPool)
+
+	poolEntry struct {
+		client   *Client
+		lastUsed time.Time
+	}
+)
+
+// NewClientPool creates a pool resolving tenant configurations with resolve.
+func NewClientPool(resolve TenantResolver, opts ...ClientPoolOption) *ClientPool {
+	p := &ClientPool{
+		resolve: resolve,
+		clients: map[string]*poolEntry{},
+	}
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		p.transport = t.Clone()
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithPoolTransport sets the HTTP transport shared by all clients of the pool.
+func WithPoolTransport(transport http.RoundTripper) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.transport = transport
+	}
+}
+
+// WithPoolMaxSize limits the number of pooled clients, the least recently used client is evicted first.
+func WithPoolMaxSize(size int) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.maxSize = size
+	}
+}
+
+// WithPoolIdleTTL evicts clients which were not used for the given duration.
+func WithPoolIdleTTL(ttl time.Duration) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.idleTTL = ttl
+	}
+}
+
+// Get returns the client of the tenant, creating it on first use.
+func (p *ClientPool) Get(tenant string) (*Client, error) {
+	now := time.Now()
+
+	p.mu.Lock()
+	if e, ok := p.clients[tenant]; ok && !p.expired(e, now) {
+		e.lastUsed = now
+		p.mu.Unlock()
+		return e.client, nil
+	}
+	p.mu.Unlock()
+
+	cfg, err := p.resolve(tenant)
+	if err != nil {
+		return nil, fmt.Errorf("[pool] can't resolve tenant %q, err %w", tenant, err)
+	}
+	opts := append([]ClientOption{WithTransport(p.transport)}, cfg.Options...)
+	client, err := NewClientE(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[pool] can't create client for tenant %q, err %w", tenant, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// another goroutine might have created the client in the meantime
+	if e, ok := p.clients[tenant]; ok && !p.expired(e, now) {
+		e.lastUsed = now
+		return e.client, nil
+	}
+	p.clients[tenant] = &poolEntry{client: client, lastUsed: now}
+	p.evict(now, tenant)
+
+	return client, nil
+}
+
+// Evict removes the client of the tenant, the next Get creates a new one.
+func (p *ClientPool) Evict(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, tenant)
+}
+
+// Tenants returns the sorted list of tenants with a pooled client.
+func (p *ClientPool) Tenants() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tenants := make([]string, 0, len(p.clients))
+	for tenant := range p.clients {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+func (p *ClientPool) expired(e *poolEntry, now time.Time) bool {
+	return p.idleTTL > 0 && now.Sub(e.lastUsed) > p.idleTTL
+}
+
+// evict drops expired clients and the least recently used ones above the max size,
+// except the client of the keep tenant. It must be called with the lock held.
+func (p *ClientPool) evict(now time.Time, keep string) {
+	for tenant, e := range p.clients {
+		if p.expired(e, now) {
+			delete(p.clients, tenant)
+		}
+	}
+
+	for p.maxSize > 0 && len(p.clients) > p.maxSize {
+		var oldest string
+		for tenant, e := range p.clients {
+			if tenant == keep {
+				continue
+			}
+			if oldest == "" || e.lastUsed.Before(p.clients[oldest].lastUsed) {
+				oldest = tenant
+			}
+		}
+		delete(p.clients, oldest)
+	}
+}
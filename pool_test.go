@@ -0,0 +1,89 @@
+package pocketbase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPool_Get(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	pool := NewClientPool(func(tenant string) (TenantConfig, error) {
+		switch tenant {
+		case "admin":
+			return TenantConfig{URL: defaultURL, Options: []ClientOption{
+				WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+			}}, nil
+		case "user":
+			return TenantConfig{URL: defaultURL, Options: []ClientOption{
+				WithUserEmailPassword(migrations.UserEmailPassword, migrations.UserEmailPassword),
+			}}, nil
+		}
+		return TenantConfig{}, errors.New("unknown tenant")
+	})
+
+	admin, err := pool.Get("admin")
+	require.NoError(t, err)
+	r, err := admin.List(migrations.PostsAdmin, ParamsList{})
+	assert.NoError(t, err)
+	assert.Positive(t, r.TotalItems)
+
+	user, err := pool.Get("user")
+	require.NoError(t, err)
+	_, err = user.List(migrations.PostsAdmin, ParamsList{})
+	assert.Error(t, err)
+	assert.NotEqual(t, admin.AuthStore().Token(), user.AuthStore().Token())
+
+	again, err := pool.Get("admin")
+	require.NoError(t, err)
+	assert.Same(t, admin, again)
+	assert.Equal(t, []string{"admin", "user"}, pool.Tenants())
+
+	_, err = pool.Get("unknown")
+	assert.Error(t, err)
+}
+
+func TestClientPool_Eviction(t *testing.T) {
+	resolved := map[string]int{}
+	pool := NewClientPool(func(tenant string) (TenantConfig, error) {
+		resolved[tenant]++
+		return TenantConfig{URL: "http://" + tenant + ".example.com"}, nil
+	}, WithPoolMaxSize(2), WithPoolIdleTTL(time.Hour))
+
+	a, err := pool.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "http://a.example.com", a.url)
+	_, _ = pool.Get("b")
+	_, _ = pool.Get("a") // a is now the most recently used
+	_, _ = pool.Get("c")
+	assert.Equal(t, []string{"a", "c"}, pool.Tenants())
+
+	pool.Evict("a")
+	assert.Equal(t, []string{"c"}, pool.Tenants())
+	_, _ = pool.Get("a")
+	assert.Equal(t, 2, resolved["a"])
+
+	t.Run("idle ttl", func(t *testing.T) {
+		pool := NewClientPool(func(tenant string) (TenantConfig, error) {
+			return TenantConfig{URL: "http://" + tenant + ".example.com"}, nil
+		}, WithPoolIdleTTL(time.Nanosecond))
+		first, _ := pool.Get("a")
+		time.Sleep(time.Millisecond)
+		second, _ := pool.Get("a")
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		pool := NewClientPool(func(string) (TenantConfig, error) {
+			return TenantConfig{URL: "no-scheme"}, nil
+		})
+		_, err := pool.Get("a")
+		assert.ErrorIs(t, err, ErrInvalidURL)
+	})
+}
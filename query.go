@@ -0,0 +1,106 @@
+package pocketbase
+
+import (
+	"context"
+	"strings"
+)
+
+// Query is a chainable list query of a collection, compiled to ParamsList.
+type Query[T any] struct {
+	collection *Collection[T]
+	filters    []string
+	sort       []string
+	expand     []string
+	fields     []string
+	limit      int
+}
+
+// Query starts a list query of the collection:
+//
+//	posts, err := collection.Query().
+//		Where(pocketbase.Filter("author = {:author}", map[string]any{"author": id})).
+//		OrderBy("-created").
+//		Limit(10).
+//		Expand("author").
+//		All(ctx)
+func (c *Collection[T]) Query() *Query[T] {
+	return &Query[T]{collection: c}
+}
+
+// Where adds a filter the records must match, in addition to the previous ones.
+func (q *Query[T]) Where(filter string) *Query[T] {
+	if filter != "" {
+		q.filters = append(q.filters, filter)
+	}
+	return q
+}
+
+// OrderBy adds sort fields, descending with a "-" prefix, e.g. "-created".
+func (q *Query[T]) OrderBy(fields ...string) *Query[T] {
+	q.sort = append(q.sort, fields...)
+	return q
+}
+
+// Limit limits the number of records; 0 means all records.
+func (q *Query[T]) Limit(n int) *Query[T] {
+	q.limit = n
+	return q
+}
+
+// Expand adds relations to expand, e.g. "author" or "comments_via_post.author".
+func (q *Query[T]) Expand(relations ...string) *Query[T] {
+	q.expand = append(q.expand, relations...)
+	return q
+}
+
+// Fields limits the returned fields, e.g. "id" or "expand.author.name".
+func (q *Query[T]) Fields(fields ...string) *Query[T] {
+	q.fields = append(q.fields, fields...)
+	return q
+}
+
+// Params returns the list params of the query, without the limit.
+func (q *Query[T]) Params() ParamsList {
+	var filter string
+	if len(q.filters) == 1 {
+		filter = q.filters[0]
+	} else if len(q.filters) > 1 {
+		filter = "(" + strings.Join(q.filters, ") && (") + ")"
+	}
+	return ParamsList{
+		Filters: filter,
+		Sort:    strings.Join(q.sort, ","),
+		Expand:  strings.Join(q.expand, ","),
+		Fields:  strings.Join(q.fields, ","),
+	}
+}
+
+// All fetches the records matching the query, up to the limit.
+func (q *Query[T]) All(ctx context.Context) ([]T, error) {
+	params := q.Params()
+	params.Page = 1
+	params.Size = 500
+	if q.limit > 0 && q.limit < params.Size {
+		params.Size = q.limit
+	}
+
+	ctx, cancel := q.collection.callContext(q.collection.sizedContext(ctx))
+	defer cancel()
+
+	var items []T
+	for {
+		r, err := q.collection.list(ctx, params)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, r.Items...)
+
+		if q.limit > 0 && len(items) >= q.limit {
+			return items[:q.limit], nil
+		}
+		if params.Page >= r.TotalPages {
+			return items, nil
+		}
+		params.Page++
+	}
+}
@@ -0,0 +1,67 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_Params(t *testing.T) {
+	collection := CollectionSet[map[string]any](NewClient(defaultURL), migrations.PostsPublic)
+
+	params := collection.Query().
+		Where("a = 1").
+		Where("").
+		Where("b = 2 || c = 3").
+		OrderBy("-created").
+		OrderBy("id").
+		Expand("author", "tags").
+		Fields("id").
+		Limit(5).
+		Params()
+	assert.Equal(t, ParamsList{
+		Filters: "(a = 1) && (b = 2 || c = 3)",
+		Sort:    "-created,id",
+		Expand:  "author,tags",
+		Fields:  "id",
+	}, params)
+
+	assert.Equal(t, "a = 1", collection.Query().Where("a = 1").Params().Filters)
+}
+
+func TestQuery_All(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id"`
+		Field string `json:"field"`
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	collection := CollectionSet[post](client, migrations.PostsPublic)
+	for i := range 3 {
+		created, err := collection.Create(post{Field: fmt.Sprintf("query_%d", i)})
+		require.NoError(t, err)
+		defer func() {
+			_ = collection.Delete(created.ID)
+		}()
+	}
+
+	posts, err := collection.Query().
+		Where(Filter("field ~ {:prefix}", map[string]any{"prefix": "query_"})).
+		OrderBy("-field").
+		Limit(2).
+		All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, "query_2", posts[0].Field)
+	assert.Equal(t, "query_1", posts[1].Field)
+
+	posts, err = collection.Query().Where("field ~ 'query_'").All(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, posts, 3)
+}
@@ -0,0 +1,160 @@
+package pocketbase
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Realtime manages the realtime subscriptions of a client.
+//
+// Every stream created by Subscribe or SubscribeWith registers itself here, so
+// topics can be listed and removed without keeping track of the streams. Removing
+// topics updates the server-side subscription set over the existing connection;
+// a stream without topics left is closed.
+//
+// The lifecycle hooks OnConnect, OnDisconnect and OnSubscriptionError are called
+// for the connections of all streams of the client, e.g. to emit metrics or to
+// resync state after the stream degraded.
+type Realtime struct {
+	mu      sync.Mutex
+	streams map[subscription]struct{}
+
+	onConnect           []func(clientID string)
+	onDisconnect        []func(err error)
+	onSubscriptionError []func(topic string, err error)
+}
+
+// subscription is the type-independent part of a Stream.
+type subscription interface {
+	Topics() []string
+	Unsubscribe()
+	unsubscribeTopics(match func(topic string) bool) error
+}
+
+func newRealtime() *Realtime {
+	return &Realtime{
+		streams: map[subscription]struct{}{},
+	}
+}
+
+func (r *Realtime) add(s subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.streams[s] = struct{}{}
+}
+
+func (r *Realtime) remove(s subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.streams, s)
+}
+
+func (r *Realtime) subscriptions() []subscription {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	streams := make([]subscription, 0, len(r.streams))
+	for s := range r.streams {
+		streams = append(streams, s)
+	}
+	return streams
+}
+
+// Subscriptions returns the sorted topics of all active subscriptions.
+func (r *Realtime) Subscriptions() []string {
+	var topics []string
+	for _, s := range r.subscriptions() {
+		topics = append(topics, s.Topics()...)
+	}
+	slices.Sort(topics)
+	return slices.Compact(topics)
+}
+
+// Unsubscribe removes the topic from all active subscriptions, including the
+// variants of the topic with options, e.g. "posts?options=...".
+func (r *Realtime) Unsubscribe(topic string) error {
+	return r.unsubscribe(func(t string) bool {
+		return t == topic || strings.HasPrefix(t, topic+"?")
+	})
+}
+
+// UnsubscribeByPrefix removes all topics starting with prefix from the active subscriptions.
+func (r *Realtime) UnsubscribeByPrefix(prefix string) error {
+	return r.unsubscribe(func(t string) bool {
+		return strings.HasPrefix(t, prefix)
+	})
+}
+
+// UnsubscribeAll closes all active subscriptions.
+func (r *Realtime) UnsubscribeAll() {
+	for _, s := range r.subscriptions() {
+		s.Unsubscribe()
+	}
+}
+
+func (r *Realtime) unsubscribe(match func(topic string) bool) error {
+	var errs []error
+	for _, s := range r.subscriptions() {
+		if err := s.unsubscribeTopics(match); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OnConnect registers a hook called after a realtime connection is established
+// and its topics are subscribed, including reconnects.
+//
+// Hooks are called synchronously from the connection goroutine and must not block.
+func (r *Realtime) OnConnect(fn func(clientID string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onConnect = append(r.onConnect, fn)
+}
+
+// OnDisconnect registers a hook called when an established realtime connection ends.
+//
+// err is nil when the connection was closed by Unsubscribe.
+func (r *Realtime) OnDisconnect(fn func(err error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onDisconnect = append(r.onDisconnect, fn)
+}
+
+// OnSubscriptionError registers a hook called for every topic of a subscription set
+// PocketBase failed to accept.
+func (r *Realtime) OnSubscriptionError(fn func(topic string, err error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onSubscriptionError = append(r.onSubscriptionError, fn)
+}
+
+func (r *Realtime) connected(clientID string) {
+	r.mu.Lock()
+	hooks := slices.Clone(r.onConnect)
+	r.mu.Unlock()
+	for _, fn := range hooks {
+		fn(clientID)
+	}
+}
+
+func (r *Realtime) disconnected(err error) {
+	r.mu.Lock()
+	hooks := slices.Clone(r.onDisconnect)
+	r.mu.Unlock()
+	for _, fn := range hooks {
+		fn(err)
+	}
+}
+
+func (r *Realtime) subscriptionFailed(topics []string, err error) {
+	r.mu.Lock()
+	hooks := slices.Clone(r.onSubscriptionError)
+	r.mu.Unlock()
+	for _, fn := range hooks {
+		for _, topic := range topics {
+			fn(topic, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package pocketbase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRealtime_Unsubscribe(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	defaultBody := map[string]interface{}{
+		"field": "value_" + time.Now().Format(time.StampMilli),
+	}
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	stream, err := collection.Subscribe(migrations.PostsPublic, migrations.PostsPublic+"/unknown")
+	require.NoError(t, err)
+	defer stream.Unsubscribe()
+	<-stream.Ready()
+	ch := stream.Events()
+
+	assert.Equal(t, []string{migrations.PostsPublic, migrations.PostsPublic + "/unknown"}, client.Realtime().Subscriptions())
+
+	_, err = collection.Create(defaultBody)
+	require.NoError(t, err)
+	e := <-ch
+	assert.Equal(t, ActionCreate, e.Action)
+
+	require.NoError(t, client.Realtime().Unsubscribe(migrations.PostsPublic))
+	assert.Equal(t, []string{migrations.PostsPublic + "/unknown"}, client.Realtime().Subscriptions())
+	assert.Equal(t, []string{migrations.PostsPublic + "/unknown"}, stream.Topics())
+
+	_, err = collection.Create(defaultBody)
+	require.NoError(t, err)
+	select {
+	case e := <-ch:
+		t.Errorf("unexpected event after unsubscribe: %+v", e)
+	case <-time.After(time.Second):
+	}
+
+	require.NoError(t, client.Realtime().UnsubscribeByPrefix(migrations.PostsPublic+"/"))
+	assert.Empty(t, client.Realtime().Subscriptions())
+	if _, ok := <-ch; ok {
+		t.Error("stream without topics is not closed")
+	}
+}
+
+func TestRealtime_UnsubscribeAll(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+
+	first, err := collection.Subscribe()
+	require.NoError(t, err)
+	second, err := collection.Subscribe(migrations.PostsPublic + "/unknown")
+	require.NoError(t, err)
+	<-first.Ready()
+	<-second.Ready()
+	firstCh, secondCh := first.Events(), second.Events()
+
+	// clones don't share the subscriptions
+	assert.Empty(t, client.Clone().Realtime().Subscriptions())
+	assert.Equal(t, []string{migrations.PostsPublic, migrations.PostsPublic + "/unknown"}, client.Realtime().Subscriptions())
+
+	client.Realtime().UnsubscribeAll()
+	assert.Empty(t, client.Realtime().Subscriptions())
+	if _, ok := <-firstCh; ok {
+		t.Error("first stream is not closed")
+	}
+	if _, ok := <-secondCh; ok {
+		t.Error("second stream is not closed")
+	}
+}
+
+func TestRealtime_Hooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+
+	connected := make(chan string, 1)
+	disconnected := make(chan error, 1)
+	var failed []string
+	client.Realtime().OnConnect(func(clientID string) { connected <- clientID })
+	client.Realtime().OnDisconnect(func(err error) { disconnected <- err })
+	client.Realtime().OnSubscriptionError(func(topic string, err error) {
+		assert.Error(t, err)
+		failed = append(failed, topic)
+	})
+
+	t.Run("connect and disconnect", func(t *testing.T) {
+		stream, err := collection.Subscribe()
+		require.NoError(t, err)
+		<-stream.Ready()
+		assert.NotEmpty(t, <-connected)
+
+		stream.Unsubscribe()
+		assert.NoError(t, <-disconnected)
+	})
+
+	t.Run("subscription error", func(t *testing.T) {
+		// PocketBase rejects topics longer than 2500 characters
+		topic := strings.Repeat("x", 2501)
+		_, err := collection.Subscribe(topic)
+		assert.Error(t, err)
+		assert.Equal(t, []string{topic}, failed)
+	})
+}
@@ -1,7 +1,6 @@
 package pocketbase
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/url"
 )
@@ -43,14 +42,14 @@ func (c *Collection[T]) ListAuthMethods22() (AuthMethod, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[records] pocketbase returned status: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[records] can't unmarshal response, err %w", err)
 	}
 	return response, nil
@@ -125,14 +124,14 @@ func (c *Collection[T]) ListAuthMethods() (AuthMethodsResponse, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[records] pocketbase returned status: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[records] can't unmarshal response, err %w", err)
 	}
 	return response, nil
@@ -186,14 +185,14 @@ func (c *Collection[T]) AuthWithPassword(username string, password string) (Auth
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[records] pocketbase returned status at auth-with-password: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at auth-with-password: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[records] can't unmarshal auth-with-password-response, err %w", err)
 	}
 
@@ -237,14 +236,14 @@ func (c *Collection[T]) AuthWithOAuth2Code(provider string, code string, codeVer
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[records] pocketbase returned status at auth-with-oauth2: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at auth-with-oauth2: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[records] can't unmarshal auth-with-oauth2-response, err %w", err)
 	}
 
@@ -288,14 +287,14 @@ func (c *Collection[T]) AuthRefresh() (AuthRefreshResponse, error) {
 	}
 
 	if resp.IsError() {
-		return response, fmt.Errorf("[records] pocketbase returned status at auth-refresh: %d, msg: %s, err %w",
+		return response, newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at auth-refresh: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[records] can't unmarshal auth-refresh-response, err %w", err)
 	}
 
@@ -320,11 +319,11 @@ func (c *Collection[T]) RequestVerification(email string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at request-verification: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at request-verification: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
@@ -349,11 +348,11 @@ func (c *Collection[T]) ConfirmVerification(verificationToken string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at confirm-verification: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at confirm-verification: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
@@ -375,11 +374,11 @@ func (c *Collection[T]) RequestPasswordReset(email string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at request-password-reset: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at request-password-reset: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
@@ -403,11 +402,11 @@ func (c *Collection[T]) ConfirmPasswordReset(passwordResetToken string, password
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at confirm-password-reset: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at confirm-password-reset: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
@@ -431,11 +430,11 @@ func (c *Collection[T]) RequestEmailChange(newEmail string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at request-email-change: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at request-email-change: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
@@ -460,11 +459,11 @@ func (c *Collection[T]) ConfirmEmailChange(emailChangeToken string, password str
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at confirm-email-change: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at confirm-email-change: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
@@ -498,12 +497,12 @@ func (c *Collection[T]) ListExternalAuths22(recordID string) ([]ExternalAuthRequ
 	if resp.IsError() {
 		return response, fmt.Errorf("[records] pocketbase request for list external-auths returned status: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
 		)
 	}
 
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+	if err := c.codec.unmarshalJSON(resp.Body(), &response); err != nil {
 		return response, fmt.Errorf("[records] can't unmarshal list external-auths response, err %w", err)
 	}
 	return response, nil
@@ -524,11 +523,11 @@ func (c *Collection[T]) UnlinkExternalAuth22(recordID string, provider string) e
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("[records] pocketbase returned status at unlink-external-auth-: %d, msg: %s, err %w",
+		return newAPIError(resp, fmt.Errorf("[records] pocketbase returned status at unlink-external-auth-: %d, msg: %s, err %w",
 			resp.StatusCode(),
-			resp.String(),
+			responseBody(resp),
 			ErrInvalidResponse,
-		)
+		))
 	}
 	return nil
 }
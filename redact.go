@@ -0,0 +1,180 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// redacted replaces the redacted values.
+const redacted = "REDACTED"
+
+// Redaction configures the redaction of the bodies embedded in the error messages and the
+// debug logs of a client, which can hold personal data and credentials.
+type Redaction struct {
+	// Fields are the patterns of the JSON fields whose values are replaced by REDACTED, at
+	// any depth, matched case-insensitively with path.Match, e.g. "email" or "*password*".
+	Fields []string
+	// MaxBodyLength truncates the longer bodies, after the redaction of their fields, to
+	// keep the messages short; 0 keeps them whole.
+	MaxBodyLength int
+}
+
+// DefaultRedaction is the redaction of the clients without WithRedaction. It redacts the
+// credentials, e.g. the auth tokens, passwords and OAuth2 client secrets, and keeps the
+// bodies whole.
+var DefaultRedaction = Redaction{Fields: []string{"token", "*password*", "*secret"}}
+
+// WithRedaction replaces the redaction of the bodies embedded in the error messages and the
+// debug logs of the client, e.g. to redact personal data and truncate the long bodies:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithRedaction(pocketbase.Redaction{
+//		Fields:        append([]string{"email", "phone"}, pocketbase.DefaultRedaction.Fields...),
+//		MaxBodyLength: 512,
+//	}))
+//
+// The fields are redacted from the JSON bodies only. The HAR recordings redact the fields
+// of the redaction as well, but keep the bodies whole.
+func WithRedaction(redaction Redaction) ClientOption {
+	return func(c *Client) {
+		c.redaction = redaction
+	}
+}
+
+// matches reports whether the field is redacted.
+func (r Redaction) matches(name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range r.Fields {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redact returns the body with the values of its redacted fields replaced, when it is
+// JSON, and truncated to the maximum length.
+func (r Redaction) redact(body []byte) string {
+	s := string(body)
+	var v any
+	if len(r.Fields) > 0 && json.Unmarshal(body, &v) == nil && r.redactJSON(v) {
+		if raw, err := json.Marshal(v); err == nil {
+			s = string(raw)
+		}
+	}
+	return r.truncate(s)
+}
+
+// redactValue returns a request body marshaled as JSON, with its redacted fields replaced.
+func (r Redaction) redactValue(body any) string {
+	switch b := body.(type) {
+	case []byte:
+		return r.redact(b)
+	case string:
+		return r.redact([]byte(b))
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return r.truncate(fmt.Sprintf("%+v", body))
+	}
+	return r.redact(raw)
+}
+
+// redactJSON replaces the values of the redacted fields of an unmarshaled JSON value, and
+// reports whether it replaced one. The field errors of the error responses are kept, e.g.
+// {"data": {"password": {"code": "validation_required", "message": "Missing required value."}}}.
+func (r Redaction) redactJSON(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if r.matches(key) && !isFieldError(value) {
+				v[key] = redacted
+				changed = true
+			} else if r.redactJSON(value) {
+				changed = true
+			}
+		}
+	case []any:
+		for i := range v {
+			if r.redactJSON(v[i]) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// isFieldError reports whether an unmarshaled JSON value is a field error of PocketBase.
+func isFieldError(v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 2 {
+		return false
+	}
+	_, code := m["code"].(string)
+	_, message := m["message"].(string)
+	return code && message
+}
+
+// truncate cuts the text to the maximum length, on a rune boundary.
+func (r Redaction) truncate(s string) string {
+	if r.MaxBodyLength <= 0 || len(s) <= r.MaxBodyLength {
+		return s
+	}
+	kept := strings.ToValidUTF8(s[:r.MaxBodyLength], "")
+	return fmt.Sprintf("%s... (%d bytes truncated)", kept, len(s)-len(kept))
+}
+
+// redactHeader returns a copy of the headers with the credentials redacted.
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for name := range header {
+		switch strings.ToLower(name) {
+		case "authorization", "cookie", "set-cookie":
+			header[name] = []string{redacted}
+		}
+	}
+	return header
+}
+
+type redactionKey struct{}
+
+// bindRedaction passes the redaction of the client to the error messages of the request.
+func (c *Client) bindRedaction(_ *resty.Client, r *resty.Request) error {
+	if r.Context().Value(redactionKey{}) == nil {
+		r.SetContext(context.WithValue(r.Context(), redactionKey{}, c.redaction))
+	}
+	return nil
+}
+
+// requestRedaction returns the redaction of the client of the request.
+func requestRedaction(r *resty.Request) Redaction {
+	if redaction, ok := r.Context().Value(redactionKey{}).(Redaction); ok {
+		return redaction
+	}
+	return DefaultRedaction
+}
+
+// responseBody returns the body of an error response for the error messages, redacted.
+func responseBody(resp *resty.Response) string {
+	return requestRedaction(resp.Request).redact(resp.Body())
+}
+
+// redactLogs redacts the debug logs of the requests and responses.
+func (c *Client) redactLogs() {
+	c.client.OnRequestLog(func(l *resty.RequestLog) error {
+		l.Header = redactHeader(l.Header)
+		l.Body = c.redaction.redact([]byte(l.Body))
+		return nil
+	})
+	c.client.OnResponseLog(func(l *resty.ResponseLog) error {
+		l.Header = redactHeader(l.Header)
+		l.Body = c.redaction.redact([]byte(l.Body))
+		return nil
+	})
+}
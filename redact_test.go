@@ -0,0 +1,81 @@
+package pocketbase
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedaction_Redact(t *testing.T) {
+	tests := []struct {
+		name      string
+		redaction Redaction
+		body      string
+		want      string
+	}{
+		{"credentials", DefaultRedaction,
+			`{"token": "t", "record": {"password": "p", "oldPassword": "o", "email": "a@b.c"}, "providers": [{"clientSecret": "s"}]}`,
+			`{"providers":[{"clientSecret":"REDACTED"}],"record":{"email":"a@b.c","oldPassword":"REDACTED","password":"REDACTED"},"token":"REDACTED"}`},
+		{"field errors", DefaultRedaction,
+			`{"data": {"token": {"code": "validation_invalid_token", "message": "Invalid token."}, "password": "p"}}`,
+			`{"data":{"password":"REDACTED","token":{"code":"validation_invalid_token","message":"Invalid token."}}}`},
+		{"unchanged body kept as is", DefaultRedaction, `{"message": "Not found.", "data": {}}`, `{"message": "Not found.", "data": {}}`},
+		{"patterns", Redaction{Fields: []string{"EMAIL", "phone_*"}}, `{"email": "a@b.c", "phone_home": "1", "phone": "2"}`,
+			`{"email":"REDACTED","phone":"2","phone_home":"REDACTED"}`},
+		{"not json", DefaultRedaction, `token=abc`, `token=abc`},
+		{"truncated", Redaction{MaxBodyLength: 10}, `{"message": "Something went wrong."}`, `{"message"... (26 bytes truncated)`},
+		{"truncated on a rune", Redaction{MaxBodyLength: 1}, `é!`, `... (3 bytes truncated)`},
+		{"truncated after redaction", Redaction{Fields: []string{"message"}, MaxBodyLength: 20}, `{"message": "Something went wrong."}`, `{"message":"REDACTED... (2 bytes truncated)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.redaction.redact([]byte(tt.body)))
+		})
+	}
+}
+
+func TestWithRedaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, `{"status": 400, "message": "Failed to create record.", "data": {"email": {"code": "validation_invalid_email", "message": "Must be a valid email address."}}, "email": "ann@example.com"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	_, err := client.Create("users", map[string]any{"email": "ann@example.com", "password": "hunter22"})
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+	assert.NotContains(t, err.Error(), "hunter22")
+	assert.Contains(t, err.Error(), "ann@example.com")
+	assert.Contains(t, err.Error(), `"password":"REDACTED"`)
+
+	var logs bytes.Buffer
+	client = NewClient(srv.URL, WithRetry(0, 0, 0), WithRestDebug(), WithRedaction(Redaction{
+		Fields:        append([]string{"email"}, DefaultRedaction.Fields...),
+		MaxBodyLength: 60,
+	}))
+	client.client.SetLogger(restyLogger{log.New(&logs, "", 0)})
+	_, err = client.Create("users", map[string]any{"email": "ann@example.com", "password": "hunter22"})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "ann@example.com")
+	assert.Contains(t, err.Error(), "bytes truncated")
+	assert.NotContains(t, logs.String(), "ann@example.com")
+	assert.NotContains(t, logs.String(), "hunter22")
+	assert.Contains(t, logs.String(), "/api/collections/users/records")
+}
+
+// restyLogger logs resty messages to a standard logger.
+type restyLogger struct {
+	*log.Logger
+}
+
+func (l restyLogger) Errorf(format string, v ...any) { l.Printf(format, v...) }
+func (l restyLogger) Warnf(format string, v ...any)  { l.Printf(format, v...) }
+func (l restyLogger) Debugf(format string, v ...any) { l.Printf(format, v...) }
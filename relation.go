@@ -0,0 +1,245 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// relationField is a struct field preloaded with an expanded relation, tagged e.g.
+//
+//	Author User `pb:"relation=author,collection=users"`
+type relationField struct {
+	index []int
+	// key is the JSON key of the field, dropped from the record before decoding.
+	key string
+	// relation is the relation field, or back-relation e.g. comments_via_post, to expand.
+	relation string
+	// collection is the collection of the related records, for the error messages.
+	collection string
+}
+
+// relationFieldsCache caches the relation fields by struct type.
+var relationFieldsCache sync.Map
+
+// relationFields returns the relation fields of the struct type, nil for other types.
+func relationFields(t reflect.Type) []relationField {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	if cached, ok := relationFieldsCache.Load(t); ok {
+		return cached.([]relationField)
+	}
+
+	var fields []relationField
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("pb")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		field := relationField{index: f.Index, key: f.Name}
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == "-" {
+			field.key = ""
+		} else if name != "" {
+			field.key = name
+		}
+		_, options := parsePBTag(tag)
+		field.relation, field.collection = options["relation"], options["collection"]
+		if field.relation != "" {
+			fields = append(fields, field)
+		}
+	}
+	relationFieldsCache.Store(t, fields)
+	return fields
+}
+
+// relationType returns the struct type of the related records of a field type,
+// e.g. User for *User or []User.
+func relationType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// relationExpand returns the expand paths preloading the relation fields of the type,
+// nested relations included, e.g. author and author.team.
+func relationExpand(t reflect.Type) []string {
+	return appendRelationExpand(nil, t, "", map[reflect.Type]bool{})
+}
+
+func appendRelationExpand(paths []string, t reflect.Type, prefix string, visiting map[reflect.Type]bool) []string {
+	// recursive types, e.g. a parent relation, only preload their first level
+	if visiting[t] {
+		return paths
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for _, f := range relationFields(t) {
+		path := prefix + f.relation
+		paths = append(paths, path)
+		paths = appendRelationExpand(paths, relationType(t.FieldByIndex(f.index).Type), path+".", visiting)
+	}
+	return paths
+}
+
+// withRelations adds the expand paths of the relation fields of the type to params.
+func withRelations(params ParamsList, t reflect.Type) ParamsList {
+	paths := relationExpand(t)
+	if len(paths) == 0 {
+		return params
+	}
+	expand := map[string]bool{}
+	if params.Expand != "" {
+		for _, path := range strings.Split(params.Expand, ",") {
+			expand[strings.TrimSpace(path)] = true
+		}
+	}
+	for _, path := range paths {
+		if !expand[path] {
+			expand[path] = true
+			if params.Expand != "" {
+				params.Expand += ","
+			}
+			params.Expand += path
+		}
+	}
+	return params
+}
+
+// decode unmarshals the record into v, moving its expanded relations into the
+// relation fields of the struct.
+func (rc *recordCodec) decode(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return rc.unmarshalJSON(data, v)
+	}
+	return rc.decodeValue(data, rv.Elem())
+}
+
+// isRecordType reports whether the struct type is decoded by the codec rather than
+// by its JSON tags, for its relation fields, field mapping, Extras, time fields or strict
+// decoding.
+func (rc *recordCodec) isRecordType(t reflect.Type) bool {
+	if rc.strict && t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return true
+	}
+	return len(relationFields(t)) > 0 || rc.fieldMapping(t) != nil || extrasField(t) != nil || len(rc.timeFields(t)) > 0
+}
+
+// decodeValue unmarshals data into the value, decoding the records of slices and
+// pointers of structs with relation fields or a field mapping.
+func (rc *recordCodec) decodeValue(data []byte, v reflect.Value) error {
+	if !rc.isRecordType(relationType(v.Type())) || string(data) == "null" {
+		return rc.unmarshalJSON(data, v.Addr().Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return rc.decodeValue(data, v.Elem())
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := rc.unmarshalJSON(data, &items); err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+		for i, item := range items {
+			if err := rc.decodeValue(item, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var record map[string]json.RawMessage
+	if err := rc.unmarshalJSON(data, &record); err != nil {
+		return err
+	}
+	var expand map[string]json.RawMessage
+	if raw, ok := record["expand"]; ok {
+		if err := rc.unmarshalJSON(raw, &expand); err != nil {
+			return err
+		}
+	}
+
+	if err := rc.decodeTimes(record, v.Type()); err != nil {
+		return err
+	}
+
+	if extras := extrasField(v.Type()); extras != nil {
+		if err := rc.decodeExtras(record, rc.unknownFields(record, v.Type()), v, extras); err != nil {
+			return err
+		}
+	} else if rc.strict {
+		if err := checkUnknownFields(rc.unknownFields(record, v.Type()), v.Type()); err != nil {
+			return err
+		}
+	}
+
+	fields := relationFields(v.Type())
+	if mapping := rc.fieldMapping(v.Type()); mapping != nil {
+		if err := rc.decodeMapped(record, v, mapping); err != nil {
+			return err
+		}
+	} else {
+		// the relation fields can't hold the related ids, e.g. author, so their keys are dropped
+		for _, f := range fields {
+			for key := range record {
+				if f.key != "" && strings.EqualFold(key, f.key) && !claimedKey(v.Type(), key) {
+					delete(record, key)
+				}
+			}
+		}
+		stripped, err := rc.marshalJSON(record)
+		if err != nil {
+			return err
+		}
+		if err := rc.unmarshalJSON(stripped, v.Addr().Interface()); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range fields {
+		raw, ok := expand[f.relation]
+		if !ok {
+			continue
+		}
+		if err := rc.decodeValue(raw, v.FieldByIndex(f.index)); err != nil {
+			if f.collection != "" {
+				return fmt.Errorf("can't decode relation %s to %s, err %w", f.relation, f.collection, err)
+			}
+			return fmt.Errorf("can't decode relation %s, err %w", f.relation, err)
+		}
+	}
+	return nil
+}
+
+// claimedKey reports whether a non-relation field of the struct type has exactly the JSON key,
+// e.g. AuthorID `json:"author"` next to Author `pb:"relation=author"`.
+func claimedKey(t reflect.Type, key string) bool {
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if name != key {
+			continue
+		}
+		for _, r := range relationFields(t) {
+			if len(r.index) == len(f.Index) && reflect.DeepEqual(r.index, f.Index) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package pocketbase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type relationTeam struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type relationUser struct {
+	ID   string        `json:"id"`
+	Name string        `json:"name"`
+	Team *relationTeam `pb:"relation=team,collection=teams"`
+}
+
+type relationPost struct {
+	ID       string         `json:"id"`
+	AuthorID string         `json:"author"`
+	Author   relationUser   `pb:"relation=author,collection=users"`
+	Tags     []relationTeam `json:"-" pb:"relation=tags"`
+	Parent   *relationPost  `pb:"relation=parent"`
+}
+
+func TestRelationExpand(t *testing.T) {
+	assert.Equal(t, []string{"author", "author.team", "tags", "parent"}, relationExpand(reflect.TypeFor[relationPost]()))
+	assert.Empty(t, relationExpand(reflect.TypeFor[map[string]any]()))
+
+	params := withRelations(ParamsList{Expand: "tags,editor"}, reflect.TypeFor[relationPost]())
+	assert.Equal(t, "tags,editor,author,author.team,parent", params.Expand)
+}
+
+func TestRecordCodec_Decode(t *testing.T) {
+	var post relationPost
+	err := defaultCodec.decode([]byte(`{
+		"id": "p1",
+		"author": "u1",
+		"tags": ["t1", "t2"],
+		"parent": "",
+		"expand": {
+			"author": {"id": "u1", "name": "ann", "team": "t1", "expand": {"team": {"id": "t1", "name": "core"}}},
+			"tags": [{"id": "t1", "name": "core"}, {"id": "t2", "name": "docs"}]
+		}
+	}`), &post)
+	require.NoError(t, err)
+	assert.Equal(t, relationPost{
+		ID:       "p1",
+		AuthorID: "u1",
+		Author: relationUser{
+			ID:   "u1",
+			Name: "ann",
+			Team: &relationTeam{ID: "t1", Name: "core"},
+		},
+		Tags: []relationTeam{{ID: "t1", Name: "core"}, {ID: "t2", Name: "docs"}},
+	}, post)
+
+	var user relationUser
+	require.NoError(t, defaultCodec.decode([]byte(`{"id": "u2", "team": "t1"}`), &user))
+	assert.Equal(t, relationUser{ID: "u2"}, user)
+
+	err = defaultCodec.decode([]byte(`{"id": "p2", "expand": {"author": "u1"}}`), &post)
+	assert.ErrorContains(t, err, "relation author to users")
+}
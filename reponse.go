@@ -1,5 +1,11 @@
 package pocketbase
 
+import (
+	"encoding/json"
+	"iter"
+	"slices"
+)
+
 // ResponseList represents a paginated list response from PocketBase.
 type ResponseList[T any] struct {
 	Page       int `json:"page"`
@@ -10,9 +16,120 @@ type ResponseList[T any] struct {
 }
 
 // ResponseCreate represents the response from creating a new record.
-type ResponseCreate struct {
+//
+// Record is the server's canonical representation of the created record,
+// including default and autodate values. Created and Updated are empty for
+// collections without these autodate fields.
+type ResponseCreate[T any] struct {
 	ID      string `json:"id"`
 	Created string `json:"created"`
-	Field   string `json:"field"`
 	Updated string `json:"updated"`
+	Record  T      `json:"-"`
+}
+
+// UnmarshalJSON decodes the record into both the common fields and Record.
+func (r *ResponseCreate[T]) UnmarshalJSON(data []byte) error {
+	return r.decode(data, defaultCodec)
+}
+
+// decode decodes the record into both the common fields and Record with the codec.
+func (r *ResponseCreate[T]) decode(data []byte, codec *recordCodec) error {
+	var common struct {
+		ID      string `json:"id"`
+		Created string `json:"created"`
+		Updated string `json:"updated"`
+	}
+	if err := json.Unmarshal(data, &common); err != nil {
+		return err
+	}
+	if err := codec.decode(data, &r.Record); err != nil {
+		return err
+	}
+	r.ID, r.Created, r.Updated = common.ID, common.Created, common.Updated
+	return nil
+}
+
+// HasNext reports whether there is a page after this one. Without the totals, i.e. listed
+// with skipTotal, a full page is assumed to have a next one.
+func (r ResponseList[T]) HasNext() bool {
+	if r.TotalPages < 0 {
+		return r.PerPage > 0 && len(r.Items) >= r.PerPage
+	}
+	return r.Page < r.TotalPages
+}
+
+// HasPrev reports whether there is a page before this one.
+func (r ResponseList[T]) HasPrev() bool {
+	return r.Page > 1
+}
+
+// NextPage returns the number of the page after this one, 0 without one:
+//
+//	for params.Page = 1; params.Page > 0; params.Page = list.NextPage() {
+//		list, err = collection.List(params)
+//		...
+//	}
+func (r ResponseList[T]) NextPage() int {
+	if !r.HasNext() {
+		return 0
+	}
+	return r.Page + 1
+}
+
+// IsLastPage reports whether this is the last page.
+func (r ResponseList[T]) IsLastPage() bool {
+	return !r.HasNext()
+}
+
+// Range returns the 1-based positions of the first and last items of the page in the
+// whole list, e.g. 31 and 60 for the second page of 30 items, or 0 and 0 without items.
+func (r ResponseList[T]) Range() (first, last int) {
+	if len(r.Items) == 0 {
+		return 0, 0
+	}
+	first = (max(r.Page, 1)-1)*r.PerPage + 1
+	return first, first + len(r.Items) - 1
+}
+
+// Values returns an iterator over the items of the page:
+//
+//	for post := range list.Values() {
+//		...
+//	}
+func (r ResponseList[T]) Values() iter.Seq[T] {
+	return slices.Values(r.Items)
+}
+
+// First returns the first item of the page, and whether there is one.
+func (r ResponseList[T]) First() (T, bool) {
+	if len(r.Items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.Items[0], true
+}
+
+// MapItems returns the list with its items converted by fn, keeping the page info, e.g. to
+// shape records into view models:
+//
+//	titles := pocketbase.MapItems(list, func(p Post) string { return p.Title })
+func MapItems[T, U any](r ResponseList[T], fn func(T) U) ResponseList[U] {
+	items := make([]U, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = fn(item)
+	}
+	return ResponseList[U]{Page: r.Page, PerPage: r.PerPage, TotalItems: r.TotalItems, TotalPages: r.TotalPages, Items: items}
+}
+
+// GroupBy groups the items of the page by the key returned by fn, keeping their order in
+// every group:
+//
+//	byAuthor := pocketbase.GroupBy(list, func(p Post) string { return p.Author })
+func GroupBy[T any, K comparable](r ResponseList[T], fn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range r.Items {
+		key := fn(item)
+		groups[key] = append(groups[key], item)
+	}
+	return groups
 }
@@ -0,0 +1,63 @@
+package pocketbase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseList_Pages(t *testing.T) {
+	items := func(n int) []int { return make([]int, n) }
+	tests := []struct {
+		name        string
+		list        ResponseList[int]
+		next        int
+		prev        bool
+		first, last int
+	}{
+		{"first", ResponseList[int]{Page: 1, PerPage: 30, TotalItems: 70, TotalPages: 3, Items: items(30)}, 2, false, 1, 30},
+		{"middle", ResponseList[int]{Page: 2, PerPage: 30, TotalItems: 70, TotalPages: 3, Items: items(30)}, 3, true, 31, 60},
+		{"last", ResponseList[int]{Page: 3, PerPage: 30, TotalItems: 70, TotalPages: 3, Items: items(10)}, 0, true, 61, 70},
+		{"empty", ResponseList[int]{Page: 1, PerPage: 30}, 0, false, 0, 0},
+		{"beyond", ResponseList[int]{Page: 4, PerPage: 30, TotalItems: 70, TotalPages: 3}, 0, true, 0, 0},
+		{"skip total full", ResponseList[int]{Page: 2, PerPage: 30, TotalItems: -1, TotalPages: -1, Items: items(30)}, 3, true, 31, 60},
+		{"skip total partial", ResponseList[int]{Page: 2, PerPage: 30, TotalItems: -1, TotalPages: -1, Items: items(5)}, 0, true, 31, 35},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.next, tt.list.NextPage())
+			assert.Equal(t, tt.next > 0, tt.list.HasNext())
+			assert.Equal(t, tt.next == 0, tt.list.IsLastPage())
+			assert.Equal(t, tt.prev, tt.list.HasPrev())
+			first, last := tt.list.Range()
+			assert.Equal(t, []int{tt.first, tt.last}, []int{first, last})
+		})
+	}
+}
+
+func TestResponseList_Items(t *testing.T) {
+	type post struct {
+		Title  string
+		Author string
+	}
+	list := ResponseList[post]{Page: 2, PerPage: 3, TotalItems: 6, TotalPages: 2, Items: []post{
+		{Title: "a", Author: "ann"}, {Title: "b", Author: "bob"}, {Title: "c", Author: "ann"},
+	}}
+
+	assert.Equal(t, list.Items, slices.Collect(list.Values()))
+	first, ok := list.First()
+	assert.True(t, ok)
+	assert.Equal(t, post{Title: "a", Author: "ann"}, first)
+	_, ok = ResponseList[post]{}.First()
+	assert.False(t, ok)
+
+	titles := MapItems(list, func(p post) string { return p.Title })
+	assert.Equal(t, ResponseList[string]{Page: 2, PerPage: 3, TotalItems: 6, TotalPages: 2, Items: []string{"a", "b", "c"}}, titles)
+	assert.Equal(t, []string{}, MapItems(ResponseList[post]{}, func(p post) string { return p.Title }).Items)
+
+	assert.Equal(t, map[string][]post{
+		"ann": {{Title: "a", Author: "ann"}, {Title: "c", Author: "ann"}},
+		"bob": {{Title: "b", Author: "bob"}},
+	}, GroupBy(list, func(p post) string { return p.Author }))
+}
@@ -0,0 +1,194 @@
+package pocketbase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrRetryBudgetExhausted is returned when a retry is skipped because the
+// retry budget or the maximum elapsed time of a request is exhausted, unless
+// the last attempt failed with an error of its own, which is returned instead.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudget is a token bucket limiting the number of retries, so an outage of
+// PocketBase doesn't multiply the latency of every request of a service.
+//
+// A budget can be shared by several clients, e.g. all clients of a ClientPool.
+type RetryBudget struct {
+	mu        sync.Mutex
+	perMinute float64
+	tokens    float64
+	last      time.Time
+	now       func() time.Time
+}
+
+// NewRetryBudget creates a budget allowing retriesPerMinute retries per minute,
+// in bursts of up to retriesPerMinute retries.
+func NewRetryBudget(retriesPerMinute int) *RetryBudget {
+	return &RetryBudget{
+		perMinute: float64(retriesPerMinute),
+		tokens:    float64(retriesPerMinute),
+		now:       time.Now,
+	}
+}
+
+// take consumes a retry from the budget, if there is one left.
+func (b *RetryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Minutes() * b.perMinute
+		if b.tokens > b.perMinute {
+			b.tokens = b.perMinute
+		}
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// WithRetryBudget limits the retries of the client by a budget, which can be shared between clients.
+func WithRetryBudget(budget *RetryBudget) ClientOption {
+	return func(c *Client) {
+		c.retryBudget = budget
+	}
+}
+
+// WithRetryMaxElapsed stops retrying a request once the given time elapsed since its first attempt.
+func WithRetryMaxElapsed(maxElapsed time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryMaxElapsed = maxElapsed
+	}
+}
+
+// IdempotencyKeyHeader is the header of the idempotency keys of the requests, see WithIdempotencyKey.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// errNotIdempotent vetoes the retries of the requests which aren't idempotent.
+var errNotIdempotent = errors.New("request not idempotent")
+
+// WithRetryNonIdempotent retries the requests of all methods, including the POST and PATCH
+// requests of the creates, updates and batches, which may then be applied twice, e.g. when
+// the first attempt timed out after the server applied it.
+//
+// By default, only the requests with an idempotent method (GET, HEAD, OPTIONS, PUT and
+// DELETE), the requests with an idempotency key, and the auth requests are retried.
+func WithRetryNonIdempotent() ClientOption {
+	return func(c *Client) {
+		c.retryNonIdempotent = true
+	}
+}
+
+// WithIdempotencyKeys sends a random idempotency key with every POST and PATCH request
+// without one, the same on every attempt, so the creates, updates and batches are retried.
+// The server must apply the requests with a known key only once, see WithIdempotencyKey.
+func WithIdempotencyKeys() ClientOption {
+	return func(c *Client) {
+		c.idempotencyKeys = true
+	}
+}
+
+type (
+	idempotencyKey struct{}
+	retrySafeKey   struct{}
+)
+
+// WithIdempotencyKey returns a context sending the key in the Idempotency-Key header of the
+// requests made with it, on every attempt, so they are retried whatever their method:
+//
+//	ctx := pocketbase.WithIdempotencyKey(ctx, "import-"+row.ID)
+//	_, err := posts.Create(ctx, post)
+//
+// The server must apply the requests with a known key only once, e.g. with a hook or a
+// proxy, for the retries to be safe. A key is set by the requests of Send with their
+// headers as well.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// retrySafe returns a context whose requests are retried whatever their method, for the
+// requests without side effects, e.g. the auth requests.
+func retrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+// setIdempotencyKey is a request middleware sending the idempotency key of the context, or a
+// random one with WithIdempotencyKeys. The retries keep the key of the first attempt.
+func (c *Client) setIdempotencyKey(_ *resty.Client, r *resty.Request) error {
+	if r.Header.Get(IdempotencyKeyHeader) != "" {
+		return nil
+	}
+	if key, _ := r.Context().Value(idempotencyKey{}).(string); key != "" {
+		r.SetHeader(IdempotencyKeyHeader, key)
+		return nil
+	}
+	if c.idempotencyKeys && (r.Method == http.MethodPost || r.Method == http.MethodPatch) {
+		if safe, _ := r.Context().Value(retrySafeKey{}).(bool); !safe {
+			r.SetHeader(IdempotencyKeyHeader, rand.Text())
+		}
+	}
+	return nil
+}
+
+// retryable reports whether a failed request can be retried without applying it twice.
+func (c *Client) retryable(r *resty.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if safe, _ := r.Context().Value(retrySafeKey{}).(bool); safe {
+		return true
+	}
+	return c.retryNonIdempotent || r.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// retryAfter is called before every retry and vetoes it when the request isn't idempotent
+// or a limit is exhausted. A zero duration keeps the default backoff.
+func (c *Client) retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if !c.retryable(resp.Request) {
+		return 0, errNotIdempotent
+	}
+	if responseTooLarge(resp.Request) {
+		return 0, ErrResponseTooLarge
+	}
+	if gate, ok := c.client.GetClient().Transport.(*healthGate); ok && gate.down() {
+		return 0, ErrServerUnavailable
+	}
+	if c.retryMaxElapsed > 0 && requestElapsed(resp.Request) >= c.retryMaxElapsed {
+		return 0, ErrRetryBudgetExhausted
+	}
+	if c.retryBudget != nil && !c.retryBudget.take() {
+		return 0, ErrRetryBudgetExhausted
+	}
+	return 0, nil
+}
+
+type requestStartKey struct{}
+
+// markRequestStart is a request middleware storing the start of the first attempt of a request.
+func markRequestStart(_ *resty.Client, r *resty.Request) error {
+	if r.Context().Value(requestStartKey{}) == nil {
+		r.SetContext(context.WithValue(r.Context(), requestStartKey{}, time.Now()))
+	}
+	return nil
+}
+
+// requestElapsed returns the time elapsed since the first attempt of a request.
+func requestElapsed(r *resty.Request) time.Duration {
+	if start, ok := r.Context().Value(requestStartKey{}).(time.Time); ok {
+		return time.Since(start)
+	}
+	return 0
+}
@@ -0,0 +1,170 @@
+package pocketbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Now()
+	budget := NewRetryBudget(2)
+	budget.now = func() time.Time { return now }
+
+	assert.True(t, budget.take())
+	assert.True(t, budget.take())
+	assert.False(t, budget.take())
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, budget.take())
+	assert.False(t, budget.take())
+
+	// refills up to the burst size only
+	now = now.Add(10 * time.Minute)
+	assert.True(t, budget.take())
+	assert.True(t, budget.take())
+	assert.False(t, budget.take())
+}
+
+// newFailingServer returns a server closing every connection and a counter of its requests.
+func newFailingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = conn.Close()
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &attempts
+}
+
+func TestWithRetryBudget(t *testing.T) {
+	srv, attempts := newFailingServer(t)
+	budget := NewRetryBudget(2)
+	client := NewClient(srv.URL, WithRetry(5, time.Millisecond, time.Millisecond), WithRetryBudget(budget))
+	other := NewClient(srv.URL, WithRetry(5, time.Millisecond, time.Millisecond), WithRetryBudget(budget))
+
+	_, err := client.List("posts", ParamsList{})
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// the budget is shared
+	_, err = other.List("posts", ParamsList{})
+	assert.Error(t, err)
+	assert.Equal(t, int32(4), attempts.Load())
+}
+
+func TestWithRetryMaxElapsed(t *testing.T) {
+	srv, attempts := newFailingServer(t)
+	client := NewClient(srv.URL,
+		WithRetry(10, 100*time.Millisecond, 100*time.Millisecond),
+		WithRetryMaxElapsed(150*time.Millisecond),
+	)
+
+	start := time.Now()
+	_, err := client.List("posts", ParamsList{})
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetry_IdempotentOnly(t *testing.T) {
+	srv, attempts := newFailingServer(t)
+	client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond))
+
+	_, err := client.Create("posts", map[string]any{"field": "test"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+
+	assert.Error(t, client.Update("posts", "p1", map[string]any{"field": "test"}))
+	assert.Equal(t, int32(2), attempts.Load())
+
+	assert.Error(t, client.Delete("posts", "p1"))
+	assert.Equal(t, int32(5), attempts.Load())
+
+	_, err = client.Send(context.Background(), Request{Method: http.MethodPost, Path: "/api/custom"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(6), attempts.Load())
+}
+
+func TestWithRetryNonIdempotent(t *testing.T) {
+	srv, attempts := newFailingServer(t)
+	client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond), WithRetryNonIdempotent())
+
+	_, err := client.Create("posts", map[string]any{"field": "test"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestWithIdempotencyKey(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		mu.Unlock()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = conn.Close()
+	}))
+	t.Cleanup(srv.Close)
+	// take returns the keys received so far and resets them
+	take := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		received := keys
+		keys = nil
+		return received
+	}
+	client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond))
+
+	ctx := WithIdempotencyKey(context.Background(), "import-1")
+	_, err := client.Batch().Create("posts", map[string]any{"field": "test"}).Send(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"import-1", "import-1", "import-1"}, take())
+
+	_, err = client.Send(context.Background(), Request{Method: http.MethodPost, Path: "/api/custom", Headers: map[string]string{IdempotencyKeyHeader: "custom-1"}})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"custom-1", "custom-1", "custom-1"}, take())
+
+	// a random key per request, kept by its retries
+	client = NewClient(srv.URL, WithRetry(1, time.Millisecond, time.Millisecond), WithIdempotencyKeys())
+	_, err = client.Create("posts", map[string]any{"field": "test"})
+	assert.Error(t, err)
+	assert.Error(t, client.Update("posts", "p1", map[string]any{"field": "test"}))
+	received := take()
+	require.Len(t, received, 4)
+	assert.NotEmpty(t, received[0])
+	assert.Equal(t, received[0], received[1])
+	assert.NotEqual(t, received[0], received[2])
+	assert.Equal(t, received[2], received[3])
+
+	// the reads don't need keys
+	_, err = client.List("posts", ParamsList{})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"", ""}, take())
+}
+
+func TestRetry_AuthRequests(t *testing.T) {
+	srv, attempts := newFailingServer(t)
+	client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond), WithAdminEmailPassword("admin@example.com", "hunter22"))
+
+	assert.Error(t, client.Authorize())
+	assert.Equal(t, int32(3), attempts.Load())
+}
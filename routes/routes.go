@@ -0,0 +1,25 @@
+// Package routes adds the custom API routes of the server, under /api/custom.
+//
+// It's registered by blank-importing it, and is the pattern for extending
+// this distribution: the SDK calls the routes with Client.Custom.
+package routes
+
+import (
+	"github.com/Forty2Co/pocketbase/app"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func init() {
+	app.Register(bind)
+}
+
+func bind(pb *pocketbase.PocketBase) {
+	pb.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		custom := e.Router.Group("/api/custom")
+		custom.Bind(apis.RequireSuperuserAuth())
+		custom.GET("/stats", stats)
+		return e.Next()
+	})
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// stats responds with the number of records of every non-system collection.
+func stats(e *core.RequestEvent) error {
+	collections, err := e.App.FindAllCollections()
+	if err != nil {
+		return e.InternalServerError("Failed to fetch the collections.", err)
+	}
+
+	records := map[string]int64{}
+	for _, collection := range collections {
+		if collection.System {
+			continue
+		}
+		count, err := e.App.CountRecords(collection)
+		if err != nil {
+			return e.InternalServerError("Failed to count the records of "+collection.Name+".", err)
+		}
+		records[collection.Name] = count
+	}
+
+	return e.JSON(http.StatusOK, map[string]any{"records": records})
+}
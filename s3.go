@@ -0,0 +1,177 @@
+package pocketbase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 checksum of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Target mirrors the files to a bucket of an S3 compatible storage, e.g. AWS S3, MinIO or
+// Cloudflare R2, with path-style URLs and requests signed with AWS Signature Version 4. The
+// checksums of the files are stored in their x-amz-meta-sha256 metadata.
+type S3Target struct {
+	// Endpoint is the URL of the storage, e.g. https://s3.eu-central-1.amazonaws.com.
+	Endpoint string
+	// Region is the region of the bucket, e.g. eu-central-1, "auto" for R2.
+	Region string
+	Bucket string
+	// Prefix is prepended to the keys of the files, e.g. "pocketbase/".
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// HTTPClient sends the requests, http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// Checksum reads the checksum from the metadata of the object, empty when there is none.
+func (s S3Target) Checksum(ctx context.Context, key string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.url(key), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", nil
+	case resp.StatusCode >= http.StatusBadRequest:
+		return "", fmt.Errorf("[s3] head %s returned status: %d", key, resp.StatusCode)
+	}
+	return resp.Header.Get("X-Amz-Meta-Sha256"), nil
+}
+
+// Put uploads the object, with the content type of its extension.
+func (s S3Target) Put(ctx context.Context, key string, body io.ReadSeeker, checksum string) error {
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url(key), io.NopCloser(body))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("X-Amz-Meta-Sha256", checksum)
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, checksum)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("[s3] put %s returned status: %d, msg: %s", key, resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// url returns the path-style URL of the object.
+func (s S3Target) url(key string) string {
+	segments := strings.Split(s.Prefix+key, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.TrimSuffix(s.Endpoint, "/") + "/" + s3Escape(s.Bucket) + "/" + strings.Join(segments, "/")
+}
+
+// do signs and sends the request.
+func (s S3Target) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, time.Now())
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("[s3] can't send request, err %w", err)
+	}
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 of the request, signing its host and all its headers.
+func (s S3Target) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := amzDate[:8] + "/" + s.Region + "/s3/aws4_request"
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + s.SecretAccessKey)
+	for _, part := range []string{amzDate[:8], s.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape escapes a segment of a path, keeping only the unreserved characters as required
+// by the signatures.
+func s3Escape(segment string) string {
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		ch := segment[i]
+		if 'A' <= ch && ch <= 'Z' || 'a' <= ch && ch <= 'z' || '0' <= ch && ch <= '9' || strings.IndexByte("-._~", ch) >= 0 {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
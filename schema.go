@@ -0,0 +1,277 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Collections provides methods for reading the schema of the collections.
+	// All methods require superuser authorization.
+	Collections struct {
+		*Client
+	}
+
+	// CollectionSchema is the definition of a collection, e.g. for validators, admin UIs or
+	// code generators reasoning about the schema at runtime.
+	CollectionSchema struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		// Type is base, auth or view.
+		Type   string        `json:"type"`
+		System bool          `json:"system"`
+		Fields []SchemaField `json:"fields"`
+		// Indexes are the CREATE INDEX statements of the collection.
+		Indexes []string `json:"indexes"`
+		// UniqueIndexes are the columns of the unique indexes, parsed from Indexes.
+		UniqueIndexes [][]string `json:"-"`
+		// The API rules are nil when only superusers are allowed.
+		ListRule   *string `json:"listRule"`
+		ViewRule   *string `json:"viewRule"`
+		CreateRule *string `json:"createRule"`
+		UpdateRule *string `json:"updateRule"`
+		DeleteRule *string `json:"deleteRule"`
+		// ViewQuery is the SELECT statement of the view collections.
+		ViewQuery string `json:"viewQuery,omitempty"`
+	}
+
+	// SchemaField is a field of a collection.
+	SchemaField struct {
+		ID   string
+		Name string
+		// Type is text, number, bool, email, url, editor, date, autodate, select, file,
+		// relation, json, geoPoint or password.
+		Type        string
+		Required    bool
+		Hidden      bool
+		System      bool
+		Presentable bool
+		// Unique reports whether a unique index covers the field alone.
+		Unique bool
+		// Options are the options of the type, e.g. min, max and pattern of the text fields,
+		// values and maxSelect of the select fields, or collectionId and cascadeDelete of
+		// the relation fields, as unmarshaled from JSON.
+		Options map[string]any
+	}
+
+	// schemaFieldJSON is the JSON of the common options of the fields.
+	schemaFieldJSON struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Required    bool   `json:"required"`
+		Hidden      bool   `json:"hidden"`
+		System      bool   `json:"system"`
+		Presentable bool   `json:"presentable"`
+	}
+)
+
+// Collections returns a Collections instance for reading the schema of the collections.
+func (c *Client) Collections() Collections {
+	return Collections{
+		Client: c,
+	}
+}
+
+// Schema fetches the schema of the collection by name or id.
+func (c Collections) Schema(name string) (CollectionSchema, error) {
+	ctx, cancel := c.callContext(context.Background())
+	defer cancel()
+	return c.collectionSchema(ctx, name)
+}
+
+// FullList fetches the schemas of all the collections.
+func (c Collections) FullList() ([]CollectionSchema, error) {
+	ctx, cancel := c.callContext(context.Background())
+	defer cancel()
+	return c.collectionSchemas(ctx)
+}
+
+// Field returns the field of the collection by name.
+func (s CollectionSchema) Field(name string) (SchemaField, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return SchemaField{}, false
+}
+
+// MaxSelect is the maximum number of values of the select, relation and file fields, which
+// hold a single value when it is 0 or 1.
+func (f SchemaField) MaxSelect() int {
+	n, _ := f.Options["maxSelect"].(float64)
+	return int(n)
+}
+
+// Multiple reports whether the field holds a list of values.
+func (f SchemaField) Multiple() bool {
+	switch f.Type {
+	case "select", "relation", "file":
+		return f.MaxSelect() > 1
+	}
+	return false
+}
+
+// Protected reports whether the files of a file field require a file token.
+func (f SchemaField) Protected() bool {
+	protected, _ := f.Options["protected"].(bool)
+	return protected
+}
+
+// UnmarshalJSON unmarshals the common options into the fields and the others into Options.
+func (f *SchemaField) UnmarshalJSON(data []byte) error {
+	var common schemaFieldJSON
+	if err := json.Unmarshal(data, &common); err != nil {
+		return err
+	}
+	var options map[string]any
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	for _, key := range []string{"id", "name", "type", "required", "hidden", "system", "presentable"} {
+		delete(options, key)
+	}
+	*f = SchemaField{
+		ID:          common.ID,
+		Name:        common.Name,
+		Type:        common.Type,
+		Required:    common.Required,
+		Hidden:      common.Hidden,
+		System:      common.System,
+		Presentable: common.Presentable,
+		Options:     options,
+	}
+	return nil
+}
+
+// MarshalJSON marshals the field like PocketBase, with its options inline.
+func (f SchemaField) MarshalJSON() ([]byte, error) {
+	field := make(map[string]any, len(f.Options)+7)
+	for key, value := range f.Options {
+		field[key] = value
+	}
+	field["id"], field["name"], field["type"] = f.ID, f.Name, f.Type
+	field["required"], field["hidden"] = f.Required, f.Hidden
+	field["system"], field["presentable"] = f.System, f.Presentable
+	return json.Marshal(field)
+}
+
+// resolveIndexes parses the unique indexes and marks the unique fields.
+func (s *CollectionSchema) resolveIndexes() {
+	s.UniqueIndexes = nil
+	for _, index := range s.Indexes {
+		if columns, ok := uniqueIndexColumns(index); ok {
+			s.UniqueIndexes = append(s.UniqueIndexes, columns)
+		}
+	}
+	for i := range s.Fields {
+		s.Fields[i].Unique = false
+		for _, columns := range s.UniqueIndexes {
+			if len(columns) == 1 && strings.EqualFold(columns[0], s.Fields[i].Name) {
+				s.Fields[i].Unique = true
+			}
+		}
+	}
+}
+
+// uniqueIndexColumns returns the columns of a CREATE UNIQUE INDEX statement, e.g. email of
+// "CREATE UNIQUE INDEX `idx_email` ON `users` (`email` COLLATE NOCASE)", partial or not.
+func uniqueIndexColumns(index string) ([]string, bool) {
+	fields := strings.Fields(index)
+	if len(fields) < 3 || !strings.EqualFold(fields[0], "CREATE") || !strings.EqualFold(fields[1], "UNIQUE") {
+		return nil, false
+	}
+	start := strings.IndexByte(index, '(')
+	if start < 0 {
+		return nil, false
+	}
+	end := strings.IndexByte(index[start+1:], ')')
+	if end < 0 {
+		return nil, false
+	}
+	var columns []string
+	for _, column := range strings.Split(index[start+1:start+1+end], ",") {
+		// the column is followed by its collation or order, e.g. COLLATE NOCASE or DESC
+		words := strings.Fields(column)
+		if len(words) == 0 {
+			return nil, false
+		}
+		columns = append(columns, strings.Trim(words[0], "`\"[]'"))
+	}
+	return columns, true
+}
+
+// collectionSchemas fetches the schemas of all the collections.
+func (c *Client) collectionSchemas(ctx context.Context) ([]CollectionSchema, error) {
+	if err := c.Authorize(); err != nil {
+		return nil, err
+	}
+
+	var schemas []CollectionSchema
+	for page := 1; ; page++ {
+		resp, err := c.client.R().
+			SetContext(ctx).
+			SetHeader("Content-Type", "application/json").
+			SetQueryParam("page", strconv.Itoa(page)).
+			SetQueryParam("perPage", "500").
+			Get(c.url + "/api/collections")
+		if err != nil {
+			return nil, fmt.Errorf("[schema] can't send collections request to pocketbase, err %w", err)
+		}
+
+		if resp.IsError() {
+			return nil, newAPIError(resp, fmt.Errorf("[schema] pocketbase returned status: %d, msg: %s, err %w",
+				resp.StatusCode(),
+				responseBody(resp),
+				ErrInvalidResponse,
+			))
+		}
+
+		var list ResponseList[CollectionSchema]
+		if err := c.codec.unmarshalJSON(resp.Body(), &list); err != nil {
+			return nil, fmt.Errorf("[schema] can't unmarshal response, err %w", err)
+		}
+		for i := range list.Items {
+			list.Items[i].resolveIndexes()
+		}
+		schemas = append(schemas, list.Items...)
+		if page >= list.TotalPages {
+			return schemas, nil
+		}
+	}
+}
+
+// collectionSchema fetches the schema of the collection.
+func (c *Client) collectionSchema(ctx context.Context, collection string) (CollectionSchema, error) {
+	var schema CollectionSchema
+	if err := c.Authorize(); err != nil {
+		return schema, err
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetPathParam("collection", collection).
+		Get(c.url + "/api/collections/{collection}")
+	if err != nil {
+		return schema, fmt.Errorf("[schema] can't send collection request to pocketbase, err %w", err)
+	}
+
+	if resp.IsError() {
+		return schema, newAPIError(resp, fmt.Errorf("[schema] pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	if err := c.codec.unmarshalJSON(resp.Body(), &schema); err != nil {
+		return schema, fmt.Errorf("[schema] can't unmarshal response, err %w", err)
+	}
+	schema.resolveIndexes()
+	return schema, nil
+}
@@ -0,0 +1,100 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollections_Schema(t *testing.T) {
+	posts := `{"id": "c1", "name": "posts", "type": "base", "listRule": "", "viewRule": null,
+		"fields": [
+			{"id": "f1", "name": "slug", "type": "text", "required": true, "max": 64, "pattern": "^[a-z-]+$"},
+			{"id": "f2", "name": "tags", "type": "select", "maxSelect": 3, "values": ["go", "db"]},
+			{"id": "f3", "name": "author", "type": "relation", "maxSelect": 1, "collectionId": "c2"},
+			{"id": "f4", "name": "files", "type": "file", "maxSelect": 2, "protected": true}
+		],
+		"indexes": [
+			"CREATE UNIQUE INDEX ` + "`idx_slug`" + ` ON ` + "`posts`" + ` (` + "`slug`" + ` COLLATE NOCASE) WHERE slug != ''",
+			"CREATE UNIQUE INDEX idx_author_tags ON posts (author, tags DESC)",
+			"CREATE INDEX idx_tags ON posts (tags)"
+		]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/collections/posts":
+			_, _ = fmt.Fprint(w, posts)
+		case "/api/collections":
+			_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 500, "totalItems": 1, "totalPages": 1, "items": [%s]}`, posts)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	collections := NewClient(srv.URL, WithRetry(0, 0, 0)).Collections()
+
+	schema, err := collections.Schema("posts")
+	require.NoError(t, err)
+	assert.Equal(t, "posts", schema.Name)
+	assert.Equal(t, [][]string{{"slug"}, {"author", "tags"}}, schema.UniqueIndexes)
+	require.NotNil(t, schema.ListRule)
+	assert.Empty(t, *schema.ListRule)
+	assert.Nil(t, schema.ViewRule)
+
+	slug, ok := schema.Field("slug")
+	require.True(t, ok)
+	assert.Equal(t, SchemaField{
+		ID: "f1", Name: "slug", Type: "text", Required: true, Unique: true,
+		Options: map[string]any{"max": 64.0, "pattern": "^[a-z-]+$"},
+	}, slug)
+	tags, _ := schema.Field("tags")
+	assert.False(t, tags.Unique, "the field is only unique with another one")
+	assert.True(t, tags.Multiple())
+	assert.Equal(t, []any{"go", "db"}, tags.Options["values"])
+	author, _ := schema.Field("author")
+	assert.False(t, author.Multiple())
+	files, _ := schema.Field("files")
+	assert.True(t, files.Protected())
+	_, ok = schema.Field("missing")
+	assert.False(t, ok)
+
+	raw, err := json.Marshal(slug)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "f1", "name": "slug", "type": "text", "required": true, "hidden": false,
+		"system": false, "presentable": false, "max": 64, "pattern": "^[a-z-]+$"}`, string(raw))
+
+	schemas, err := collections.FullList()
+	require.NoError(t, err)
+	require.Len(t, schemas, 1)
+	assert.Equal(t, schema, schemas[0])
+
+	_, err = collections.Schema("missing")
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestCollections_Schema_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+
+	schema, err := client.Collections().Schema("users")
+	require.NoError(t, err)
+	assert.Equal(t, "auth", schema.Type)
+	email, ok := schema.Field("email")
+	require.True(t, ok)
+	assert.Equal(t, "email", email.Type)
+	assert.True(t, email.Unique)
+	password, ok := schema.Field("password")
+	require.True(t, ok)
+	assert.True(t, password.Hidden)
+
+	_, err = NewClient(defaultURL).Collections().Schema("users")
+	assert.Error(t, err, "the schema requires a superuser")
+}
@@ -0,0 +1,134 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type (
+	// Request describes an arbitrary API call issued through Client.Send.
+	Request struct {
+		// Method is the HTTP method, defaults to GET.
+		Method string
+		// Path is relative to the client URL, e.g. "/api/settings".
+		Path    string
+		Query   url.Values
+		Headers map[string]string
+		// Body is sent as JSON unless it is an io.Reader, []byte or string.
+		Body any
+	}
+
+	// Response holds the raw result of a Client.Send call.
+	Response struct {
+		ResponseMeta
+		Body []byte
+	}
+
+	// ResponseMeta describes the HTTP exchange behind an API call.
+	// It can be used for custom caching, clock-skew handling or latency measurements.
+	ResponseMeta struct {
+		StatusCode int
+		Header     http.Header
+		// Date is the server time taken from the Date header, zero when missing or malformed.
+		Date time.Time
+		// Duration is the time between sending the request and receiving the response.
+		Duration time.Duration
+	}
+
+	// ResponseHook is called with the metadata of the response of every SDK call, the typed
+	// calls of collections included, error statuses included.
+	ResponseHook func(op Operation, meta ResponseMeta)
+)
+
+// WithResponseHook adds a hook called with the metadata of the response of every SDK call.
+// A clone with the hook captures the metadata of a single call:
+//
+//	var meta pocketbase.ResponseMeta
+//	metered := client.Clone(pocketbase.WithResponseHook(func(_ pocketbase.Operation, m pocketbase.ResponseMeta) {
+//		meta = m
+//	}))
+//	posts, err := pocketbase.CollectionSet[Post](metered, "posts").List(params)
+//	etag := meta.Header.Get("ETag")
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// NewResponseMeta extracts the metadata of a resty response,
+// e.g. inside the onResponse callback of Client.Get.
+func NewResponseMeta(resp *resty.Response) ResponseMeta {
+	meta := ResponseMeta{
+		StatusCode: resp.StatusCode(),
+		Header:     resp.Header(),
+		Duration:   resp.Time(),
+	}
+	if date, err := http.ParseTime(meta.Header.Get("Date")); err == nil {
+		meta.Date = date
+	}
+	return meta
+}
+
+// Decode unmarshals the response body into v.
+func (r Response) Decode(v any) error {
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("[send] can't unmarshal response, err %w", err)
+	}
+	return nil
+}
+
+// Send performs a request against an arbitrary PocketBase endpoint.
+//
+// It is intended for endpoints the SDK doesn't model yet: the request goes through
+// the same authorization, retry and middleware chain as every other call, but the
+// caller controls the method, path, query, headers and body.
+//
+// On an error status the returned Response is still populated, so the body can be inspected.
+func (c *Client) Send(ctx context.Context, req Request) (Response, error) {
+	var response Response
+
+	if err := c.Authorize(); err != nil {
+		return response, err
+	}
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	request := c.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeaders(req.Headers)
+	if req.Query != nil {
+		request.SetQueryParamsFromValues(req.Query)
+	}
+	if req.Body != nil {
+		request.SetBody(req.Body)
+	}
+
+	resp, err := request.Execute(strings.ToUpper(method), c.url+"/"+strings.TrimPrefix(req.Path, "/"))
+	if err != nil {
+		return response, fmt.Errorf("[send] can't send %s request to pocketbase, err %w", method, err)
+	}
+
+	response.ResponseMeta = NewResponseMeta(resp)
+	response.Body = resp.Body()
+
+	if resp.IsError() {
+		return response, newAPIError(resp, fmt.Errorf("[send] pocketbase returned status: %d, msg: %s, err %w",
+			resp.StatusCode(),
+			responseBody(resp),
+			ErrInvalidResponse,
+		))
+	}
+
+	return response, nil
+}
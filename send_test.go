@@ -0,0 +1,137 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Send(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ctx := context.Background()
+
+	t.Run("health check without method", func(t *testing.T) {
+		client := NewClient(defaultURL)
+		resp, err := client.Send(ctx, Request{Path: "/api/health"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var health struct {
+			Code int `json:"code"`
+		}
+		require.NoError(t, resp.Decode(&health))
+		assert.Equal(t, http.StatusOK, health.Code)
+	})
+
+	t.Run("response metadata", func(t *testing.T) {
+		client := NewClient(defaultURL)
+		resp, err := client.Send(ctx, Request{Path: "/api/health"})
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.WithinDuration(t, time.Now(), resp.Date, time.Minute)
+		assert.Positive(t, resp.Duration)
+	})
+
+	t.Run("create and list with query", func(t *testing.T) {
+		client := NewClient(defaultURL)
+		field := "value_" + time.Now().Format(time.StampMilli)
+
+		resp, err := client.Send(ctx, Request{
+			Method: http.MethodPost,
+			Path:   "/api/collections/" + migrations.PostsPublic + "/records",
+			Body:   map[string]any{"field": field},
+		})
+		require.NoError(t, err)
+		var created ResponseCreate[map[string]any]
+		require.NoError(t, resp.Decode(&created))
+		require.NotEmpty(t, created.ID)
+		defer func() {
+			_ = client.Delete(migrations.PostsPublic, created.ID)
+		}()
+
+		resp, err = client.Send(ctx, Request{
+			Path:  "api/collections/" + migrations.PostsPublic + "/records",
+			Query: url.Values{"filter": {"id='" + created.ID + "'"}},
+		})
+		require.NoError(t, err)
+		var list ResponseList[map[string]any]
+		require.NoError(t, resp.Decode(&list))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, field, list.Items[0]["field"])
+	})
+
+	t.Run("error status keeps response", func(t *testing.T) {
+		client := NewClient(defaultURL)
+		resp, err := client.Send(ctx, Request{Path: "/api/collections/" + migrations.PostsAdmin + "/records"})
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.NotEmpty(t, resp.Body)
+	})
+
+	t.Run("authorized request", func(t *testing.T) {
+		client := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+		resp, err := client.Send(ctx, Request{Path: "/api/settings"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestWithResponseHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"`+r.Method+`"`)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/collections/posts/records":
+			_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 30, "totalItems": 0, "totalPages": 0, "items": []}`)
+		case r.URL.Path == "/api/collections/posts/records/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"status": 404, "message": "not found"}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"id": "p1", "title": "a"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+
+	type post struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	var metas []ResponseMeta
+	var ops []string
+	metered := client.Clone(WithResponseHook(func(op Operation, meta ResponseMeta) {
+		ops = append(ops, op.Name)
+		metas = append(metas, meta)
+	}))
+	posts := CollectionSet[post](metered, "posts")
+
+	_, err := posts.List(ParamsList{})
+	require.NoError(t, err)
+	_, err = posts.One("p1")
+	require.NoError(t, err)
+	_, err = posts.Create(post{Title: "a"})
+	require.NoError(t, err)
+	require.NoError(t, posts.Update("p1", post{Title: "b"}))
+	_, err = posts.One("missing")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"list", "view", "create", "update", "view"}, ops)
+	assert.Equal(t, `"GET"`, metas[0].Header.Get("ETag"))
+	assert.Equal(t, `"POST"`, metas[2].Header.Get("ETag"))
+	assert.Equal(t, http.StatusNotFound, metas[4].StatusCode, "the error responses are reported")
+	assert.False(t, metas[0].Date.IsZero())
+
+	_, err = CollectionSet[post](client, "posts").List(ParamsList{})
+	require.NoError(t, err)
+	assert.Len(t, metas, 5, "the original client has no hook")
+}
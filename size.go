@@ -0,0 +1,72 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrResponseTooLarge is returned when a response, or the pages of a FullList, exceed the
+// maximum response size of the client, see WithMaxResponseSize.
+var ErrResponseTooLarge = resty.ErrResponseBodyTooLarge
+
+// WithMaxResponseSize caps the size of the response bodies read by the client, so a
+// misissued call can't exhaust the memory of the calling service:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithMaxResponseSize(64<<20))
+//
+// The calls accumulating pages, i.e. FullList, FullListPartial and Query.All, cap the total
+// size of their pages as well. The calls exceeding the limit fail with ErrResponseTooLarge
+// and aren't retried. The streamed responses, e.g. the downloads and the realtime events,
+// aren't capped. A zero limit removes the cap, the default.
+func WithMaxResponseSize(limit int) ClientOption {
+	return func(c *Client) {
+		c.maxResponseSize = limit
+		c.client.SetResponseBodyLimit(limit)
+	}
+}
+
+type (
+	responseSizeKey     struct{}
+	responseTooLargeKey struct{}
+)
+
+// sizedContext returns a context whose requests count the total size of their responses
+// against the maximum response size of the client, for the calls accumulating pages.
+func (c *Client) sizedContext(ctx context.Context) context.Context {
+	if c.maxResponseSize <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, responseSizeKey{}, new(atomic.Int64))
+}
+
+// limitResponseSize is a response middleware counting the size of the responses of the
+// calls accumulating pages, and failing the calls exceeding the maximum response size.
+func (c *Client) limitResponseSize(_ *resty.Client, resp *resty.Response) error {
+	total, ok := resp.Request.Context().Value(responseSizeKey{}).(*atomic.Int64)
+	if !ok {
+		return nil
+	}
+	if size := total.Add(int64(len(resp.Body()))); c.maxResponseSize > 0 && size > int64(c.maxResponseSize) {
+		return fmt.Errorf("[size] the responses of the call exceed %d bytes, err %w", c.maxResponseSize, ErrResponseTooLarge)
+	}
+	return nil
+}
+
+// markResponseTooLarge is a retry hook marking the requests whose response exceeded the
+// maximum response size, which retryAfter doesn't retry.
+func markResponseTooLarge(resp *resty.Response, err error) {
+	if resp != nil && errors.Is(err, ErrResponseTooLarge) {
+		resp.Request.SetContext(context.WithValue(resp.Request.Context(), responseTooLargeKey{}, true))
+	}
+}
+
+// responseTooLarge reports whether the last response of the request exceeded the maximum
+// response size.
+func responseTooLarge(r *resty.Request) bool {
+	tooLarge, _ := r.Context().Value(responseTooLargeKey{}).(bool)
+	return tooLarge
+}
@@ -0,0 +1,74 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxResponseSize(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/collections/posts/records/large" {
+			_, _ = fmt.Fprintf(w, `{"id": "large", "field": %q}`, strings.Repeat("a", 4096))
+			return
+		}
+		page := r.URL.Query().Get("page")
+		_, _ = fmt.Fprintf(w, `{"page": %s, "perPage": 1, "totalItems": 20, "totalPages": 20, "items": [{"id": "p%s", "field": %q}]}`,
+			page, page, strings.Repeat("a", 256))
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond), WithMaxResponseSize(1024))
+
+	_, err := client.One("posts", "large")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.Equal(t, int32(1), attempts.Load(), "the large responses aren't retried")
+
+	// every page is under the limit, but not all of them
+	list, err := client.List("posts", ParamsList{Page: 1})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+
+	attempts.Store(0)
+	_, err = client.FullList("posts", ParamsList{})
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.ErrorContains(t, err, "exceed 1024 bytes")
+	assert.Less(t, attempts.Load(), int32(5), "the pages stop at the limit")
+
+	type post struct {
+		ID    string `json:"id"`
+		Field string `json:"field"`
+	}
+	posts := CollectionSet[post](client, "posts")
+	_, err = posts.FullList(ParamsList{})
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	_, err = posts.Query().All(t.Context())
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	// without a limit
+	list, err = NewClient(srv.URL).FullList("posts", ParamsList{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 20)
+}
+
+func TestWithMaxResponseSize_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithMaxResponseSize(64))
+
+	_, err := client.List(migrations.PostsPublic, ParamsList{})
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+}
@@ -0,0 +1,137 @@
+package pocketbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot is the consistency mode of FullList, so the records created during a scan
+// neither duplicate nor skip records of the aggregated result, see WithSnapshot.
+type Snapshot int
+
+const (
+	// SnapshotNone pages through the records by offset, the default: a record created
+	// during the scan shifts the next pages, duplicating a record.
+	SnapshotNone Snapshot = iota
+	// SnapshotKeyset sorts the records by id, ignoring params.Sort, and filters every page to
+	// the records after the last id of the previous page. The records created during the scan
+	// are included when their id sorts after the last id. When params.Fields is set, it must
+	// include the id.
+	SnapshotKeyset
+	// SnapshotCreated pins a created <= start time filter to the scan, keeping params.Sort,
+	// so the records created during the scan are excluded. The start time is the created
+	// date of the last record created before the scan, fetched first; the collection needs
+	// a created field.
+	SnapshotCreated
+)
+
+// WithSnapshot sets the consistency mode of the FullList calls of the client, e.g. for the
+// exports of collections written concurrently:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithSnapshot(pocketbase.SnapshotKeyset))
+//
+// The records deleted during the scan may still shift the pages of SnapshotCreated.
+func WithSnapshot(snapshot Snapshot) ClientOption {
+	return func(c *Client) {
+		c.snapshot = snapshot
+	}
+}
+
+// pinCreated returns the params filtered to the records created before the scan, i.e. up to
+// the last created record matching the params.
+func (c *Client) pinCreated(ctx context.Context, collection string, params ParamsList) (ParamsList, error) {
+	var r ResponseList[struct {
+		Created string `json:"created"`
+	}]
+	latest := ParamsList{Page: 1, Size: 1, Filters: params.Filters, Sort: "-created", Fields: "created"}
+	if err := c.list(ctx, collection, latest, &r); err != nil {
+		return params, err
+	}
+	if len(r.Items) == 0 {
+		return params, nil
+	}
+	filter := Filter("created <= {:start}", map[string]any{"start": r.Items[0].Created})
+	if params.Filters != "" {
+		filter = "(" + params.Filters + ") && " + filter
+	}
+	params.Filters = filter
+	return params, nil
+}
+
+// keysetList fetches all the records matching params sorted by id, every page filtered to the
+// records after the last id of the previous page.
+func keysetList[T any](ctx context.Context, c *Client, collection string, params ParamsList, decode func(json.RawMessage, *T) error) (ResponseList[T], error) {
+	var response ResponseList[T]
+	err := keysetPages(ctx, c, collection, params, func(r ResponseList[json.RawMessage]) error {
+		if response.Items == nil {
+			response.Page = 1
+			response.PerPage = r.PerPage
+			response.TotalItems = r.TotalItems
+			response.TotalPages = r.TotalPages
+			response.Items = make([]T, 0, max(r.TotalItems, 0))
+		}
+		for _, raw := range r.Items {
+			var item T
+			if err := decode(raw, &item); err != nil {
+				return fmt.Errorf("[list] can't unmarshal record, err %w", err)
+			}
+			response.Items = append(response.Items, item)
+		}
+		return nil
+	})
+	return response, err
+}
+
+// keysetPages fetches the pages of the records matching params sorted by id like keysetList,
+// passing every page to fn instead of keeping the records.
+func keysetPages(ctx context.Context, c *Client, collection string, params ParamsList, fn func(ResponseList[json.RawMessage]) error) error {
+	size := 500
+	if c.paging != nil {
+		size = c.paging.InitialSize
+	}
+	received, ok := ctx.Value(responseSizeKey{}).(*atomic.Int64)
+	if !ok {
+		received = new(atomic.Int64)
+		ctx = context.WithValue(ctx, responseSizeKey{}, received)
+	}
+
+	filter, last := params.Filters, ""
+	params.Page = 1
+	params.Sort = "id"
+	for {
+		params.Size = size
+		params.Filters = filter
+		if last != "" {
+			params.Filters = Filter("id > {:id}", map[string]any{"id": last})
+			if filter != "" {
+				params.Filters = "(" + filter + ") && " + params.Filters
+			}
+		}
+
+		before, start := received.Load(), time.Now()
+		var r ResponseList[json.RawMessage]
+		if err := c.list(ctx, collection, params, &r); err != nil {
+			return err
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+
+		if len(r.Items) < size {
+			return nil
+		}
+		var key struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(r.Items[len(r.Items)-1], &key); err != nil || key.ID == "" {
+			return fmt.Errorf("[list] can't read the id of the last record, err %w", ErrInvalidResponse)
+		}
+		last = key.ID
+		if c.paging != nil {
+			size = c.paging.next(size, time.Since(start), received.Load()-before)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGrowingServer returns a server listing records sorted by id, which inserts a record
+// sorting first on every request, and the filters of the requests.
+func newGrowingServer(t *testing.T) (*httptest.Server, *[]string) {
+	var (
+		mu      sync.Mutex
+		ids     = []string{"m1", "m2", "m3", "m4", "m5"}
+		filters []string
+	)
+	keyset := regexp.MustCompile(`id > '([^']*)'`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		query := r.URL.Query()
+		filters = append(filters, query.Get("filter"))
+		page, _ := strconv.Atoi(query.Get("page"))
+		perPage, _ := strconv.Atoi(query.Get("perPage"))
+
+		matching := ids
+		if m := keyset.FindStringSubmatch(query.Get("filter")); m != nil {
+			matching = nil
+			for _, id := range ids {
+				if id > m[1] {
+					matching = append(matching, id)
+				}
+			}
+		}
+		items := []map[string]any{}
+		for i := (page - 1) * perPage; i < min(page*perPage, len(matching)); i++ {
+			items = append(items, map[string]any{"id": matching[i], "created": "2026-01-01 00:00:00.000Z"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"page": page, "perPage": perPage, "totalItems": len(matching), "totalPages": (len(matching) + perPage - 1) / perPage, "items": items,
+		})
+
+		// created during the scan, sorting first
+		ids = append(ids, fmt.Sprintf("a%d", len(ids)))
+		sort.Strings(ids)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &filters
+}
+
+func TestWithSnapshot(t *testing.T) {
+	paging := WithAdaptivePaging(AdaptivePaging{InitialSize: 2, MinSize: 2})
+	ids := func(items []map[string]any) []any {
+		var ids []any
+		for _, item := range items {
+			ids = append(ids, item["id"])
+		}
+		return ids
+	}
+
+	// the records created during the scan shift the pages
+	srv, _ := newGrowingServer(t)
+	list, err := NewClient(srv.URL, paging).FullList("posts", ParamsList{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"m1", "m2", "m2", "m3", "m3", "m4", "m4", "m5"}, ids(list.Items))
+
+	srv, filters := newGrowingServer(t)
+	list, err = NewClient(srv.URL, paging, WithSnapshot(SnapshotKeyset)).FullList("posts", ParamsList{Filters: "field != ''"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"m1", "m2", "m3", "m4", "m5"}, ids(list.Items))
+	assert.Equal(t, []string{"field != ''", "(field != '') && id > 'm2'", "(field != '') && id > 'm4'"}, *filters)
+
+	type post struct {
+		ID string `json:"id"`
+	}
+	srv, _ = newGrowingServer(t)
+	posts, err := CollectionSet[post](NewClient(srv.URL, paging, WithSnapshot(SnapshotKeyset)), "posts").FullList(ParamsList{})
+	require.NoError(t, err)
+	assert.Equal(t, []post{{"m1"}, {"m2"}, {"m3"}, {"m4"}, {"m5"}}, posts.Items)
+
+	// the scan is pinned to the last created record
+	srv, filters = newGrowingServer(t)
+	_, err = NewClient(srv.URL, WithSnapshot(SnapshotCreated)).FullList("posts", ParamsList{Filters: "field != ''"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"field != ''", "(field != '') && created <= '2026-01-01 00:00:00.000Z'"}, *filters)
+}
+
+func TestWithSnapshot_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	admin := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	marker := fmt.Sprintf("snapshot-%d", time.Now().UnixNano())
+	filter := Filter("field = {:marker}", map[string]any{"marker": marker})
+	var created []string
+	t.Cleanup(func() {
+		for _, id := range created {
+			_ = admin.Delete(migrations.PostsPublic, id)
+		}
+	})
+	create := func(record map[string]any) {
+		r, err := admin.Create(migrations.PostsPublic, record)
+		require.NoError(t, err)
+		created = append(created, r.ID)
+	}
+	for range 4 {
+		create(map[string]any{"field": marker})
+	}
+
+	for _, snapshot := range []Snapshot{SnapshotKeyset, SnapshotCreated} {
+		inserted := false
+		client := NewClient(defaultURL,
+			WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+			WithAdaptivePaging(AdaptivePaging{InitialSize: 2, MinSize: 2}),
+			WithSnapshot(snapshot),
+			WithObserver(func(op Operation, _ time.Duration, _ error) {
+				if op.Name == "list" && !inserted {
+					inserted = true
+					// sorts before the records of the first page
+					create(map[string]any{"id": fmt.Sprintf("%015d", len(created)), "field": marker})
+				}
+			}))
+
+		list, err := client.FullList(migrations.PostsPublic, ParamsList{Filters: filter, Sort: "-created"})
+		require.NoError(t, err)
+		seen := map[any]int{}
+		for _, item := range list.Items {
+			seen[item["id"]]++
+		}
+		for id, n := range seen {
+			assert.Equal(t, 1, n, "snapshot %d, record %s", snapshot, id)
+		}
+		for _, id := range created[:4] {
+			assert.Contains(t, seen, id, "snapshot %d", snapshot)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownFields is wrapped by the decoding errors of the records with fields unknown to
+// their struct type, in strict decoding mode.
+var ErrUnknownFields = errors.New("unknown fields")
+
+// WithStrictDecoding fails the decoding of the records with fields their struct type doesn't
+// hold, instead of silently dropping them, e.g. to detect in staging the fields added to
+// the collections but not to the structs:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithStrictDecoding())
+//
+// For a single collection, use a clone of the client:
+//
+//	posts := pocketbase.CollectionSet[Post](client.Clone(pocketbase.WithStrictDecoding()), "posts")
+//
+// The errors wrap ErrUnknownFields and list all the unknown fields of the record. The
+// metadata of the records, e.g. collectionId and expand, and the keys of the relation
+// fields are always known. The types with their own JSON decoding and the maps are
+// decoded as usual.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		codec := c.codec.clone()
+		codec.strict = true
+		c.codec = codec
+	}
+}
+
+// unknownFields returns the sorted keys of the record not held by the fields of the struct
+// type, matched like encoding/json, exactly first and then case-insensitively, except the
+// metadata of the records.
+func (rc *recordCodec) unknownFields(record map[string]json.RawMessage, t reflect.Type) []string {
+	var known map[string]bool
+	if cached, ok := rc.known.Load(t); ok {
+		known = cached.(map[string]bool)
+	} else {
+		known = map[string]bool{}
+		fields, _ := rc.appendMappedFields(nil, t, nil)
+		for _, f := range fields {
+			known[strings.ToLower(f.key)] = true
+		}
+		rc.known.Store(t, known)
+	}
+
+	var unknown []string
+	for key := range record {
+		if !recordMetaFields[key] && !known[strings.ToLower(key)] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// checkUnknownFields returns an error wrapping ErrUnknownFields when the record has fields
+// not held by the struct type, the keys of its relation fields aside.
+func checkUnknownFields(unknown []string, t reflect.Type) error {
+	relations := relationFields(t)
+	unknown = slices.DeleteFunc(slices.Clone(unknown), func(key string) bool {
+		for _, f := range relations {
+			if strings.EqualFold(key, f.relation) || f.key != "" && strings.EqualFold(key, f.key) {
+				return true
+			}
+		}
+		return false
+	})
+	if len(unknown) > 0 {
+		return fmt.Errorf("can't decode %s into %s, err %w", strings.Join(unknown, ", "), t, ErrUnknownFields)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_StrictDecoding(t *testing.T) {
+	record := `{"id": "p1", "collectionId": "c1", "collectionName": "posts", "title": "hello",
+		"author": "u1", "views": 3, "draft": true,
+		"expand": {"author": {"id": "u1", "collectionName": "users", "name": "ann", "avatar": "a.png"}}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/collections/posts/records/p1":
+			_, _ = fmt.Fprint(w, record)
+		case "/api/collections/posts/records":
+			_, _ = fmt.Fprintf(w, `{"page": 1, "perPage": 30, "totalItems": 1, "totalPages": 1, "items": [%s]}`, record)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	strict := client.Clone(WithStrictDecoding())
+
+	type user struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Avatar string `json:"avatar"`
+	}
+	type post struct {
+		ID     string `json:"id"`
+		Title  string `json:"Title"`
+		Views  int    `pb:"views"`
+		Draft  bool   `json:"draft"`
+		Author user   `json:"-" pb:"relation=author"`
+	}
+	one, err := CollectionSet[post](strict, "posts").One("p1")
+	require.NoError(t, err)
+	assert.Equal(t, post{ID: "p1", Title: "hello", Views: 3, Draft: true, Author: user{ID: "u1", Name: "ann", Avatar: "a.png"}}, one)
+
+	type partial struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	_, err = CollectionSet[partial](client, "posts").One("p1")
+	require.NoError(t, err, "unknown fields are dropped by default")
+	_, err = CollectionSet[partial](strict, "posts").One("p1")
+	require.ErrorIs(t, err, ErrUnknownFields)
+	assert.ErrorContains(t, err, "can't decode author, draft, views into pocketbase.partial")
+	_, err = CollectionSet[partial](strict, "posts").List(ParamsList{})
+	assert.ErrorIs(t, err, ErrUnknownFields)
+
+	type author struct {
+		ID string `json:"id"`
+	}
+	type withAuthor struct {
+		post
+		Author author `json:"-" pb:"relation=author"`
+	}
+	_, err = CollectionSet[withAuthor](strict, "posts").One("p1")
+	require.ErrorIs(t, err, ErrUnknownFields, "the expanded records are decoded strictly")
+	assert.ErrorContains(t, err, "can't decode relation author, err can't decode avatar, name into pocketbase.author")
+
+	_, err = CollectionSet[map[string]any](strict, "posts").One("p1")
+	assert.NoError(t, err)
+	_, err = CollectionSet[partial](client.Clone(WithStrictDecoding(), WithNamingStrategy(SnakeCase)), "posts").One("p1")
+	assert.ErrorIs(t, err, ErrUnknownFields, "the naming strategy keeps the strict decoding")
+}
+
+func TestClient_StrictDecoding_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithStrictDecoding())
+	type post struct {
+		ID      string `json:"id"`
+		Field   string `json:"field"`
+		Created string `json:"created"`
+		Updated string `json:"updated"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+	created, err := posts.Create(post{Field: "strict"})
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+	_, err = posts.One(created.ID)
+	require.NoError(t, err)
+
+	type partial struct {
+		ID string `json:"id"`
+	}
+	_, err = CollectionSet[partial](client, migrations.PostsPublic).One(created.ID)
+	assert.ErrorIs(t, err, ErrUnknownFields)
+}
@@ -6,20 +6,65 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/SierraSoftworks/multicast/v2"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/donovanhide/eventsource"
 )
 
-// Event represents a real-time event from PocketBase with action, record data, and optional error.
-type Event[T any] struct {
-	Action string `json:"action"`
+// Action is the kind of change reported by a realtime event.
+type Action string
+
+// Actions reported by PocketBase realtime events.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// RealtimeEvent represents a real-time event from PocketBase with action, record data, and optional error.
+type RealtimeEvent[T any] struct {
+	Action Action `json:"action"`
 	Record T      `json:"record"`
-	Error  error  `json:"-"`
+	// Topic is the subscription topic the event was delivered for, e.g. "posts" or "posts/RECORD_ID".
+	Topic string `json:"-"`
+	// Raw is the undecoded event payload, e.g. for fields T doesn't model.
+	Raw json.RawMessage `json:"-"`
+	// Error is set when the payload couldn't be decoded.
+	Error error `json:"-"`
 }
 
+// UnmarshalJSON decodes the event, preloading the relation fields of the record like List.
+func (e *RealtimeEvent[T]) UnmarshalJSON(data []byte) error {
+	return e.decode(data, defaultCodec)
+}
+
+// decode decodes the event, decoding the record with the codec.
+func (e *RealtimeEvent[T]) decode(data []byte, codec *recordCodec) error {
+	var event struct {
+		Action Action          `json:"action"`
+		Record json.RawMessage `json:"record"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return err
+	}
+	e.Action = event.Action
+	if len(event.Record) == 0 {
+		return nil
+	}
+	return codec.decode(event.Record, &e.Record)
+}
+
+// Event is the former name of RealtimeEvent.
+//
+// Deprecated: use RealtimeEvent instead.
+type Event[T any] = RealtimeEvent[T]
+
 // Subscribe creates a real-time subscription to the collection with default options.
 func (c *Collection[T]) Subscribe(targets ...string) (*Stream[T], error) {
 	opts := SubscribeOptions{
@@ -28,9 +73,60 @@ func (c *Collection[T]) Subscribe(targets ...string) (*Stream[T], error) {
 	return c.SubscribeWith(opts, targets...)
 }
 
+// SubscribeRecord creates a real-time subscription to a single record of the collection.
+//
+// The stream only receives the events of the record with the given id, i.e. the
+// topic "collection/id", and is unsubscribed when ctx is done.
+func (c *Collection[T]) SubscribeRecord(ctx context.Context, id string) (*Stream[T], error) {
+	stream, err := c.Subscribe(c.Name + "/" + id)
+	if err != nil {
+		return nil, err
+	}
+	context.AfterFunc(ctx, stream.Unsubscribe)
+	return stream, nil
+}
+
 // SubscribeOptions configures real-time subscription behavior including reconnection strategy.
 type SubscribeOptions struct {
+	// ReconnectStrategy defaults to reconnecting immediately.
 	ReconnectStrategy backoff.BackOff
+	// Headers are sent with the requests establishing the realtime connection,
+	// e.g. when PocketBase sits behind an auth proxy requiring extra headers.
+	Headers map[string]string
+	// Query is added to the URL of the realtime connection.
+	Query url.Values
+	// TopicOptions are attached to every topic, see TopicWithOptions.
+	TopicOptions *TopicOptions
+	// PollInterval switches the subscription to poll mode, for environments where proxies
+	// or serverless platforms buffer or kill SSE streams. The topics are listed every
+	// interval for records with an "updated" value not older than the last seen one,
+	// which are emitted as create and update events, with the query and headers of the
+	// TopicOptions. Deletes are not reported.
+	PollInterval time.Duration
+}
+
+// TopicOptions are the per-topic options of a subscription.
+//
+// PocketBase uses them as the request info of the topic, i.e. they are available
+// to the API rules as @request.query.* and @request.headers.*, and the "expand"
+// and "fields" query params shape the records of the events.
+type TopicOptions struct {
+	Query   map[string]string `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// TopicWithOptions returns the topic with the options attached in the format PocketBase
+// expects, e.g. "posts?options=%7B%22query%22%3A...".
+func TopicWithOptions(topic string, opts TopicOptions) string {
+	raw, err := json.Marshal(opts)
+	if err != nil {
+		return topic
+	}
+	sep := "?"
+	if strings.Contains(topic, "?") {
+		sep = "&"
+	}
+	return topic + sep + "options=" + url.QueryEscape(string(raw))
 }
 
 // SubscribeWith creates a real-time subscription with custom options and target collections.
@@ -43,16 +139,32 @@ func (c *Collection[T]) SubscribeWith(opts SubscribeOptions, targets ...string)
 		targets = []string{c.Name}
 	}
 
-	stream := newStream[T]()
-	ctx, cancel := context.WithCancel(context.Background())
+	if opts.ReconnectStrategy == nil {
+		opts.ReconnectStrategy = &backoff.ZeroBackOff{}
+	}
+	if opts.TopicOptions != nil {
+		targets = slices.Clone(targets)
+		for i, target := range targets {
+			targets[i] = TopicWithOptions(target, *opts.TopicOptions)
+		}
+	}
+
+	if opts.PollInterval > 0 {
+		return c.subscribePoll(opts, targets)
+	}
+
+	stream := newStream[T](c.Client, targets, opts.Headers)
+	ctx, cancel := context.WithCancel(unboundedContext(context.Background()))
 	stream.unsubscribe = func() { cancel() }
 
 	handleSSEEvent := func(ev eventsource.Event) {
-		var e Event[T]
+		var e RealtimeEvent[T]
 		if c.sseDebug {
-			log.Printf("SSE event: %+v", ev)
+			log.Printf("SSE event: %s %s", ev.Event(), c.redaction.redact([]byte(ev.Data())))
 		}
-		e.Error = json.Unmarshal([]byte(ev.Data()), &e)
+		e.Error = e.decode([]byte(ev.Data()), c.codec)
+		e.Topic = ev.Event()
+		e.Raw = json.RawMessage(ev.Data())
 		stream.channel.C <- e
 	}
 
@@ -60,7 +172,11 @@ func (c *Collection[T]) SubscribeWith(opts SubscribeOptions, targets ...string)
 	stream.ready.Lock()
 	startStream := func(check bool) func() error {
 		return func() (err error) {
-			req := c.client.R().SetContext(ctx).SetDoNotParseResponse(true)
+			req := c.client.R().
+				SetContext(ctx).
+				SetDoNotParseResponse(true).
+				SetHeaders(opts.Headers).
+				SetQueryParamsFromValues(opts.Query)
 			resp, err := req.Get(c.url + "/api/realtime")
 			if err != nil {
 				return
@@ -83,17 +199,32 @@ func (c *Collection[T]) SubscribeWith(opts SubscribeOptions, targets ...string)
 				return fmt.Errorf("first event must be PB_CONNECT, but got %s", event)
 			}
 
-			if err := c.authSubscribeStream([]byte(ev.Data()), targets); err != nil {
+			var s SubscriptionsSet
+			if err := json.Unmarshal([]byte(ev.Data()), &s); err != nil {
+				return err
+			}
+			topics := targets
+			if !check {
+				topics = stream.connected(s.ClientID)
+			}
+			if err := c.subscribeTopics(s.ClientID, topics, opts.Headers); err != nil {
 				return err
 			}
 
 			if !check {
+				c.realtime.connected(s.ClientID)
 				once.Do(func() {
 					stream.ready.Unlock()
 				})
 				for {
 					ev, err := d.Decode()
 					if err != nil {
+						stream.disconnected()
+						if ctx.Err() != nil {
+							c.realtime.disconnected(nil)
+						} else {
+							c.realtime.disconnected(err)
+						}
 						return err
 					}
 					go handleSSEEvent(ev)
@@ -107,6 +238,7 @@ func (c *Collection[T]) SubscribeWith(opts SubscribeOptions, targets ...string)
 	if err := startStream(true)(); err != nil {
 		return nil, err
 	}
+	c.realtime.add(stream)
 
 	go func() {
 		if err := backoff.Retry(startStream(false), backoff.WithContext(opts.ReconnectStrategy, ctx)); err != nil {
@@ -123,52 +255,123 @@ type SubscriptionsSet struct {
 	Subscriptions []string `json:"subscriptions"`
 }
 
-func (c *Collection[T]) authSubscribeStream(data []byte, targets []string) (err error) {
-	var s SubscriptionsSet
-	if err = json.Unmarshal(data, &s); err != nil {
-		return
+// subscribeTopics replaces the server-side subscription set of the realtime connection.
+func (c *Client) subscribeTopics(clientID string, topics []string, headers map[string]string) (err error) {
+	s := SubscriptionsSet{
+		ClientID:      clientID,
+		Subscriptions: topics,
 	}
-	s.Subscriptions = targets
-	resp, err := c.client.R().SetBody(s).Post(c.url + "/api/realtime")
+	// the subscriptions set replaces the previous one, and can be sent twice
+	resp, err := c.client.R().SetContext(retrySafe(context.Background())).SetHeaders(headers).SetBody(s).Post(c.url + "/api/realtime")
 	if err != nil {
+		c.realtime.subscriptionFailed(topics, err)
 		return
 	}
 	if code := resp.StatusCode(); code != http.StatusNoContent {
-		return fmt.Errorf("auth subscribe stream failed. resp status code is %v", code)
+		err = fmt.Errorf("auth subscribe stream failed. resp status code is %v", code)
+		c.realtime.subscriptionFailed(topics, err)
+		return
 	}
 	return
 }
 
 // Stream represents a real-time event stream with subscription management capabilities.
 type Stream[T any] struct {
-	channel     *multicast.Channel[Event[T]]
+	channel     *multicast.Channel[RealtimeEvent[T]]
 	unsubscribe func()
+	client      *Client
+	headers     map[string]string
+
+	mu       sync.Mutex
+	clientID string
+	topics   []string
 
 	ready       *sync.RWMutex
 	onceCleanup *sync.Once
 }
 
-func newStream[T any]() *Stream[T] {
+func newStream[T any](client *Client, topics []string, headers map[string]string) *Stream[T] {
 	return &Stream[T]{
-		channel:     multicast.New[Event[T]](),
+		channel:     multicast.New[RealtimeEvent[T]](),
+		client:      client,
+		headers:     headers,
+		topics:      slices.Clone(topics),
 		ready:       &sync.RWMutex{},
 		onceCleanup: &sync.Once{},
 	}
 }
 
 // Events returns a channel that receives real-time events from the stream.
-func (s *Stream[T]) Events() <-chan Event[T] {
+func (s *Stream[T]) Events() <-chan RealtimeEvent[T] {
 	return s.channel.Listen().C
 }
 
 // Unsubscribe closes the stream and cleans up resources.
 func (s *Stream[T]) Unsubscribe() {
 	s.onceCleanup.Do(func() {
+		s.client.realtime.remove(s)
 		s.unsubscribe()
 		s.channel.Close()
 	})
 }
 
+// Topics returns the topics the stream is currently subscribed to.
+func (s *Stream[T]) Topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.topics)
+}
+
+// connected stores the client id of a new realtime connection and returns the topics to subscribe.
+func (s *Stream[T]) connected(clientID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientID = clientID
+	return slices.Clone(s.topics)
+}
+
+// disconnected forgets the client id of the closed realtime connection.
+func (s *Stream[T]) disconnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientID = ""
+}
+
+// ClientID returns the id PocketBase assigned to the realtime connection of the
+// stream with the PB_CONNECT event, or an empty string while it is not connected.
+//
+// The id changes on every reconnect; the topics of the stream are re-registered
+// for the new id automatically. It can be passed to server-side code, e.g. in a
+// request header, to skip broadcasting changes back to the client that made them.
+func (s *Stream[T]) ClientID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clientID
+}
+
+// unsubscribeTopics removes the matching topics and updates the server-side
+// subscription set. The stream is closed when no topics are left.
+func (s *Stream[T]) unsubscribeTopics(match func(topic string) bool) error {
+	s.mu.Lock()
+	topics := slices.DeleteFunc(slices.Clone(s.topics), match)
+	changed := len(topics) != len(s.topics)
+	s.topics = topics
+	clientID := s.clientID
+	s.mu.Unlock()
+
+	switch {
+	case !changed:
+		return nil
+	case len(topics) == 0:
+		s.Unsubscribe()
+		return nil
+	case clientID == "":
+		// not connected yet, the topics are subscribed on connect
+		return nil
+	}
+	return s.client.subscribeTopics(clientID, topics, s.headers)
+}
+
 // WaitAuthReady waits for the stream to be ready for authentication.
 // Deprecated: use <-stream.Ready() instead.
 func (s *Stream[T]) WaitAuthReady() error {
@@ -1,8 +1,13 @@
 package pocketbase
 
 import (
+	"context"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,8 +41,10 @@ func TestCollection_Subscribe(t *testing.T) {
 			return
 		}
 		e := <-ch
-		assert.Equal(t, "create", e.Action)
+		assert.Equal(t, ActionCreate, e.Action)
 		assert.Equal(t, resp.ID, e.Record["id"])
+		assert.Equal(t, migrations.PostsPublic, e.Topic)
+		assert.Contains(t, string(e.Raw), resp.ID)
 	})
 
 	t.Run("subscribe event: update", func(t *testing.T) {
@@ -56,7 +63,7 @@ func TestCollection_Subscribe(t *testing.T) {
 			return
 		}
 		e := <-ch
-		assert.Equal(t, "update", e.Action)
+		assert.Equal(t, ActionUpdate, e.Action)
 		assert.Equal(t, body["field"], e.Record["field"])
 	})
 
@@ -73,7 +80,7 @@ func TestCollection_Subscribe(t *testing.T) {
 			return
 		}
 		e := <-ch
-		assert.Equal(t, "delete", e.Action)
+		assert.Equal(t, ActionDelete, e.Action)
 		assert.Equal(t, resp.ID, e.Record["id"])
 	})
 }
@@ -169,3 +176,160 @@ func TestCollection_RealtimeReconnect(t *testing.T) {
 	}
 	assert.Equal(t, true, got)
 }
+
+func TestStream_ClientID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	connected := make(chan string, 1)
+	client.Realtime().OnConnect(func(clientID string) { connected <- clientID })
+
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	stream, err := collection.Subscribe()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	<-stream.Ready()
+
+	clientID := <-connected
+	assert.NotEmpty(t, clientID)
+	assert.Equal(t, clientID, stream.ClientID())
+
+	stream.Unsubscribe()
+	assert.Eventually(t, func() bool { return stream.ClientID() == "" }, time.Second, 10*time.Millisecond)
+}
+
+func TestCollection_SubscribeRecord(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	defaultBody := map[string]interface{}{
+		"field": "value_" + time.Now().Format(time.StampMilli),
+	}
+	watched, err := collection.Create(defaultBody)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	other, err := collection.Create(defaultBody)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := collection.SubscribeRecord(ctx, watched.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	<-stream.Ready()
+	ch := stream.Events()
+	assert.Equal(t, []string{migrations.PostsPublic + "/" + watched.ID}, stream.Topics())
+
+	if err := collection.Update(other.ID, map[string]any{"field": "other"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := collection.Update(watched.ID, map[string]any{"field": "watched"}); err != nil {
+		t.Error(err)
+		return
+	}
+	e := <-ch
+	assert.Equal(t, ActionUpdate, e.Action)
+	assert.Equal(t, watched.ID, e.Record["id"])
+	assert.Equal(t, migrations.PostsPublic+"/"+watched.ID, e.Topic)
+
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Error("cancelling the context is not unsubscribing.")
+	}
+}
+
+func TestCollection_SubscribeWithHeaders(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	target, err := url.Parse(defaultURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// auth proxy in front of pocketbase
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.FlushInterval = -1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/realtime") && r.Header.Get("X-Proxy-Auth") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Path == "/api/realtime" && r.URL.Query().Get("tenant") != "acme" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+
+	_, err = collection.Subscribe()
+	assert.Error(t, err)
+
+	stream, err := collection.SubscribeWith(SubscribeOptions{
+		Headers: map[string]string{"X-Proxy-Auth": "secret"},
+		Query:   url.Values{"tenant": {"acme"}},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer stream.Unsubscribe()
+	<-stream.Ready()
+
+	resp, err := collection.Create(map[string]any{"field": "proxied"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	e := <-stream.Events()
+	assert.Equal(t, resp.ID, e.Record["id"])
+}
+
+func TestCollection_SubscribeWithTopicOptions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL)
+	collection := CollectionSet[map[string]any](client, migrations.PostsPublic)
+	topicOptions := TopicOptions{Query: map[string]string{"fields": "id"}}
+
+	stream, err := collection.SubscribeWith(SubscribeOptions{TopicOptions: &topicOptions})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer stream.Unsubscribe()
+	<-stream.Ready()
+
+	topic := TopicWithOptions(migrations.PostsPublic, topicOptions)
+	assert.Equal(t, migrations.PostsPublic+`?options=%7B%22query%22%3A%7B%22fields%22%3A%22id%22%7D%7D`, topic)
+	assert.Equal(t, []string{topic}, stream.Topics())
+
+	resp, err := collection.Create(map[string]any{"field": "only id"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	e := <-stream.Events()
+	assert.Equal(t, map[string]any{"id": resp.ID}, e.Record)
+
+	// the options are ignored when unsubscribing by topic
+	assert.NoError(t, client.Realtime().Unsubscribe(migrations.PostsPublic))
+	assert.Empty(t, client.Realtime().Subscriptions())
+}
@@ -0,0 +1,86 @@
+package pocketbase
+
+import (
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type (
+	// Superusers provides methods for managing PocketBase superuser accounts.
+	// All methods require superuser authorization.
+	Superusers struct {
+		*Client
+	}
+
+	// Superuser represents a record of the superusers collection.
+	Superuser struct {
+		ID              string `json:"id"`
+		CollectionID    string `json:"collectionId"`
+		CollectionName  string `json:"collectionName"`
+		Email           string `json:"email"`
+		EmailVisibility bool   `json:"emailVisibility"`
+		Verified        bool   `json:"verified"`
+		Created         string `json:"created"`
+		Updated         string `json:"updated"`
+	}
+)
+
+// Superusers returns a Superusers instance for managing superuser accounts.
+func (c *Client) Superusers() Superusers {
+	return Superusers{
+		Client: c,
+	}
+}
+
+func (s Superusers) collection() *AuthCollection[Superuser] {
+	return AuthCollectionSet[Superuser](s.Client, core.CollectionNameSuperusers)
+}
+
+// List retrieves a paginated list of superusers.
+func (s Superusers) List(params ParamsList) (ResponseList[Superuser], error) {
+	return s.collection().List(params)
+}
+
+// FullList retrieves all superusers without pagination.
+func (s Superusers) FullList(params ParamsList) (ResponseList[Superuser], error) {
+	return s.collection().FullList(params)
+}
+
+// One retrieves a single superuser by ID.
+func (s Superusers) One(id string) (Superuser, error) {
+	return s.collection().One(id)
+}
+
+// Create creates a new superuser account.
+func (s Superusers) Create(email, password string) (ResponseCreate[Superuser], error) {
+	return s.collection().CreateUser(NewUser{
+		Email:    email,
+		Password: password,
+	})
+}
+
+// Update updates a superuser account with the specified ID.
+func (s Superusers) Update(id string, body any) error {
+	return s.Client.Update(core.CollectionNameSuperusers, id, body)
+}
+
+// UpdatePassword sets a new password for the superuser account with the specified ID.
+func (s Superusers) UpdatePassword(id string, password string) error {
+	return s.Update(id, map[string]any{
+		"password":        password,
+		"passwordConfirm": password,
+	})
+}
+
+// Delete removes a superuser account by ID.
+func (s Superusers) Delete(id string) error {
+	return s.Client.Delete(core.CollectionNameSuperusers, id)
+}
+
+// Token issues a one-off, non-refreshable auth token for the superuser with the specified ID,
+// e.g. for provisioning scripts. A zero duration uses the default auth token duration.
+func (s Superusers) Token(id string, duration time.Duration) (string, error) {
+	resp, err := s.collection().Impersonate(id, duration)
+	return resp.Token, err
+}
@@ -0,0 +1,60 @@
+package pocketbase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperusers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Run("without authorization", func(t *testing.T) {
+		_, err := NewClient(defaultURL).Superusers().List(ParamsList{})
+		assert.Error(t, err)
+	})
+
+	admin := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	superusers := admin.Superusers()
+	email := "admin_" + time.Now().Format("20060102150405.000000") + "@admin.com"
+
+	created, err := superusers.Create(email, email)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	defer func() {
+		_ = superusers.Delete(created.ID)
+	}()
+
+	list, err := superusers.FullList(ParamsList{Filters: "email='" + email + "'"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, created.ID, list.Items[0].ID)
+
+	one, err := superusers.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, email, one.Email)
+
+	// the new account can authenticate, then again after a password change
+	require.NoError(t, NewClient(defaultURL, WithAdminEmailPassword(email, email)).Authorize())
+	require.NoError(t, superusers.UpdatePassword(created.ID, email+"_changed"))
+	assert.Error(t, NewClient(defaultURL, WithAdminEmailPassword(email, email)).Authorize())
+	assert.NoError(t, NewClient(defaultURL, WithAdminEmailPassword(email, email+"_changed")).Authorize())
+
+	token, err := superusers.Token(created.ID, time.Minute)
+	require.NoError(t, err)
+	_, err = NewClient(defaultURL).Send(context.Background(), Request{
+		Path:    "/api/settings",
+		Headers: map[string]string{"Authorization": token},
+	})
+	assert.NoError(t, err)
+
+	require.NoError(t, superusers.Delete(created.ID))
+	_, err = superusers.One(created.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DateTimeLayout is the layout of the date and autodate fields of PocketBase, in UTC,
+// e.g. "2024-03-01 10:00:00.000Z".
+const DateTimeLayout = dateTimeLayout
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// WithTimeLayouts decodes the time.Time fields of the records, and their pointers, from the
+// strings in the output layout or any of the other layouts, besides RFC 3339, so the date
+// and autodate fields of PocketBase don't need a custom type on every struct:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithTimeLayouts(pocketbase.DateTimeLayout, "2006-01-02"))
+//
+// The empty strings of the unset date fields are decoded as the zero time, or nil. The time
+// fields of the bodies sent by Create and Update are encoded in the output layout, in UTC,
+// and the zero time as an empty string; an empty output layout keeps RFC 3339. The time
+// fields of the nested structs and the types with their own JSON decoding are left alone.
+func WithTimeLayouts(output string, layouts ...string) ClientOption {
+	return func(c *Client) {
+		codec := c.codec.clone()
+		codec.timeFormat = output
+		codec.timeLayouts = append([]string{time.RFC3339Nano}, layouts...)
+		if output != "" {
+			codec.timeLayouts = append(codec.timeLayouts, output)
+		}
+		c.codec = codec
+	}
+}
+
+// timeFields returns the time fields of the struct type, with WithTimeLayouts.
+func (rc *recordCodec) timeFields(t reflect.Type) []mappedField {
+	if len(rc.timeLayouts) == 0 || t.Kind() != reflect.Struct || t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return nil
+	}
+	if cached, ok := rc.times.Load(t); ok {
+		return cached.([]mappedField)
+	}
+
+	var times []mappedField
+	fields, _ := rc.appendMappedFields(nil, t, nil)
+	for _, f := range fields {
+		if ft := t.FieldByIndex(f.index).Type; ft == timeType || ft == reflect.PointerTo(timeType) {
+			times = append(times, f)
+		}
+	}
+	rc.times.Store(t, times)
+	return times
+}
+
+// decodeTimes rewrites the time fields of the record in RFC 3339, and the empty ones as null.
+func (rc *recordCodec) decodeTimes(record map[string]json.RawMessage, t reflect.Type) error {
+	for _, f := range rc.timeFields(t) {
+		key, ok := recordKey(record, f.key)
+		if !ok {
+			continue
+		}
+		var s string
+		if json.Unmarshal(record[key], &s) != nil {
+			// null, or not a string left to fail the decoding
+			continue
+		}
+		if s == "" {
+			record[key] = json.RawMessage("null")
+			continue
+		}
+		parsed, err := rc.parseTime(s)
+		if err != nil {
+			return fmt.Errorf("can't decode time field %s, err %w", key, err)
+		}
+		raw, err := json.Marshal(parsed.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+		record[key] = raw
+	}
+	return nil
+}
+
+// parseTime parses the time in the first matching layout.
+func (rc *recordCodec) parseTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range rc.timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// decodesDateTimes reports whether the time fields are decoded from the date fields of
+// PocketBase, with WithTimeLayouts parsing DateTimeLayout.
+func (rc *recordCodec) decodesDateTimes() bool {
+	if len(rc.timeLayouts) == 0 {
+		return false
+	}
+	_, err := rc.parseTime(time.Unix(0, 0).UTC().Format(dateTimeLayout))
+	return err == nil
+}
+
+// encodeTimes replaces the time fields of the encoded record with their values in the
+// output layout.
+func (rc *recordCodec) encodeTimes(record map[string]json.RawMessage, v reflect.Value, fields []mappedField) error {
+	for _, f := range fields {
+		if _, ok := record[f.key]; !ok {
+			continue
+		}
+		field, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		s := ""
+		if t := field.Interface().(time.Time); !t.IsZero() {
+			s = t.UTC().Format(rc.timeFormat)
+		}
+		raw, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		record[f.key] = raw
+	}
+	return nil
+}
+
+// recordKey returns the key of the record matching the field name like encoding/json,
+// exactly first and then case-insensitively.
+func recordKey(record map[string]json.RawMessage, name string) (string, bool) {
+	if _, ok := record[name]; ok {
+		return name, true
+	}
+	for key := range record {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,99 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TimeLayouts(t *testing.T) {
+	var sent map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			sent = nil
+			_ = json.Unmarshal(body, &sent)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id": "p1", "published": "2024-03-01 10:00:00.123Z", "day": "2024-03-02",
+			"created": "2024-03-01T09:00:00Z", "archived": "", "author": "u1",
+			"expand": {"author": {"id": "u1", "Joined": "2023-01-01 00:00:00.000Z"}}}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+
+	type user struct {
+		ID     string `json:"id"`
+		Joined time.Time
+	}
+	type post struct {
+		ID        string     `json:"id"`
+		Published time.Time  `json:"published"`
+		Day       *time.Time `pb:"day"`
+		Created   time.Time  `json:"created"`
+		Archived  *time.Time `json:"archived"`
+		Author    user       `json:"-" pb:"relation=author"`
+	}
+	_, err := CollectionSet[post](client, "posts").One("p1")
+	require.Error(t, err, "encoding/json only decodes RFC 3339")
+
+	posts := CollectionSet[post](client.Clone(WithTimeLayouts(DateTimeLayout, "2006-01-02"), WithStrictDecoding()), "posts")
+	one, err := posts.One("p1")
+	require.NoError(t, err)
+	day := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, post{
+		ID:        "p1",
+		Published: time.Date(2024, 3, 1, 10, 0, 0, 123e6, time.UTC),
+		Day:       &day,
+		Created:   time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		Author:    user{ID: "u1", Joined: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, one, "the layouts decode the fields, the empty ones as nil, kept by the later options")
+
+	_, err = posts.Create(post{ID: "p2", Published: time.Date(2024, 3, 1, 11, 0, 0, 0, time.FixedZone("CET", 3600)), Day: &day})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"id":        "p2",
+		"published": "2024-03-01 10:00:00.000Z",
+		"day":       "2024-03-02 00:00:00.000Z",
+		"created":   "",
+		"archived":  nil,
+	}, sent, "the times are sent in the output layout")
+
+	_, err = CollectionSet[post](client.Clone(WithTimeLayouts("", time.DateOnly)), "posts").One("p1")
+	assert.ErrorContains(t, err, "can't decode time field published")
+}
+
+func TestClient_TimeLayouts_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	client := NewClient(defaultURL,
+		WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword),
+		WithTimeLayouts(DateTimeLayout))
+	type post struct {
+		ID      string    `json:"id,omitempty"`
+		Field   string    `json:"field"`
+		Created time.Time `json:"created,omitempty"`
+		Updated time.Time `json:"updated,omitempty"`
+	}
+	posts := CollectionSet[post](client, migrations.PostsPublic)
+
+	before := time.Now().Add(-time.Minute)
+	created, err := posts.Create(post{Field: "times"})
+	require.NoError(t, err)
+	defer func() {
+		_ = posts.Delete(created.ID)
+	}()
+	one, err := posts.One(created.ID)
+	require.NoError(t, err)
+	assert.True(t, one.Created.After(before), "created %s", one.Created)
+	assert.Equal(t, one.Created, one.Updated)
+}
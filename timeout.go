@@ -0,0 +1,67 @@
+package pocketbase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type (
+	callKey struct{}
+
+	// callBound is the call timeout of the requests of a call, nil for the unbounded calls.
+	callBound struct {
+		cancel context.CancelFunc
+		// request reports whether the bound was set by boundRequest, for a call of a single request.
+		request bool
+	}
+)
+
+// WithCallTimeout bounds every SDK call of the client by d, its retries and the pages of
+// FullList included, unlike WithTimeout bounding each HTTP attempt. Clones give different
+// limits to different calls:
+//
+//	client := pocketbase.NewClient(url, pocketbase.WithCallTimeout(5*time.Second))
+//	exports := client.Clone(pocketbase.WithCallTimeout(10 * time.Minute))
+//	records, err := exports.FullList("posts", pocketbase.ParamsList{})
+//
+// The bound ends the call with context.DeadlineExceeded. Realtime subscriptions aren't bounded.
+func WithCallTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.callTimeout = d
+	}
+}
+
+// callContext returns ctx bounded by the call timeout for a call made of several requests,
+// unless it is already bounded.
+func (c *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.callTimeout <= 0 || ctx.Value(callKey{}) != nil {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.callTimeout)
+	return context.WithValue(ctx, callKey{}, &callBound{cancel: cancel}), cancel
+}
+
+// unboundedContext returns ctx exempted from the call timeout, e.g. for realtime streams.
+func unboundedContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, callKey{}, (*callBound)(nil))
+}
+
+// boundRequest is a request middleware bounding the requests made outside of a bounded call
+// by the call timeout, from their first attempt.
+func (c *Client) boundRequest(_ *resty.Client, r *resty.Request) error {
+	if c.callTimeout <= 0 || r.Context().Value(callKey{}) != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), c.callTimeout)
+	r.SetContext(context.WithValue(ctx, callKey{}, &callBound{cancel: cancel, request: true}))
+	return nil
+}
+
+// releaseRequest releases the bound set by boundRequest once the request completed.
+func releaseRequest(r *resty.Request) {
+	if bound, ok := r.Context().Value(callKey{}).(*callBound); ok && bound != nil && bound.request {
+		bound.cancel()
+	}
+}
@@ -0,0 +1,41 @@
+package pocketbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCallTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(40 * time.Millisecond)
+		_, _ = fmt.Fprintf(w, `{"page": %s, "perPage": 1, "totalItems": 3, "totalPages": 3, "items": [{"id": "r"}]}`, r.URL.Query().Get("page"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	bounded := client.Clone(WithCallTimeout(100 * time.Millisecond))
+
+	// every page is within the bound, the whole list isn't
+	_, err := bounded.List("posts", ParamsList{Page: 1})
+	require.NoError(t, err)
+	_, err = bounded.FullList("posts", ParamsList{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, next, err := CollectionSet[map[string]any](bounded, "posts").FullListPartial(context.Background(), ParamsList{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, next)
+	assert.Equal(t, 3, next.Params.Page)
+
+	records, err := client.FullList("posts", ParamsList{})
+	require.NoError(t, err)
+	assert.Len(t, records.Items, 3)
+
+	_, err = client.Clone(WithCallTimeout(10*time.Millisecond)).One("posts", "r")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
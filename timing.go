@@ -0,0 +1,62 @@
+package pocketbase
+
+import (
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type (
+	// Timing is the breakdown of the duration of an SDK call, telling the latency of the
+	// network from the latency of PocketBase. The phases are traced on the last attempt
+	// of the call, and are zero when it didn't reach them, e.g. DNSLookup, Connect and
+	// TLSHandshake with a reused connection.
+	Timing struct {
+		// Attempts is the number of attempts of the call, more than 1 when it was retried.
+		Attempts int
+		// DNSLookup is the duration of the DNS lookup of the host.
+		DNSLookup time.Duration
+		// Connect is the duration of the TCP connection.
+		Connect time.Duration
+		// TLSHandshake is the duration of the TLS handshake.
+		TLSHandshake time.Duration
+		// Server is the duration from the connection to the first byte of the response,
+		// mostly spent by PocketBase handling the request.
+		Server time.Duration
+		// Transfer is the duration from the first to the last byte of the response.
+		Transfer time.Duration
+		// Total is the duration of the call, including the retries and their backoff.
+		Total time.Duration
+		// ConnReused reports whether the connection was reused from the pool.
+		ConnReused bool
+	}
+
+	// TimingHook is called after every SDK call with its timing and error, e.g. to feed
+	// latency histograms by phase. Error responses are reported as errors wrapping
+	// ErrInvalidResponse.
+	TimingHook func(op Operation, timing Timing, err error)
+)
+
+// WithTimingHook adds a hook called after every SDK call with its timing. It enables the
+// tracing of the requests of the client.
+func WithTimingHook(hook TimingHook) ClientOption {
+	return func(c *Client) {
+		c.timingHooks = append(c.timingHooks, hook)
+		c.client.EnableTrace()
+	}
+}
+
+// newTiming returns the timing of a request from its trace.
+func newTiming(r *resty.Request, total time.Duration) Timing {
+	trace := r.TraceInfo()
+	return Timing{
+		Attempts:     max(r.Attempt, 1),
+		DNSLookup:    max(trace.DNSLookup, 0),
+		Connect:      max(trace.TCPConnTime, 0),
+		TLSHandshake: max(trace.TLSHandshake, 0),
+		Server:       max(trace.ServerTime, 0),
+		Transfer:     max(trace.ResponseTime, 0),
+		Total:        total,
+		ConnReused:   trace.IsConnReused,
+	}
+}
@@ -0,0 +1,74 @@
+package pocketbase
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimingHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"page": 1, "perPage": 30, "totalItems": 0, "totalPages": 0, "items": []}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	type call struct {
+		op     Operation
+		timing Timing
+		err    error
+	}
+	var (
+		mu    sync.Mutex
+		calls []call
+	)
+	hook := func(op Operation, timing Timing, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call{op, timing, err})
+	}
+	client := NewClient(srv.URL, WithRetry(0, 0, 0), WithTimingHook(hook))
+
+	_, err := client.List("posts", ParamsList{})
+	require.NoError(t, err)
+	_, err = client.List("posts", ParamsList{})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, calls, 2)
+	first, second := calls[0], calls[1]
+	assert.Equal(t, Operation{"posts", "list", http.MethodGet, "/api/collections/posts/records"}, first.op)
+	assert.NoError(t, first.err)
+	assert.Equal(t, 1, first.timing.Attempts)
+	assert.False(t, first.timing.ConnReused)
+	assert.Positive(t, first.timing.Connect)
+	assert.GreaterOrEqual(t, first.timing.Server, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, first.timing.Total, first.timing.Server)
+	assert.True(t, second.timing.ConnReused)
+	assert.Zero(t, second.timing.Connect)
+	assert.GreaterOrEqual(t, second.timing.Server, 20*time.Millisecond)
+}
+
+func TestWithTimingHook_Retries(t *testing.T) {
+	srv, attempts := newFailingServer(t)
+	var timings []Timing
+	client := NewClient(srv.URL, WithRetry(2, time.Millisecond, time.Millisecond), WithTimingHook(func(_ Operation, timing Timing, err error) {
+		assert.Error(t, err)
+		timings = append(timings, timing)
+	}))
+
+	_, err := client.List("posts", ParamsList{})
+	assert.Error(t, err)
+	require.Len(t, timings, 1)
+	assert.Equal(t, int(attempts.Load()), timings[0].Attempts)
+	assert.Equal(t, 3, timings[0].Attempts)
+	assert.Zero(t, timings[0].Transfer)
+}
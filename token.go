@@ -0,0 +1,66 @@
+package pocketbase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned when a token can't be decoded as a PocketBase JWT.
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenClaims holds the claims of a PocketBase auth token.
+type TokenClaims struct {
+	RecordID     string
+	CollectionID string
+	// Type is the token type, e.g. "auth", "file" or "verification".
+	Type        string
+	Refreshable bool
+	ExpiresAt   time.Time
+}
+
+// IsExpired reports whether the token is expired at the time of the call.
+func (t TokenClaims) IsExpired() bool {
+	return !t.ExpiresAt.IsZero() && !time.Now().Before(t.ExpiresAt)
+}
+
+// ParseToken decodes the claims of a PocketBase token.
+//
+// The signature is NOT verified, so the claims must not be used for authorization
+// decisions - only for displaying session info or deciding when to refresh.
+func ParseToken(token string) (TokenClaims, error) {
+	var claims TokenClaims
+
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "), ".")
+	if len(parts) != 3 {
+		return claims, fmt.Errorf("[token] expected 3 token parts, got %d, err %w", len(parts), ErrInvalidToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims, fmt.Errorf("[token] can't decode token payload: %w, err %w", err, ErrInvalidToken)
+	}
+
+	var raw struct {
+		ID           string  `json:"id"`
+		CollectionID string  `json:"collectionId"`
+		Type         string  `json:"type"`
+		Refreshable  bool    `json:"refreshable"`
+		Exp          float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return claims, fmt.Errorf("[token] can't unmarshal token payload: %w, err %w", err, ErrInvalidToken)
+	}
+
+	claims.RecordID = raw.ID
+	claims.CollectionID = raw.CollectionID
+	claims.Type = raw.Type
+	claims.Refreshable = raw.Refreshable
+	if raw.Exp > 0 {
+		claims.ExpiresAt = time.Unix(int64(raw.Exp), 0)
+	}
+	return claims, nil
+}
@@ -1,7 +1,10 @@
 package pocketbase
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -10,58 +13,86 @@ import (
 
 type authorizeToken struct {
 	client      *resty.Client
+	clock       *serverClock
 	url         string
+	mu          sync.RWMutex
 	token       string
 	tokenValid  time.Time
+	model       map[string]any
 	tokenSingle singleflight.Group
 }
 
-func newAuthorizeToken(c *resty.Client, url string, token string) authStore {
-	c.SetHeader("Authorization", token)
+func newAuthorizeToken(c *resty.Client, clock *serverClock, url string, token string) AuthStore {
 	return &authorizeToken{
 		client:      c,
+		clock:       clock,
 		url:         url,
 		token:       token,
 		tokenSingle: singleflight.Group{},
 	}
 }
 
-func (a *authorizeToken) authorize() error {
+func (a *authorizeToken) Authorize() error {
 	type authResponse struct {
-		Token string `json:"token"`
+		Token  string         `json:"token"`
+		Record map[string]any `json:"record"`
 	}
 	_, err, _ := a.tokenSingle.Do("auth-refresh", func() (interface{}, error) {
-		if time.Now().Before(a.tokenValid) {
+		if a.IsValid() {
 			return nil, nil
 		}
+		if a.Token() == "" {
+			return nil, fmt.Errorf("[auth-refresh] can't refresh an empty token, err %w", ErrNotAuthenticated)
+		}
 		resp, err := a.client.R().
+			SetContext(retrySafe(context.Background())).
 			SetHeader("Content-Type", "application/json").
-			SetHeader("Authorization", a.token).
+			SetHeader("Authorization", a.Token()).
 			SetResult(&authResponse{}).
 			Post(a.url)
 		if err != nil {
 			return nil, fmt.Errorf("[auth-refresh] can't send request to pocketbase %w", err)
 		}
 		if resp.IsError() {
-			return nil, fmt.Errorf("[auth-refresh] pocketbase returned status: %d, msg: %s, err %w",
+			return nil, newAPIError(resp, fmt.Errorf("[auth-refresh] pocketbase returned status: %d, msg: %s, err %w",
 				resp.StatusCode(),
-				resp.String(),
+				responseBody(resp),
 				ErrInvalidResponse,
-			)
+			))
 		}
 		auth := *resp.Result().(*authResponse)
+		a.mu.Lock()
 		a.token = auth.Token
-		a.client.SetHeader("Authorization", auth.Token)
-		a.tokenValid = time.Now().Add(60 * time.Minute)
+		a.model = auth.Record
+		a.tokenValid = a.clock.tokenValidUntil(auth.Token)
+		a.mu.Unlock()
 		return nil, nil
 	})
 	return err
 }
 
 func (a *authorizeToken) IsValid() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return time.Now().Before(a.tokenValid)
 }
 
 func (a *authorizeToken) Token() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return a.token
 }
+
+func (a *authorizeToken) Model() map[string]any {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return maps.Clone(a.model)
+}
+
+func (a *authorizeToken) Clear() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+	a.model = nil
+	a.tokenValid = time.Time{}
+}
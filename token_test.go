@@ -0,0 +1,91 @@
+package pocketbase
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testToken(payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestParseToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    TokenClaims
+		expired bool
+		wantErr bool
+	}{
+		{
+			name:  "auth token",
+			token: testToken(`{"id":"r1","collectionId":"c1","type":"auth","refreshable":true,"exp":4102444800}`),
+			want: TokenClaims{
+				RecordID:     "r1",
+				CollectionID: "c1",
+				Type:         "auth",
+				Refreshable:  true,
+				ExpiresAt:    time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "expired bearer token",
+			token:   "Bearer " + testToken(`{"id":"r1","type":"file","exp":946684800}`),
+			want:    TokenClaims{RecordID: "r1", Type: "file", ExpiresAt: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expired: true,
+		},
+		{
+			name:    "missing parts",
+			token:   "abc.def",
+			wantErr: true,
+		},
+		{
+			name:    "invalid base64",
+			token:   "abc.!!!.def",
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			token:   testToken(`not json`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseToken(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.RecordID, got.RecordID)
+			assert.Equal(t, tt.want.CollectionID, got.CollectionID)
+			assert.Equal(t, tt.want.Type, got.Type)
+			assert.Equal(t, tt.want.Refreshable, got.Refreshable)
+			assert.True(t, tt.want.ExpiresAt.Equal(got.ExpiresAt))
+			assert.Equal(t, tt.expired, got.IsExpired())
+		})
+	}
+}
+
+func TestParseToken_AuthStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	c := NewClient(defaultURL, WithUserEmailPassword(migrations.UserEmailPassword, migrations.UserEmailPassword))
+	require.NoError(t, c.Authorize())
+
+	claims, err := ParseToken(c.AuthStore().Token())
+	require.NoError(t, err)
+	assert.Equal(t, "auth", claims.Type)
+	assert.NotEmpty(t, claims.RecordID)
+	assert.NotEmpty(t, claims.CollectionID)
+	assert.True(t, claims.Refreshable)
+	assert.False(t, claims.IsExpired())
+}
@@ -0,0 +1,54 @@
+package pocketbase
+
+import (
+	"fmt"
+	"maps"
+)
+
+// NewUser describes an auth record created by AuthCollection.CreateUser.
+type NewUser struct {
+	Email    string
+	Password string
+	// Verified marks the record as verified, only superusers are allowed to set it.
+	Verified        bool
+	EmailVisibility bool
+	// SendVerification triggers the verification email after the record is created.
+	// It is ignored for records created as verified.
+	SendVerification bool
+	// Fields holds additional collection fields, e.g. "name".
+	Fields map[string]any
+}
+
+// Users returns the default "users" auth collection.
+func (c *Client) Users() *AuthCollection[map[string]any] {
+	return AuthCollectionSet[map[string]any](c, "users")
+}
+
+// CreateUser creates a new auth record, taking care of the password confirmation
+// and the optional verification flow.
+func (c *AuthCollection[T]) CreateUser(user NewUser) (ResponseCreate[T], error) {
+	body := map[string]any{}
+	maps.Copy(body, user.Fields)
+	body["email"] = user.Email
+	body["password"] = user.Password
+	body["passwordConfirm"] = user.Password
+	if user.EmailVisibility {
+		body["emailVisibility"] = true
+	}
+	if user.Verified {
+		body["verified"] = true
+	}
+
+	var response ResponseCreate[T]
+	if err := c.Client.create(c.Name, body, &response); err != nil {
+		return response, err
+	}
+
+	if user.SendVerification && !user.Verified {
+		if err := c.RequestVerification(user.Email); err != nil {
+			return response, fmt.Errorf("[users] record %s was created, but the verification request failed, err %w", response.ID, err)
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,91 @@
+package pocketbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthCollection_CreateUser(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	admin := NewClient(defaultURL, WithAdminEmailPassword(migrations.AdminEmailPassword, migrations.AdminEmailPassword))
+	suffix := time.Now().Format("20060102150405.000000")
+
+	tests := []struct {
+		name     string
+		client   *Client
+		user     NewUser
+		verified bool
+		wantErr  bool
+	}{
+		{
+			name:   "self registration with verification email",
+			client: NewClient(defaultURL),
+			user: NewUser{
+				Email:            "self_" + suffix + "@user.com",
+				Password:         "password_" + suffix,
+				SendVerification: true,
+				Fields:           map[string]any{"name": "Self"},
+			},
+		},
+		{
+			name:   "verified by superuser",
+			client: admin,
+			user: NewUser{
+				Email:    "verified_" + suffix + "@user.com",
+				Password: "password_" + suffix,
+				Verified: true,
+			},
+			verified: true,
+		},
+		{
+			name:   "verified flag without superuser",
+			client: NewClient(defaultURL),
+			user: NewUser{
+				Email:    "not_allowed_" + suffix + "@user.com",
+				Password: "password_" + suffix,
+				Verified: true,
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid email",
+			client: NewClient(defaultURL),
+			user: NewUser{
+				Email:    "invalid",
+				Password: "password_" + suffix,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.client.Users().CreateUser(tt.user)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, r.ID)
+			defer func() {
+				_ = admin.Delete("users", r.ID)
+			}()
+
+			record, err := admin.One("users", r.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.verified, record["verified"])
+			for k, v := range tt.user.Fields {
+				assert.Equal(t, v, record[k])
+			}
+
+			auth, err := NewClient(defaultURL).Users().AuthWithPassword(tt.user.Email, tt.user.Password)
+			require.NoError(t, err)
+			assert.Equal(t, r.ID, auth.Record.ID)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package pocketbase
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrInvalidRecord is wrapped by the ValidationError of a body failing local validation.
+var ErrInvalidRecord = errors.New("invalid record")
+
+// Validator validates the body of a record before it is created or updated in the collection,
+// e.g. with a validation library. The returned error is returned by Create and Update.
+type Validator func(collection string, body any) error
+
+// WithValidator registers a validator run before every Create and Update, after the
+// validate tags of the body when enabled by WithTagValidation.
+func WithValidator(v Validator) ClientOption {
+	return func(c *Client) {
+		c.validators = append(c.validators, v)
+	}
+}
+
+// WithTagValidation validates the bodies of Create and Update with their validate tags, see
+// ValidateStruct. The required rule isn't checked on the bodies of updates, which may leave
+// fields empty.
+func WithTagValidation() ClientOption {
+	return func(c *Client) {
+		c.tagValidation = true
+	}
+}
+
+// fieldRule is the validation of a struct field, parsed from its validate tag.
+type fieldRule struct {
+	index    []int
+	name     string
+	required bool
+	email    bool
+	min, max *float64
+	pattern  *regexp.Regexp
+}
+
+// fieldRulesCache caches the field rules, or their parsing error, by struct type.
+var fieldRulesCache sync.Map
+
+type fieldRules struct {
+	rules []fieldRule
+	err   error
+}
+
+// validate validates the body with its validate tags, when enabled, and the registered
+// validators. The partial bodies of updates aren't checked for required fields.
+func (c *Client) validate(collection string, body any, partial bool) error {
+	if c.tagValidation {
+		if err := validateStruct(body, partial); err != nil {
+			return err
+		}
+	}
+	for _, v := range c.validators {
+		if err := v(collection, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateStruct validates the fields of a struct, or pointer to a struct, with their validate
+// tags, like Create and Update do before sending it with WithTagValidation. The rules are
+// separated by commas:
+//
+//	Title string `json:"title" validate:"required,min=3,max=100"`
+//	Email string `json:"email" validate:"email"`
+//	Slug  string `json:"slug" validate:"pattern=^[a-z0-9-]+$"`
+//
+// min and max bound the length of strings, slices and maps and the value of numbers.
+// pattern must be the last rule, as the expression may contain commas. Empty values
+// are only checked by required. The failures are returned as a *ValidationError
+// wrapping ErrInvalidRecord, keyed by the JSON names of the fields. Other values are valid.
+func ValidateStruct(v any) error {
+	return validateStruct(v, false)
+}
+
+// validateStruct validates the struct, without the required rules when it is partial.
+func validateStruct(v any, partial bool) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	rules, err := structRules(rv.Type())
+	if err != nil {
+		return err
+	}
+	fields := map[string]FieldError{}
+	for _, rule := range rules {
+		if partial {
+			rule.required = false
+		}
+		value, ok := fieldByIndex(rv, rule.index)
+		if ok {
+			if failure, failed := rule.check(value); failed {
+				fields[rule.name] = failure
+			}
+		} else if rule.required {
+			fields[rule.name] = FieldError{Code: "validation_required", Message: "Cannot be blank."}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &ValidationError{
+		Message: "Failed to validate record.",
+		Fields:  fields,
+		err:     fmt.Errorf("[validate] invalid fields %s, err %w", strings.Join(names, ", "), ErrInvalidRecord),
+	}
+}
+
+// fieldByIndex returns the field of the struct, false when it is in a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// structRules returns the rules of the fields of the struct type.
+func structRules(t reflect.Type) ([]fieldRule, error) {
+	if cached, ok := fieldRulesCache.Load(t); ok {
+		r := cached.(fieldRules)
+		return r.rules, r.err
+	}
+
+	var rules []fieldRule
+	var err error
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("validate")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		var rule fieldRule
+		if rule, err = parseFieldRule(f, tag); err != nil {
+			break
+		}
+		rules = append(rules, rule)
+	}
+	fieldRulesCache.Store(t, fieldRules{rules: rules, err: err})
+	return rules, err
+}
+
+// parseFieldRule parses the validate tag of the field.
+func parseFieldRule(f reflect.StructField, tag string) (fieldRule, error) {
+	rule := fieldRule{index: f.Index, name: f.Name}
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		rule.name = name
+	}
+
+	for tag != "" {
+		var option string
+		if strings.HasPrefix(strings.TrimSpace(tag), "pattern=") {
+			option, tag = strings.TrimSpace(tag), ""
+		} else {
+			option, tag, _ = strings.Cut(tag, ",")
+			option = strings.TrimSpace(option)
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			rule.required = true
+		case "email":
+			rule.email = true
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return rule, fmt.Errorf("[validate] invalid %s of field %s, err %w", key, f.Name, err)
+			}
+			if key == "min" {
+				rule.min = &n
+			} else {
+				rule.max = &n
+			}
+		case "pattern":
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				return rule, fmt.Errorf("[validate] invalid pattern of field %s, err %w", f.Name, err)
+			}
+			rule.pattern = pattern
+		case "":
+		default:
+			return rule, fmt.Errorf("[validate] unknown rule %q of field %s", key, f.Name)
+		}
+	}
+	return rule, nil
+}
+
+// check validates the value of the field, returning the failure of the first broken rule.
+func (r fieldRule) check(v reflect.Value) (FieldError, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+	if v.IsZero() || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+		if r.required {
+			return FieldError{Code: "validation_required", Message: "Cannot be blank."}, true
+		}
+		return FieldError{}, false
+	}
+
+	var length, number float64
+	isNumber := false
+	switch v.Kind() {
+	case reflect.String:
+		length = float64(utf8.RuneCountInString(v.String()))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		length = float64(v.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, isNumber = float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, isNumber = float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		number, isNumber = v.Float(), true
+	}
+
+	switch {
+	case isNumber && r.min != nil && number < *r.min:
+		return FieldError{Code: "validation_min_number_constraint", Message: fmt.Sprintf("Must be larger than %g.", *r.min)}, true
+	case isNumber && r.max != nil && number > *r.max:
+		return FieldError{Code: "validation_max_number_constraint", Message: fmt.Sprintf("Must be less than %g.", *r.max)}, true
+	case !isNumber && r.min != nil && length < *r.min:
+		return FieldError{Code: "validation_length_too_short", Message: fmt.Sprintf("The length must be at least %g.", *r.min)}, true
+	case !isNumber && r.max != nil && length > *r.max:
+		return FieldError{Code: "validation_length_too_long", Message: fmt.Sprintf("The length must be at most %g.", *r.max)}, true
+	}
+
+	if v.Kind() != reflect.String {
+		return FieldError{}, false
+	}
+	s := v.String()
+	if r.email {
+		if address, err := mail.ParseAddress(s); err != nil || address.Address != s {
+			return FieldError{Code: "validation_is_email", Message: "Must be a valid email address."}, true
+		}
+	}
+	if r.pattern != nil && !r.pattern.MatchString(s) {
+		return FieldError{Code: "validation_match_invalid", Message: "Invalid value format."}, true
+	}
+	return FieldError{}, false
+}
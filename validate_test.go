@@ -0,0 +1,119 @@
+package pocketbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedPost struct {
+	Title string   `json:"title" validate:"required,min=3,max=5"`
+	Email string   `json:"email" validate:"email"`
+	Slug  string   `json:"slug" validate:"pattern=^[a-z]{1,3}$"`
+	Views int      `json:"views" validate:"min=1,max=10"`
+	Tags  []string `validate:"max=1"`
+	Note  *string  `json:"note" validate:"required"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	note := "draft"
+	err := ValidateStruct(&validatedPost{
+		Title: "ab",
+		Email: "Ann <ann@example.com>",
+		Slug:  "a,b",
+		Views: 11,
+		Tags:  []string{"a", "b"},
+	})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+	assert.Equal(t, map[string]string{
+		"title": "validation_length_too_short",
+		"email": "validation_is_email",
+		"slug":  "validation_match_invalid",
+		"views": "validation_max_number_constraint",
+		"Tags":  "validation_length_too_long",
+		"note":  "validation_required",
+	}, codes(verr.Fields))
+
+	assert.NoError(t, ValidateStruct(validatedPost{Title: "abc", Email: "ann@example.com", Slug: "ab", Note: &note}))
+	assert.NoError(t, ValidateStruct(map[string]any{"title": ""}))
+	assert.NoError(t, ValidateStruct((*validatedPost)(nil)))
+
+	err = ValidateStruct(struct {
+		Title string `validate:"size=1"`
+	}{})
+	assert.ErrorContains(t, err, `unknown rule "size"`)
+}
+
+func codes(fields map[string]FieldError) map[string]string {
+	result := map[string]string{}
+	for name, f := range fields {
+		result[name] = f.Code
+	}
+	return result
+}
+
+func TestClient_Validator(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+	var validated []string
+	client := NewClient("http://127.0.0.1:1", WithTagValidation(), WithValidator(func(collection string, body any) error {
+		validated = append(validated, collection)
+		return errForbidden
+	}))
+
+	_, err := client.Create(migrations.PostsPublic, validatedPost{})
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+	assert.Empty(t, validated)
+
+	_, err = client.Create(migrations.PostsPublic, map[string]any{"field": "value"})
+	assert.ErrorIs(t, err, errForbidden)
+	err = client.Update(migrations.PostsPublic, "id", map[string]any{"field": "value"})
+	assert.ErrorIs(t, err, errForbidden)
+	assert.Equal(t, []string{migrations.PostsPublic, migrations.PostsPublic}, validated)
+}
+
+func TestWithTagValidation(t *testing.T) {
+	type post struct {
+		Title string `json:"title" validate:"required,max=3"`
+	}
+	type playground struct {
+		Views int    `json:"views" validate:"gte=1,omitempty"`
+		Kind  string `json:"kind" validate:"oneof=a b"`
+	}
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id": "p1"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL, WithRetry(0, 0, 0))
+	_, err := client.Create("posts", playground{})
+	require.NoError(t, err, "the tags aren't validated by default")
+	_, err = client.Create("posts", post{Title: "long"})
+	require.NoError(t, err)
+
+	validating := NewClient(srv.URL, WithRetry(0, 0, 0), WithTagValidation())
+	_, err = validating.Create("posts", post{Title: "long"})
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+	_, err = validating.Create("posts", post{})
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+	require.NoError(t, validating.Update("posts", "p1", post{}), "the updates may leave required fields empty")
+	assert.ErrorIs(t, validating.Update("posts", "p1", post{Title: "long"}), ErrInvalidRecord)
+
+	batch := validating.Batch()
+	batch.Update("posts", "p1", post{})
+	batch.Create("posts", post{})
+	_, err = batch.Send(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+	assert.Equal(t, []string{http.MethodPost, http.MethodPost, http.MethodPatch}, sent)
+}
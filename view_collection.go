@@ -0,0 +1,52 @@
+package pocketbase
+
+// ViewCollection is a read-only wrapper around a PocketBase view collection.
+//
+// It only exposes the read and realtime methods of Collection, so attempts to
+// mutate a view collection are caught at compile time.
+type ViewCollection[T any] struct {
+	collection *Collection[T]
+}
+
+// ViewCollectionSet creates a new type-safe, read-only wrapper for the specified view collection.
+func ViewCollectionSet[T any](client *Client, collection string) *ViewCollection[T] {
+	return &ViewCollection[T]{
+		collection: CollectionSet[T](client, collection),
+	}
+}
+
+// Name returns the name of the view collection.
+func (v *ViewCollection[T]) Name() string {
+	return v.collection.Name
+}
+
+// List retrieves a paginated list of records from the view collection.
+func (v *ViewCollection[T]) List(params ParamsList) (ResponseList[T], error) {
+	return v.collection.List(params)
+}
+
+// FullList retrieves all records from the view collection without pagination.
+func (v *ViewCollection[T]) FullList(params ParamsList) (ResponseList[T], error) {
+	return v.collection.FullList(params)
+}
+
+// One retrieves a single record from the view collection by ID.
+func (v *ViewCollection[T]) One(id string) (T, error) {
+	return v.collection.One(id)
+}
+
+// OneWithParams retrieves a single record from the view collection by ID with additional parameters.
+// Only fields and expand parameters are supported.
+func (v *ViewCollection[T]) OneWithParams(id string, params ParamsList) (T, error) {
+	return v.collection.OneWithParams(id, params)
+}
+
+// Subscribe creates a real-time subscription to the view collection with default options.
+func (v *ViewCollection[T]) Subscribe(targets ...string) (*Stream[T], error) {
+	return v.collection.Subscribe(targets...)
+}
+
+// SubscribeWith creates a real-time subscription to the view collection with custom options.
+func (v *ViewCollection[T]) SubscribeWith(opts SubscribeOptions, targets ...string) (*Stream[T], error) {
+	return v.collection.SubscribeWith(opts, targets...)
+}
@@ -0,0 +1,52 @@
+package pocketbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Forty2Co/pocketbase/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCollection_Read(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	type post struct {
+		ID    string `json:"id"`
+		Field string `json:"field"`
+	}
+
+	client := NewClient(defaultURL)
+	field := "value_" + time.Now().Format(time.StampMilli)
+	created, err := client.Create(migrations.PostsPublic, map[string]any{"field": field})
+	require.NoError(t, err)
+	defer func() {
+		_ = client.Delete(migrations.PostsPublic, created.ID)
+	}()
+
+	view := ViewCollectionSet[post](client, migrations.PostsView)
+	assert.Equal(t, migrations.PostsView, view.Name())
+
+	list, err := view.List(ParamsList{Filters: "id='" + created.ID + "'"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, field, list.Items[0].Field)
+
+	full, err := view.FullList(ParamsList{})
+	require.NoError(t, err)
+	assert.Equal(t, full.TotalItems, len(full.Items))
+
+	one, err := view.One(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, field, one.Field)
+
+	one, err = view.OneWithParams(created.ID, ParamsList{Fields: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, one.ID)
+	assert.Empty(t, one.Field)
+
+	_, err = view.One("non_existing_id")
+	assert.Error(t, err)
+}